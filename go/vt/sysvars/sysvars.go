@@ -185,6 +185,7 @@ var (
 		{Name: "foreign_key_checks", IsBoolean: true, SupportSetVar: true},
 		{Name: "group_concat_max_len", SupportSetVar: true},
 		{Name: "information_schema_stats_expiry"},
+		{Name: "lc_time_names"},
 		{Name: "max_heap_table_size", SupportSetVar: true},
 		{Name: "max_seeks_for_key", SupportSetVar: true},
 		{Name: "max_tmp_tables"},
@@ -242,7 +243,6 @@ var (
 		{Name: "div_precision_increment", SupportSetVar: true},
 		{Name: "innodb_lock_wait_timeout"},
 		{Name: "interactive_timeout"},
-		{Name: "lc_time_names"},
 		{Name: "lock_wait_timeout", SupportSetVar: true},
 		{Name: "max_allowed_packet"},
 		{Name: "max_error_count", SupportSetVar: true},
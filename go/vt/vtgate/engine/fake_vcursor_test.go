@@ -118,7 +118,6 @@ func (t *noopVCursor) HasSystemVariables() bool {
 }
 
 func (t *noopVCursor) GetSystemVariables(func(k string, v string)) {
-	panic("implement me")
 }
 
 func (t *noopVCursor) GetWarnings() []*querypb.QueryWarning {
@@ -387,8 +386,10 @@ func (f *loggingVCursor) HasSystemVariables() bool {
 	return len(f.systemVariables) > 0
 }
 
-func (f *loggingVCursor) GetSystemVariables(func(k string, v string)) {
-	panic("implement me")
+func (f *loggingVCursor) GetSystemVariables(fn func(k string, v string)) {
+	for k, v := range f.systemVariables {
+		fn(k, v)
+	}
 }
 
 func (f *loggingVCursor) SetFoundRows(u uint64) {
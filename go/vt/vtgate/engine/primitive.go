@@ -24,6 +24,7 @@ import (
 	"vitess.io/vitess/go/vt/key"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/srvtopo"
+	"vitess.io/vitess/go/vt/vtgate/evalengine"
 	"vitess.io/vitess/go/vt/vtgate/vindexes"
 
 	binlogdatapb "vitess.io/vitess/go/vt/proto/binlogdata"
@@ -251,6 +252,15 @@ func Find(isMatch Match, start Primitive) Primitive {
 	return nil
 }
 
+// newExpressionEnv returns the environment to evaluate expressions in for the
+// session of the given cursor, so that they use its collation and the system
+// variables that functions depend on, such as its time_zone.
+func newExpressionEnv(vcursor VCursor, bindVars map[string]*querypb.BindVariable) *evalengine.ExpressionEnv {
+	env := evalengine.EnvWithBindVars(bindVars, vcursor.ConnCollation())
+	vcursor.Session().GetSystemVariables(env.SetSystemVariable)
+	return env
+}
+
 // Exists traverses recursively down the Primitive tree structure, and returns true when Match returns true
 func Exists(m Match, p Primitive) bool {
 	return Find(m, p) != nil
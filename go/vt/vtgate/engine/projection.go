@@ -57,7 +57,7 @@ func (p *Projection) TryExecute(ctx context.Context, vcursor VCursor, bindVars m
 		return nil, err
 	}
 
-	env := evalengine.EnvWithBindVars(bindVars, vcursor.ConnCollation())
+	env := newExpressionEnv(vcursor, bindVars)
 	env.Fields = result.Fields
 	var resultRows []sqltypes.Row
 	for _, row := range result.Rows {
@@ -84,7 +84,7 @@ func (p *Projection) TryExecute(ctx context.Context, vcursor VCursor, bindVars m
 
 // TryStreamExecute implements the Primitive interface
 func (p *Projection) TryStreamExecute(ctx context.Context, vcursor VCursor, bindVars map[string]*querypb.BindVariable, wantfields bool, callback func(*sqltypes.Result) error) error {
-	env := evalengine.EnvWithBindVars(bindVars, vcursor.ConnCollation())
+	env := newExpressionEnv(vcursor, bindVars)
 	var once sync.Once
 	var fields []*querypb.Field
 	return vcursor.StreamExecutePrimitive(ctx, p.Input, bindVars, wantfields, func(qr *sqltypes.Result) error {
@@ -132,7 +132,7 @@ func (p *Projection) GetFields(ctx context.Context, vcursor VCursor, bindVars ma
 	if err != nil {
 		return nil, err
 	}
-	env := evalengine.EnvWithBindVars(bindVars, vcursor.ConnCollation())
+	env := newExpressionEnv(vcursor, bindVars)
 	err = p.addFields(env, qr)
 	if err != nil {
 		return nil, err
@@ -120,3 +120,30 @@ func TestHexAndBinaryArgument(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, `[[VARBINARY("\t")]]`, fmt.Sprintf("%v", qr.Rows))
 }
+
+func TestProjectionSessionTimeZone(t *testing.T) {
+	expr := &sqlparser.FuncExpr{
+		Name:  sqlparser.NewIdentifierCI("from_unixtime"),
+		Exprs: sqlparser.SelectExprs{&sqlparser.AliasedExpr{Expr: &sqlparser.Offset{V: 0}}},
+	}
+	evalExpr, err := evalengine.Translate(expr, nil)
+	require.NoError(t, err)
+	fp := &fakePrimitive{
+		results: []*sqltypes.Result{sqltypes.MakeTestResult(
+			sqltypes.MakeTestFields("a", "int64"),
+			"0",
+			"3600",
+		)},
+	}
+	proj := &Projection{
+		Cols:  []string{"ts"},
+		Exprs: []evalengine.Expr{evalExpr},
+		Input: fp,
+	}
+
+	// the time zone of the session decides the result, not the one of vtgate
+	vc := &loggingVCursor{systemVariables: map[string]string{"time_zone": "'+01:00'"}}
+	qr, err := proj.TryExecute(context.Background(), vc, map[string]*querypb.BindVariable{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, `[[DATETIME("1970-01-01 01:00:00")] [DATETIME("1970-01-01 02:00:00")]]`, fmt.Sprintf("%v", qr.Rows))
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package evalengine
 
 import (
+	"math"
 	"strings"
 
 	"golang.org/x/exp/constraints"
@@ -126,6 +127,45 @@ func divideNumericWithError(left, right eval, precise bool) (eval, error) {
 	return mathDiv_xx(v1, v2, divPrecisionIncrement)
 }
 
+func modNumericWithError(left, right eval) (eval, error) {
+	v1 := evalToNumeric(left)
+	v2 := evalToNumeric(right)
+	switch v1 := v1.(type) {
+	case *evalInt64:
+		switch v2 := v2.(type) {
+		case *evalInt64:
+			return mathMod_ii(v1.i, v2.i)
+		case *evalUint64:
+			return mathMod_iu(v1.i, v2.u)
+		case *evalFloat:
+			return mathMod_xf(v1, v2.f)
+		case *evalDecimal:
+			return mathMod_dd(v1.toDecimal(0, 0), v2)
+		}
+	case *evalUint64:
+		switch v2 := v2.(type) {
+		case *evalInt64:
+			return mathMod_ui(v1.u, v2.i)
+		case *evalUint64:
+			return mathMod_uu(v1.u, v2.u)
+		case *evalFloat:
+			return mathMod_xf(v1, v2.f)
+		case *evalDecimal:
+			return mathMod_dd(v1.toDecimal(0, 0), v2)
+		}
+	case *evalFloat:
+		return mathMod_fx(v1.f, v2)
+	case *evalDecimal:
+		switch v2 := v2.(type) {
+		case *evalFloat:
+			return mathMod_xf(v1, v2.f)
+		default:
+			return mathMod_dd(v1, v2.toDecimal(0, 0))
+		}
+	}
+	return nil, vterrors.Errorf(vtrpcpb.Code_INTERNAL, "invalid arithmetic between: %s %s", evalToSQLValue(v1), evalToSQLValue(v2))
+}
+
 // makeNumericAndPrioritize reorders the input parameters
 // to be Float64, Decimal, Uint64, Int64.
 func makeNumericAndPrioritize(left, right eval) (evalNumeric, evalNumeric) {
@@ -414,6 +454,73 @@ func mathDiv_ff0(v1, v2 float64) (float64, error) {
 	return result, nil
 }
 
+// The remainder of a modulo always has the sign of the dividend, and a
+// modulo by zero is NULL.
+
+func mathMod_ii(v1, v2 int64) (eval, error) {
+	if v2 == 0 {
+		return nil, nil
+	}
+	return newEvalInt64(v1 % v2), nil
+}
+
+func mathMod_iu(v1 int64, v2 uint64) (eval, error) {
+	if v2 == 0 {
+		return nil, nil
+	}
+	if v1 < 0 {
+		return newEvalInt64(-int64(uint64(-v1) % v2)), nil
+	}
+	return newEvalInt64(int64(uint64(v1) % v2)), nil
+}
+
+func mathMod_ui(v1 uint64, v2 int64) (eval, error) {
+	if v2 == 0 {
+		return nil, nil
+	}
+	if v2 < 0 {
+		return newEvalUint64(v1 % uint64(-v2)), nil
+	}
+	return newEvalUint64(v1 % uint64(v2)), nil
+}
+
+func mathMod_uu(v1, v2 uint64) (eval, error) {
+	if v2 == 0 {
+		return nil, nil
+	}
+	return newEvalUint64(v1 % v2), nil
+}
+
+func mathMod_fx(v1 float64, v2 evalNumeric) (eval, error) {
+	v2f, ok := v2.toFloat()
+	if !ok {
+		return nil, errDecimalOutOfRange
+	}
+	return mathMod_ff(v1, v2f.f), nil
+}
+
+func mathMod_xf(v1 evalNumeric, v2 float64) (eval, error) {
+	v1f, ok := v1.toFloat()
+	if !ok {
+		return nil, errDecimalOutOfRange
+	}
+	return mathMod_ff(v1f.f, v2), nil
+}
+
+func mathMod_ff(v1, v2 float64) eval {
+	if v2 == 0 {
+		return nil
+	}
+	return newEvalFloat(math.Mod(v1, v2))
+}
+
+func mathMod_dd(v1, v2 *evalDecimal) (eval, error) {
+	if v2.dec.IsZero() {
+		return nil, nil
+	}
+	return newEvalDecimalWithPrec(v1.dec.Mod(v2.dec), maxprec(v1.length, v2.length)), nil
+}
+
 func mathSub_xf(v1 evalNumeric, v2 float64) (*evalFloat, error) {
 	v1f, ok := v1.toFloat()
 	if !ok {
@@ -17,6 +17,8 @@ limitations under the License.
 package evalengine
 
 import (
+	"bytes"
+	"strings"
 	"time"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -24,6 +26,7 @@ import (
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/decimal"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/json"
 )
 
 func compareNumeric(left, right eval) (int, error) {
@@ -200,3 +203,89 @@ func compareStrings(l, r eval) (int, error) {
 	}
 	return collation.Collate(l.(*evalBytes).bytes, r.(*evalBytes).bytes, false), nil
 }
+
+// jsonTypeRank orders the types of JSON values like MySQL does when it
+// compares values of different types: booleans are greater than arrays,
+// which are greater than objects, then strings, then numbers, and JSON
+// null is the smallest of all.
+func jsonTypeRank(t json.Type) int {
+	switch t {
+	case json.TypeTrue, json.TypeFalse:
+		return 5
+	case json.TypeArray:
+		return 4
+	case json.TypeObject:
+		return 3
+	case json.TypeString:
+		return 2
+	case json.TypeNumber:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareJSONValue compares two JSON values following MySQL's ordering of
+// JSON values. Objects only compare equal when they have the same keys and
+// values; otherwise they are ordered by size and then by their contents.
+func compareJSONValue(l, r *json.Value) int {
+	lt, rt := l.Type(), r.Type()
+	if lr, rr := jsonTypeRank(lt), jsonTypeRank(rt); lr != rr {
+		return lr - rr
+	}
+
+	switch lt {
+	case json.TypeTrue, json.TypeFalse:
+		switch {
+		case lt == rt:
+			return 0
+		case lt == json.TypeTrue:
+			return 1
+		default:
+			return -1
+		}
+	case json.TypeNumber:
+		ld, _ := decimal.NewFromString(l.Raw())
+		rd, _ := decimal.NewFromString(r.Raw())
+		return ld.Cmp(rd)
+	case json.TypeString:
+		ls, _ := l.StringBytes()
+		rs, _ := r.StringBytes()
+		return bytes.Compare(ls, rs)
+	case json.TypeArray:
+		la, _ := l.Array()
+		ra, _ := r.Array()
+		for i := 0; i < len(la) && i < len(ra); i++ {
+			if cmp := compareJSONValue(la[i], ra[i]); cmp != 0 {
+				return cmp
+			}
+		}
+		return len(la) - len(ra)
+	case json.TypeObject:
+		lo, _ := l.Object()
+		ro, _ := r.Object()
+		if lo.Len() != ro.Len() {
+			return lo.Len() - ro.Len()
+		}
+
+		var lkeys, rkeys []string
+		var lvals, rvals []*json.Value
+		lo.Visit(func(key []byte, v *json.Value) {
+			lkeys = append(lkeys, string(key))
+			lvals = append(lvals, v)
+		})
+		ro.Visit(func(key []byte, v *json.Value) {
+			rkeys = append(rkeys, string(key))
+			rvals = append(rvals, v)
+		})
+		for i := range lkeys {
+			if cmp := strings.Compare(lkeys[i], rkeys[i]); cmp != 0 {
+				return cmp
+			}
+			if cmp := compareJSONValue(lvals[i], rvals[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	return 0
+}
@@ -36,6 +36,7 @@ func (fn errJSONType) Error() string {
 }
 
 var errJSONPath = errors.New("Invalid JSON path expression.")
+var errInvalidCastToJSON = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Invalid JSON text in argument 1 to function cast_as_json.")
 
 type evalJSON = json.Value
 
@@ -47,6 +48,11 @@ func intoJSON(fn string, e eval) (*evalJSON, error) {
 	case *evalJSON:
 		return e, nil
 	case *evalBytes:
+		if sqltypes.IsDate(e.SQLType()) {
+			// temporal values are not JSON text, even if some of them look
+			// like numbers
+			return nil, errJSONType(fn)
+		}
 		var p json.Parser
 		return p.ParseBytes(e.bytes)
 	default:
@@ -54,6 +60,19 @@ func intoJSON(fn string, e eval) (*evalJSON, error) {
 	}
 }
 
+// checkJSONDocument returns an error if the JSON document built by the given
+// function is nested too deeply, or if it's larger than max_allowed_packet once
+// serialized.
+func checkJSONDocument(env *ExpressionEnv, fn string, doc *evalJSON) error {
+	if doc.Depth() > json.MaxDepth {
+		return vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "The JSON document exceeds the maximum depth of %d.", json.MaxDepth)
+	}
+	if limit := env.maxAllowedPacket(); int64(len(doc.MarshalTo(nil))) > limit {
+		return vterrors.Errorf(vtrpcpb.Code_RESOURCE_EXHAUSTED, "Result of %s() was larger than max_allowed_packet (%d) - truncated", fn, limit)
+	}
+	return nil
+}
+
 func intoJSONPath(e eval) (*json.Path, error) {
 	switch e := e.(type) {
 	case *evalBytes:
@@ -99,6 +118,25 @@ func evalConvert_cj(e *evalBytes) (*evalJSON, error) {
 	return json.NewString(jsonText), nil
 }
 
+// evalCastToJSON converts the value like CAST(... AS JSON) does. Unlike the
+// values passed to the JSON functions, which are turned into JSON strings,
+// text is parsed as a JSON document.
+func evalCastToJSON(e eval) (*evalJSON, error) {
+	if b, ok := e.(*evalBytes); ok && !sqltypes.IsBinary(b.SQLType()) && !sqltypes.IsDate(b.SQLType()) {
+		jsonText, err := charset.Convert(nil, charset.Charset_utf8mb4{}, b.bytes, b.col.Collation.Get().Charset())
+		if err != nil {
+			return nil, err
+		}
+		var p json.Parser
+		j, err := p.ParseBytes(jsonText)
+		if err != nil {
+			return nil, errInvalidCastToJSON
+		}
+		return j, nil
+	}
+	return evalToJSON(e)
+}
+
 func evalToJSON(e eval) (*evalJSON, error) {
 	switch e := e.(type) {
 	case nil:
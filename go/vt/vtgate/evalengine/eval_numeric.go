@@ -80,7 +80,7 @@ func newEvalDecimal(dec decimal.Decimal, m, d int32) *evalDecimal {
 	if m == 0 && d == 0 {
 		return newEvalDecimalWithPrec(dec, -dec.Exponent())
 	}
-	return newEvalDecimalWithPrec(dec.Clamp(m-d, d), d)
+	return newEvalDecimalWithPrec(dec.Round(d).Clamp(m-d, d), d)
 }
 
 func newEvalDecimalWithPrec(dec decimal.Decimal, prec int32) *evalDecimal {
@@ -99,6 +99,11 @@ func evalToNumeric(e eval) evalNumeric {
 	case evalNumeric:
 		return e
 	case *evalBytes:
+		if sqltypes.IsDate(e.SQLType()) {
+			if num, ok := evalTemporalToNumeric(e); ok {
+				return num
+			}
+		}
 		if e.isHexLiteral {
 			hex, ok := e.toNumericHex()
 			if !ok {
@@ -305,8 +310,17 @@ func (e *evalDecimal) negate() evalNumeric {
 }
 
 func (e *evalDecimal) toInt64() *evalInt64 {
+	// decimals are rounded half away from zero, and like in MySQL, values
+	// outside of the range of an int64 are clamped to it
 	dec := e.dec.Round(0)
-	i, _ := dec.Int64()
+	i, ok := dec.Int64()
+	if !ok {
+		if dec.Sign() < 0 {
+			i = math.MinInt64
+		} else {
+			i = math.MaxInt64
+		}
+	}
 	return newEvalInt64(i)
 }
 
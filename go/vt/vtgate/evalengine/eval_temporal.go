@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"vitess.io/vitess/go/hack"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/datetime"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/decimal"
+)
+
+// splitNumericTemporal splits the textual representation of a numeric value
+// into its integral part and its fractional part in nanoseconds, which is how
+// MySQL interprets numbers used as temporal values.
+func splitNumericTemporal(e evalNumeric) (int64, int, bool) {
+	var text string
+	switch e := e.(type) {
+	case *evalInt64:
+		return e.i, 0, true
+	case *evalUint64:
+		return int64(e.u), 0, e.u <= uint64(1<<63-1)
+	case *evalFloat:
+		text = strconv.FormatFloat(e.f, 'f', -1, 64)
+	case *evalDecimal:
+		text = e.dec.String()
+	default:
+		return 0, 0, false
+	}
+
+	integral, frac, _ := strings.Cut(text, ".")
+	i, err := strconv.ParseInt(integral, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	var nsec int
+	for n := 0; n < 9; n++ {
+		nsec *= 10
+		if n < len(frac) {
+			nsec += int(frac[n] - '0')
+		}
+	}
+	return i, nsec, true
+}
+
+// timeOnCurrentDate converts a TIME into a DATETIME like MySQL does, adding it
+// to the midnight of the current date. Negative times and times of more than
+// 24 hours fall on the days before or after it.
+func timeOnCurrentDate(env *ExpressionEnv, t datetime.Time) datetime.DateTime {
+	today := datetime.NewDateTimeFromStd(env.time()).Date
+	return datetime.NewDateTimeFromStd(today.ToStdTime(time.UTC).Add(t.ToDuration()))
+}
+
+// evalToDateTime converts the given eval into a DATETIME, parsing strings and
+// numbers leniently like MySQL does. TIME values are on the current date.
+// The boolean result is false if the eval cannot be interpreted as a datetime.
+func evalToDateTime(env *ExpressionEnv, e eval) (datetime.DateTime, bool) {
+	switch e := e.(type) {
+	case *evalBytes:
+		switch e.SQLType() {
+		case sqltypes.Time:
+			t, _, ok := datetime.ParseTime(hack.String(e.bytes))
+			if !ok {
+				return datetime.DateTime{}, false
+			}
+			return timeOnCurrentDate(env, t), true
+		default:
+			dt, _, ok := datetime.ParseDateTime(hack.String(e.bytes))
+			return dt, ok
+		}
+	case evalNumeric:
+		i, nsec, ok := splitNumericTemporal(e)
+		if !ok {
+			return datetime.DateTime{}, false
+		}
+		dt, ok := datetime.ParseDateTimeInt64(i)
+		if ok && nsec > 0 {
+			dt.Time = datetime.NewTime(false, dt.Time.Hour(), dt.Time.Minute(), dt.Time.Second(), nsec)
+		}
+		return dt, ok
+	default:
+		return datetime.DateTime{}, false
+	}
+}
+
+// evalToDateTimeToday is like evalToDateTime, but it also returns the number
+// of fractional second digits of the value.
+func evalToDateTimeToday(env *ExpressionEnv, e eval) (datetime.DateTime, int, bool) {
+	switch e := e.(type) {
+	case *evalBytes:
+		if e.SQLType() == sqltypes.Time {
+			t, prec, ok := datetime.ParseTime(e.string())
+			if !ok {
+				return datetime.DateTime{}, 0, false
+			}
+			return timeOnCurrentDate(env, t), prec, true
+		}
+		dt, prec, _, ok := datetime.ParseDateOrDateTime(e.string())
+		return dt, prec, ok
+	case evalNumeric:
+		_, nsec, _ := splitNumericTemporal(e)
+		dt, ok := evalToDateTime(env, e)
+		return dt, numericTemporalPrecision(e, nsec), ok
+	default:
+		return datetime.DateTime{}, 0, false
+	}
+}
+
+// evalToTime converts the given eval into a TIME, parsing strings and numbers
+// leniently like MySQL does. DATETIME values return their time of day, and DATE
+// values return midnight. It also returns the number of fractional second digits
+// of the value. The boolean result is false if the eval cannot be interpreted as
+// a time.
+func evalToTime(e eval) (datetime.Time, int, bool) {
+	switch e := e.(type) {
+	case *evalBytes:
+		if e.SQLType() == sqltypes.Date {
+			_, ok := datetime.ParseDate(hack.String(e.bytes))
+			return datetime.Time{}, 0, ok
+		}
+		return datetime.ParseTime(hack.String(e.bytes))
+	case evalNumeric:
+		i, nsec, ok := splitNumericTemporal(e)
+		if !ok {
+			return datetime.Time{}, 0, false
+		}
+		t, ok := datetime.ParseTimeInt64(i)
+		if !ok {
+			return datetime.Time{}, 0, false
+		}
+
+		// a fraction on top of the largest TIME would be out of range, so
+		// it's dropped like when the integer part has been clamped
+		if nsec > 0 && !(t.Hour() == datetime.MaxHours && t.Minute() == 59 && t.Second() == 59) {
+			t = datetime.NewTime(t.Neg(), t.Hour(), t.Minute(), t.Second(), nsec)
+		}
+		return t, numericTemporalPrecision(e, nsec), true
+	default:
+		return datetime.Time{}, 0, false
+	}
+}
+
+// numericTemporalPrecision returns the number of fractional second digits of
+// a temporal value given as a number, whose fractional part is nsec.
+func numericTemporalPrecision(e evalNumeric, nsec int) int {
+	var prec int
+	switch e := e.(type) {
+	case *evalDecimal:
+		prec = int(e.length)
+	case *evalFloat:
+		for frac := nsec; frac > 0; frac = (frac * 10) % 1000000000 {
+			prec++
+		}
+	}
+	if prec > datetime.DefaultPrecision {
+		prec = datetime.DefaultPrecision
+	}
+	return prec
+}
+
+// evalTemporalToNumeric returns the numeric representation that MySQL uses
+// for a temporal value in a numeric context: YYYYMMDD for dates,
+// YYYYMMDDhhmmss for datetimes and hhmmss for times. Values with fractional
+// seconds are returned as decimals. The boolean result is false if the
+// value cannot be parsed as the temporal type it claims to be.
+func evalTemporalToNumeric(e *evalBytes) (evalNumeric, bool) {
+	s := hack.String(e.bytes)
+
+	var (
+		v    int64
+		t    datetime.Time
+		prec int
+	)
+	switch e.SQLType() {
+	case sqltypes.Date:
+		d, ok := datetime.ParseDate(s)
+		if !ok {
+			return nil, false
+		}
+		return newEvalInt64(int64(d.Year())*10000 + int64(d.Month())*100 + int64(d.Day())), true
+	case sqltypes.Datetime, sqltypes.Timestamp:
+		dt, p, ok := datetime.ParseDateTime(s)
+		if !ok {
+			return nil, false
+		}
+		v = int64(dt.Date.Year())*10000 + int64(dt.Date.Month())*100 + int64(dt.Date.Day())
+		t, prec = dt.Time, p
+	case sqltypes.Time:
+		tt, p, ok := datetime.ParseTime(s)
+		if !ok {
+			return nil, false
+		}
+		t, prec = tt, p
+	default:
+		return nil, false
+	}
+
+	v = v*1000000 + int64(t.Hour())*10000 + int64(t.Minute())*100 + int64(t.Second())
+	if t.Neg() {
+		v = -v
+	}
+	if prec == 0 {
+		return newEvalInt64(v), true
+	}
+
+	frac := int64(t.Nanosecond())
+	for i := prec; i < 9; i++ {
+		frac /= 10
+	}
+	if t.Neg() {
+		frac = -frac
+	}
+	dec := decimal.NewFromInt(v).Add(decimal.New(frac, -int32(prec)))
+	return newEvalDecimalWithPrec(dec, int32(prec)), true
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package evalengine
 
 import (
+	"bytes"
+
 	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/decimal"
 )
 
 type (
@@ -39,6 +42,7 @@ type (
 	opArithSub struct{}
 	opArithMul struct{}
 	opArithDiv struct{}
+	opArithMod struct{}
 )
 
 var _ Expr = (*ArithmeticExpr)(nil)
@@ -47,22 +51,116 @@ var _ opArith = (*opArithAdd)(nil)
 var _ opArith = (*opArithSub)(nil)
 var _ opArith = (*opArithMul)(nil)
 var _ opArith = (*opArithDiv)(nil)
+var _ opArith = (*opArithMod)(nil)
 
 func (b *ArithmeticExpr) eval(env *ExpressionEnv) (eval, error) {
 	left, right, err := b.arguments(env)
 	if left == nil || right == nil || err != nil {
 		return nil, err
 	}
+	left = temporalOperand(env, b.Left, left)
+	right = temporalOperand(env, b.Right, right)
 	return b.Op.eval(left, right)
 }
 
-func makeNumericalType(t sqltypes.Type, f typeFlag) sqltypes.Type {
+// temporalPrecision returns the number of fractional second digits of the
+// values of a temporal expression, and whether it can be known without
+// evaluating the expression: literals and bind variables have the precision
+// of their value, columns the one of their field, and casts and the functions
+// that return the current time the one they're given.
+func temporalPrecision(env *ExpressionEnv, expr Expr) (int, bool) {
+	switch expr := expr.(type) {
+	case *Literal:
+		if b, ok := expr.inner.(*evalBytes); ok {
+			return fractionalDigits(b.bytes), true
+		}
+		return 0, true
+	case *BindVariable:
+		bvar, err := expr.bvar(env)
+		if err != nil {
+			return 0, true
+		}
+		return fractionalDigits(bvar.Value), true
+	case *Column:
+		if expr.Offset < len(env.Row) {
+			return fractionalDigits(env.Row[expr.Offset].Raw()), true
+		}
+		if expr.Offset < len(env.Fields) {
+			return int(env.Fields[expr.Offset].Decimals), true
+		}
+	case *ConvertExpr:
+		switch expr.Type {
+		case "DATE":
+			return 0, true
+		case "TIME", "DATETIME":
+			return expr.Length, true
+		}
+	case *builtinNow:
+		return int(expr.prec), true
+	case *builtinCurtime:
+		return int(expr.prec), true
+	case *builtinSysdate:
+		return int(expr.prec), true
+	case *builtinCurdate:
+		return 0, true
+	}
+	return 0, false
+}
+
+// fractionalDigits returns the number of digits after the decimal point in
+// the textual form of a temporal value.
+func fractionalDigits(raw []byte) int {
+	if i := bytes.IndexByte(raw, '.'); i >= 0 {
+		return len(raw) - i - 1
+	}
+	return 0
+}
+
+// temporalOperand converts the value of a time or datetime operand into a
+// decimal when its precision can only be known by evaluating it, so that
+// the type of the result does not depend on whether the value of a given
+// row has fractional seconds.
+func temporalOperand(env *ExpressionEnv, expr Expr, e eval) eval {
+	b, ok := e.(*evalBytes)
+	if !ok || !sqltypes.IsDate(b.SQLType()) || b.SQLType() == sqltypes.Date {
+		return e
+	}
+	if _, static := temporalPrecision(env, expr); static {
+		return e
+	}
+	num, ok := evalTemporalToNumeric(b)
+	if !ok {
+		return e
+	}
+	if i, ok := num.(*evalInt64); ok {
+		return newEvalDecimalWithPrec(decimal.NewFromInt(i.i), 0)
+	}
+	return num
+}
+
+// temporalNumericalType returns the type of the numeric form of a temporal
+// operand: an integer for dates and for values without fractional seconds,
+// and a decimal otherwise.
+func temporalNumericalType(env *ExpressionEnv, expr Expr, t sqltypes.Type) sqltypes.Type {
+	if t == sqltypes.Date {
+		return sqltypes.Int64
+	}
+	if prec, ok := temporalPrecision(env, expr); ok && prec == 0 {
+		return sqltypes.Int64
+	}
+	return sqltypes.Decimal
+}
+
+func makeNumericalType(env *ExpressionEnv, expr Expr, t sqltypes.Type, f typeFlag) sqltypes.Type {
 	if sqltypes.IsNumber(t) {
 		return t
 	}
 	if t == sqltypes.VarBinary && (f&flagHex) != 0 {
 		return sqltypes.Uint64
 	}
+	if sqltypes.IsDate(t) {
+		return temporalNumericalType(env, expr, t)
+	}
 	return sqltypes.Float64
 }
 
@@ -72,8 +170,8 @@ func (b *ArithmeticExpr) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 	t2, f2 := b.Right.typeof(env)
 	flags := f1 | f2
 
-	t1 = makeNumericalType(t1, f1)
-	t2 = makeNumericalType(t2, f2)
+	t1 = makeNumericalType(env, b.Left, t1, f1)
+	t2 = makeNumericalType(env, b.Right, t2, f2)
 
 	switch b.Op.(type) {
 	case *opArithDiv:
@@ -81,6 +179,13 @@ func (b *ArithmeticExpr) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 			return sqltypes.Float64, flags
 		}
 		return sqltypes.Decimal, flags
+	case *opArithMod:
+		// the remainder of two integers has the sign of the dividend, so
+		// it can only be unsigned when the dividend is
+		if sqltypes.IsIntegral(t1) && sqltypes.IsIntegral(t2) {
+			return t1, flags | flagNullable
+		}
+		flags |= flagNullable
 	}
 
 	switch t1 {
@@ -122,6 +227,11 @@ func (op *opArithDiv) eval(left, right eval) (eval, error) {
 }
 func (op *opArithDiv) String() string { return "/" }
 
+func (op *opArithMod) eval(left, right eval) (eval, error) {
+	return modNumericWithError(left, right)
+}
+func (op *opArithMod) String() string { return "%" }
+
 func (n *NegateExpr) eval(env *ExpressionEnv) (eval, error) {
 	e, err := n.Inner.eval(env)
 	if err != nil {
@@ -130,6 +240,7 @@ func (n *NegateExpr) eval(env *ExpressionEnv) (eval, error) {
 	if e == nil {
 		return nil, nil
 	}
+	e = temporalOperand(env, n.Inner, e)
 	return evalToNumeric(e).negate(), nil
 }
 
@@ -151,6 +262,8 @@ func (n *NegateExpr) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 		return sqltypes.Int64, f
 	case sqltypes.Decimal:
 		return sqltypes.Decimal, f
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp, sqltypes.Time:
+		return temporalNumericalType(env, n.Inner, tt), f
 	}
 	return sqltypes.Float64, f
 }
@@ -117,8 +117,28 @@ func (c *ConvertExpr) eval(env *ExpressionEnv) (eval, error) {
 	case "UNSIGNED", "UNSIGNED INTEGER":
 		return evalToNumeric(e).toUint64(), nil
 	case "JSON":
-		return evalToJSON(e)
-	case "DATE", "DATETIME", "YEAR", "TIME":
+		return evalCastToJSON(e)
+	case "TIME":
+		t, _, ok := evalToTime(e)
+		if !ok {
+			return nil, nil
+		}
+		t = t.Round(c.Length)
+		return newEvalRaw(sqltypes.Time, t.Format(uint8(c.Length)), collationNumeric), nil
+	case "DATE":
+		dt, _, ok := evalToDateTimeToday(env, e)
+		if !ok {
+			return nil, nil
+		}
+		return newEvalRaw(sqltypes.Date, dt.Date.Format(), collationNumeric), nil
+	case "DATETIME":
+		dt, _, ok := evalToDateTimeToday(env, e)
+		if !ok {
+			return nil, nil
+		}
+		dt = dt.Round(c.Length)
+		return newEvalRaw(sqltypes.Datetime, dt.Format(uint8(c.Length)), collationNumeric), nil
+	case "YEAR":
 		return nil, c.returnUnsupportedError()
 	default:
 		panic("BUG: sqlparser emitted unknown type")
@@ -145,7 +165,13 @@ func (c *ConvertExpr) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 		return sqltypes.Uint64, f
 	case "JSON":
 		return sqltypes.TypeJSON, f
-	case "DATE", "DATETIME", "YEAR", "TIME":
+	case "TIME":
+		return sqltypes.Time, f | flagNullable
+	case "DATE":
+		return sqltypes.Date, f | flagNullable
+	case "DATETIME":
+		return sqltypes.Datetime, f | flagNullable
+	case "YEAR":
 		return sqltypes.Null, f
 	default:
 		panic("BUG: sqlparser emitted unknown type")
@@ -17,9 +17,16 @@ limitations under the License.
 package evalengine
 
 import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/datetime"
 )
 
 type (
@@ -32,6 +39,37 @@ type (
 		// Row and Fields should line up
 		Row    []sqltypes.Value
 		Fields []*querypb.Field
+
+		// Tz is the time zone of the session, used by the temporal functions.
+		// vtgate sets it from the session's time_zone; the local time zone of
+		// the process is used when it's not set.
+		Tz *time.Location
+
+		// LcTimeNames is the locale used for the names of days and months, as
+		// set by the lc_time_names session variable, which vtgate passes on.
+		// en_US is used when it's not set or the locale is not supported.
+		LcTimeNames string
+
+		// MaxAllowedPacket is the largest value that the functions can build,
+		// in bytes. The session value of max_allowed_packet is read-only in
+		// MySQL, so vtgate never sets it and MySQL's default of 64MB is used.
+		MaxAllowedPacket int64
+
+		// now is the timestamp of the current statement
+		now time.Time
+
+		// rand holds the generators of the RAND calls with a constant seed,
+		// which return the next value of their sequence on every call during
+		// the statement
+		rand map[*builtinRand]*mysqlRand
+
+		// regexps holds the compiled patterns of the REGEXP calls with a
+		// constant pattern, which are only compiled once during the statement
+		regexps map[Expr]*regexp.Regexp
+
+		// clock returns the current wall-clock time; time.Now is used when
+		// it's not set
+		clock func() time.Time
 	}
 )
 
@@ -56,6 +94,91 @@ func (env *ExpressionEnv) collation() collations.TypedCollation {
 	}
 }
 
+// SetTime sets the timestamp of the current statement, which is returned
+// by all the functions that return the current time, such as NOW().
+func (env *ExpressionEnv) SetTime(now time.Time) {
+	env.now = now
+}
+
+// time returns the timestamp of the current statement in the session's time
+// zone. The timestamp is captured the first time that it is needed, so all the
+// calls during the same statement return the same time.
+func (env *ExpressionEnv) time() time.Time {
+	if env.now.IsZero() {
+		env.now = env.wallClock()
+	}
+	return env.now.In(env.currentTimezone())
+}
+
+// wallClock returns the actual time at the moment it's called, which is
+// what SYSDATE() returns instead of the timestamp of the statement.
+func (env *ExpressionEnv) wallClock() time.Time {
+	if env.clock != nil {
+		return env.clock()
+	}
+	return time.Now()
+}
+
+func (env *ExpressionEnv) currentTimezone() *time.Location {
+	if env.Tz == nil {
+		return time.Local
+	}
+	return env.Tz
+}
+
+// defaultMaxAllowedPacket is the default value of max_allowed_packet in MySQL.
+const defaultMaxAllowedPacket = 64 << 20
+
+func (env *ExpressionEnv) maxAllowedPacket() int64 {
+	if env.MaxAllowedPacket <= 0 {
+		return defaultMaxAllowedPacket
+	}
+	return env.MaxAllowedPacket
+}
+
+func (env *ExpressionEnv) currentLocale() *datetime.Locale {
+	if l := datetime.LookupLocale(env.LcTimeNames); l != nil {
+		return l
+	}
+	return datetime.LocaleEnUS
+}
+
+// SetSystemVariable applies a system variable of the session to the
+// environment. The value is given as the SQL literal that vtgate keeps in the
+// session for it, e.g. '+01:00' for the time_zone. Only time_zone,
+// lc_time_names and max_allowed_packet change how expressions are evaluated;
+// other variables, and values that can't be parsed, are ignored.
+func (env *ExpressionEnv) SetSystemVariable(name, value string) {
+	name = strings.ToLower(name)
+	switch name {
+	case "time_zone", "lc_time_names", "max_allowed_packet":
+	default:
+		return
+	}
+
+	expr, err := sqlparser.ParseExpr(value)
+	if err != nil {
+		return
+	}
+	lit, ok := expr.(*sqlparser.Literal)
+	if !ok {
+		return
+	}
+
+	switch name {
+	case "time_zone":
+		if tz, ok := datetime.ParseTimeZone(lit.Val); ok {
+			env.Tz = tz
+		}
+	case "lc_time_names":
+		env.LcTimeNames = lit.Val
+	case "max_allowed_packet":
+		if n, err := strconv.ParseInt(lit.Val, 10, 64); err == nil {
+			env.MaxAllowedPacket = n
+		}
+	}
+}
+
 // EmptyExpressionEnv returns a new ExpressionEnv with no bind vars or row
 func EmptyExpressionEnv() *ExpressionEnv {
 	return EnvWithBindVars(map[string]*querypb.BindVariable{}, collations.Unknown)
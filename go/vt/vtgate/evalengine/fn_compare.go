@@ -47,9 +47,22 @@ func (b *builtinCoalesce) eval(env *ExpressionEnv) (eval, error) {
 		return nil, err
 	}
 	for _, arg := range args {
-		if arg != nil {
-			return arg, nil
+		if arg == nil {
+			continue
+		}
+		// like in MySQL, the result keeps the temporal type of the arguments,
+		// but a mix of temporal types results in a DATETIME
+		switch arg.SQLType() {
+		case sqltypes.Date, sqltypes.Time, sqltypes.Timestamp:
+			if tt, _ := b.typeof(env); tt == sqltypes.Datetime {
+				dt, prec, ok := evalToDateTimeToday(env, arg)
+				if !ok {
+					return nil, nil
+				}
+				return newEvalRaw(sqltypes.Datetime, dt.Format(uint8(prec)), collationNumeric), nil
+			}
 		}
+		return arg, nil
 	}
 	return nil, nil
 }
@@ -66,14 +79,17 @@ func (b *builtinCoalesce) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 func getMultiComparisonFunc(args []eval) multiComparisonFunc {
 	var (
 		integers int
+		unsigned int
 		floats   int
 		decimals int
 		text     int
 		binary   int
+		jsons    int
 	)
 
 	/*
 		If any argument is NULL, the result is NULL. No comparison is needed.
+		If any argument is a JSON document, the rest of them are converted to JSON and they are compared as JSON values.
 		If all arguments are integer-valued, they are compared as integers.
 		If at least one argument is double precision, they are compared as double-precision values. Otherwise, if at least one argument is a DECIMAL value, they are compared as DECIMAL values.
 		If the arguments comprise a mix of numbers and strings, they are compared as strings.
@@ -92,6 +108,7 @@ func getMultiComparisonFunc(args []eval) multiComparisonFunc {
 		case *evalInt64:
 			integers++
 		case *evalUint64:
+			unsigned++
 			if arg.u > math.MaxInt64 {
 				decimals++
 			} else {
@@ -108,9 +125,17 @@ func getMultiComparisonFunc(args []eval) multiComparisonFunc {
 			case sqltypes.Blob, sqltypes.Binary, sqltypes.VarBinary:
 				binary++
 			}
+		case *evalJSON:
+			jsons++
 		}
 	}
 
+	if jsons > 0 {
+		return compareAllJSON
+	}
+	if unsigned == len(args) {
+		return compareAllUnsigned
+	}
 	if integers == len(args) {
 		return compareAllInteger
 	}
@@ -133,9 +158,9 @@ func getMultiComparisonFunc(args []eval) multiComparisonFunc {
 }
 
 func compareAllInteger(args []eval, cmp int) (eval, error) {
-	var candidateI = args[0].(*evalInt64).i
+	var candidateI = evalToNumeric(args[0]).toInt64().i
 	for _, arg := range args[1:] {
-		thisI := arg.(*evalInt64).i
+		thisI := evalToNumeric(arg).toInt64().i
 		if (cmp < 0) == (thisI < candidateI) {
 			candidateI = thisI
 		}
@@ -143,6 +168,17 @@ func compareAllInteger(args []eval, cmp int) (eval, error) {
 	return &evalInt64{candidateI}, nil
 }
 
+func compareAllUnsigned(args []eval, cmp int) (eval, error) {
+	var candidateU = args[0].(*evalUint64).u
+	for _, arg := range args[1:] {
+		thisU := arg.(*evalUint64).u
+		if (cmp < 0) == (thisU < candidateU) {
+			candidateU = thisU
+		}
+	}
+	return newEvalUint64(candidateU), nil
+}
+
 func compareAllFloat(args []eval, cmp int) (eval, error) {
 	candidateF, ok := evalToNumeric(args[0]).toFloat()
 	if !ok {
@@ -168,6 +204,9 @@ func evalDecimalPrecision(e eval) int32 {
 	return 0
 }
 
+// compareAllDecimal compares a mix of integer and decimal arguments as decimals.
+// Like in MySQL, the result has the largest scale of all the arguments, even
+// when the chosen argument was an integer (e.g. GREATEST(3, 2.5) is 3.0).
 func compareAllDecimal(args []eval, cmp int) (eval, error) {
 	decExtreme := evalToNumeric(args[0]).toDecimal(0, 0).dec
 	precExtreme := evalDecimalPrecision(args[0])
@@ -233,6 +272,26 @@ func compareAllBinary(args []eval, cmp int) (eval, error) {
 	return newEvalBinary(candidateB), nil
 }
 
+// compareAllJSON compares the arguments as JSON values when any of them is
+// a JSON document, converting the rest of them to JSON first.
+func compareAllJSON(args []eval, cmp int) (eval, error) {
+	candidateJ, err := evalToJSON(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, arg := range args[1:] {
+		thisJ, err := evalToJSON(arg)
+		if err != nil {
+			return nil, err
+		}
+		if (cmp < 0) == (compareJSONValue(thisJ, candidateJ) < 0) {
+			candidateJ = thisJ
+		}
+	}
+	return candidateJ, nil
+}
+
 func (call *builtinMultiComparison) eval(env *ExpressionEnv) (eval, error) {
 	args, err := call.args(env)
 	if err != nil {
@@ -244,10 +303,13 @@ func (call *builtinMultiComparison) eval(env *ExpressionEnv) (eval, error) {
 func (call *builtinMultiComparison) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 	var (
 		integers int
+		unsigned int
 		floats   int
 		decimals int
 		text     int
 		binary   int
+		jsons    int
+		nulls    int
 		flags    typeFlag
 	)
 
@@ -259,6 +321,7 @@ func (call *builtinMultiComparison) typeof(env *ExpressionEnv) (sqltypes.Type, t
 		case sqltypes.Int8, sqltypes.Int16, sqltypes.Int32, sqltypes.Int64:
 			integers++
 		case sqltypes.Uint8, sqltypes.Uint16, sqltypes.Uint32, sqltypes.Uint64:
+			unsigned++
 			if f&flagIntegerOvf != 0 {
 				decimals++
 			} else {
@@ -270,15 +333,29 @@ func (call *builtinMultiComparison) typeof(env *ExpressionEnv) (sqltypes.Type, t
 			decimals++
 		case sqltypes.Text, sqltypes.VarChar:
 			text++
+		case sqltypes.TypeJSON:
+			jsons++
 		case sqltypes.Blob, sqltypes.Binary, sqltypes.VarBinary:
 			binary++
+		case sqltypes.Null:
+			nulls++
 		}
 	}
 
-	if flags&flagNull != 0 {
-		return sqltypes.Null, flags
+	// arguments that can only be NULL, like columns typed as NULL, don't
+	// contribute to the type of the result; if all of them are like that,
+	// the result is always NULL
+	if flags&flagNull != 0 || nulls == len(call.Arguments) {
+		return sqltypes.Null, flags | flagNull
+	}
+	if jsons > 0 {
+		return sqltypes.TypeJSON, flags
+	}
+	args := len(call.Arguments) - nulls
+	if unsigned == args {
+		return sqltypes.Uint64, flags
 	}
-	if integers == len(call.Arguments) {
+	if integers == args {
 		return sqltypes.Int64, flags
 	}
 	if binary > 0 || text > 0 {
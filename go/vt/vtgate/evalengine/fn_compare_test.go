@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestMultiComparisonUnsigned(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"GREATEST(18446744073709551615, 9223372036854775808)", sqltypes.NewUint64(18446744073709551615)},
+		{"LEAST(18446744073709551615, 9223372036854775808)", sqltypes.NewUint64(9223372036854775808)},
+		{"GREATEST(CAST(1 AS UNSIGNED), CAST(18446744073709551615 AS UNSIGNED))", sqltypes.NewUint64(18446744073709551615)},
+		{"LEAST(CAST(1 AS UNSIGNED), CAST(18446744073709551615 AS UNSIGNED))", sqltypes.NewUint64(1)},
+		{"GREATEST(1, CAST(5 AS UNSIGNED))", sqltypes.NewInt64(5)},
+		{"LEAST(-1, CAST(5 AS UNSIGNED))", sqltypes.NewInt64(-1)},
+		{"GREATEST(CAST(1 AS UNSIGNED), NULL)", sqltypes.NULL},
+	})
+}
+
+func TestMultiComparisonDecimal(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"GREATEST(1, 2.5)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("2.5"))},
+		{"GREATEST(3, 2.5)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("3.0"))},
+		{"LEAST(1, 2.5)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("1.0"))},
+		{"LEAST(1, 2.50)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("1.00"))},
+		{"GREATEST(2.5, 1.25)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("2.50"))},
+		{"LEAST(-3, 2.125, 7)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("-3.000"))},
+		{"GREATEST(-3, 2.125, 7)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("7.000"))},
+		{"GREATEST(18446744073709551615, 1.5)", sqltypes.MakeTrusted(sqltypes.Decimal, []byte("18446744073709551615.0"))},
+		{"GREATEST(1, 2.5, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestMultiComparisonAllNull(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"GREATEST(NULL, NULL)", sqltypes.NULL},
+		{"LEAST(NULL, NULL)", sqltypes.NULL},
+		{"GREATEST(NULL, NULL, NULL)", sqltypes.NULL},
+		{"LEAST(CAST(NULL AS CHAR), CAST(NULL AS CHAR))", sqltypes.NULL},
+		{"COALESCE(GREATEST(NULL, NULL), 1)", sqltypes.NewInt64(1)},
+	})
+
+	// columns typed as NULL are ignored when inferring the type of the
+	// result, so the type is NULL only when all of them are like that
+	for expression, want := range map[string]sqltypes.Type{
+		"GREATEST(column0, column1)": sqltypes.Null,
+		"GREATEST(column0, column2)": sqltypes.Int64,
+	} {
+		expr := translateForEnv(t, expression)
+
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		env.Fields = []*querypb.Field{{Type: sqltypes.Null}, {Type: sqltypes.Null}, {Type: sqltypes.Int64}}
+		tt, err := env.TypeOf(expr)
+		require.NoError(t, err)
+		assert.Equal(t, want, tt, "type of %s", expression)
+	}
+}
+
+func TestCoalesceTemporal(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"COALESCE(NULL, CAST('2023-01-01' AS DATE))", dateValue("2023-01-01")},
+		{"COALESCE(CAST('2023-01-01' AS DATE), NULL)", dateValue("2023-01-01")},
+		{"COALESCE(NULL, DATE '2023-01-01', DATE '2023-01-02')", dateValue("2023-01-01")},
+		{"COALESCE(NULL, CAST('2023-01-01 10:20:30' AS DATETIME))", datetimeValue("2023-01-01 10:20:30")},
+		{"COALESCE(NULL, CAST('2023-01-01 10:20:30.25' AS DATETIME(2)))", datetimeValue("2023-01-01 10:20:30.25")},
+		{"COALESCE(NULL, TIMESTAMP '2023-01-01 10:20:30')", datetimeValue("2023-01-01 10:20:30")},
+
+		// a mix of temporal types results in a DATETIME
+		{"COALESCE(CAST('2023-01-01' AS DATE), CAST('2023-01-02 10:20:30' AS DATETIME))", datetimeValue("2023-01-01 00:00:00")},
+		{"COALESCE(NULL, DATE '2023-01-01', TIMESTAMP '2023-01-02 10:20:30')", datetimeValue("2023-01-01 00:00:00")},
+		{"COALESCE(TIMESTAMP '2023-01-01 10:20:30.5', DATE '2023-01-02')", datetimeValue("2023-01-01 10:20:30.5")},
+
+		{"COALESCE(NULL, CAST(NULL AS DATE))", sqltypes.NULL},
+	})
+
+	for _, tc := range []struct {
+		expr     string
+		expected sqltypes.Type
+	}{
+		{"COALESCE(NULL, CAST('2023-01-01' AS DATE))", sqltypes.Date},
+		{"COALESCE(NULL, CAST('2023-01-01 10:20:30' AS DATETIME))", sqltypes.Datetime},
+		{"COALESCE(CAST('2023-01-01' AS DATE), CAST('2023-01-02 10:20:30' AS DATETIME))", sqltypes.Datetime},
+		{"COALESCE(NULL, CAST('10:20:30' AS TIME))", sqltypes.Time},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			tt, err := env.TypeOf(translateForEnv(t, tc.expr))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tt)
+		})
+	}
+}
+
+func TestMultiComparisonJSON(t *testing.T) {
+	// when any argument is JSON, the rest of them are converted to JSON and
+	// compared with the ordering of JSON values
+	testEvaluateCases(t, []evaluateCase{
+		{"GREATEST(JSON_ARRAY(1), 'a')", jsonValue("[1]")},
+		{"LEAST(JSON_ARRAY(1), 'a')", jsonValue(`"a"`)},
+		{"GREATEST(JSON_ARRAY(1), JSON_ARRAY(2))", jsonValue("[2]")},
+		{"LEAST(JSON_ARRAY(1, 2), JSON_ARRAY(1))", jsonValue("[1]")},
+		{"GREATEST(JSON_OBJECT('a', 1), 5)", jsonValue(`{"a": 1}`)},
+		{"LEAST(JSON_EXTRACT('[10]', '$[0]'), 9)", jsonValue("9")},
+		{"GREATEST(JSON_EXTRACT('[10]', '$[0]'), 9.5)", jsonValue("10")},
+		{"GREATEST(JSON_EXTRACT('\"b\"', '$'), 'a')", jsonValue(`"b"`)},
+		{"LEAST(JSON_EXTRACT('\"b\"', '$'), 'a', 'c')", jsonValue(`"a"`)},
+		{"GREATEST(JSON_EXTRACT('true', '$'), JSON_ARRAY(1))", jsonValue("true")},
+		{"GREATEST(JSON_ARRAY(1), NULL)", sqltypes.NULL},
+	})
+}
+
+func TestMultiComparisonTypeOf(t *testing.T) {
+	for _, tc := range []struct {
+		expr     string
+		expected sqltypes.Type
+	}{
+		{"GREATEST(18446744073709551615, 9223372036854775808)", sqltypes.Uint64},
+		{"LEAST(CAST(1 AS UNSIGNED), CAST(18446744073709551615 AS UNSIGNED))", sqltypes.Uint64},
+		{"GREATEST(1, 2.5)", sqltypes.Decimal},
+		{"LEAST(3, 2.50, -1)", sqltypes.Decimal},
+		{"GREATEST(CAST(1 AS UNSIGNED), 0.5)", sqltypes.Decimal},
+		{"GREATEST(NULL, NULL)", sqltypes.Null},
+		{"LEAST(NULL, NULL, NULL)", sqltypes.Null},
+		{"GREATEST(NULL, 1)", sqltypes.Null},
+		{"GREATEST(JSON_ARRAY(1), 'a')", sqltypes.TypeJSON},
+		{"LEAST(JSON_ARRAY(1), JSON_ARRAY(2))", sqltypes.TypeJSON},
+		{"GREATEST(JSON_EXTRACT('[10]', '$[0]'), 9)", sqltypes.TypeJSON},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			tt, err := env.TypeOf(translateForEnv(t, tc.expr))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tt)
+		})
+	}
+}
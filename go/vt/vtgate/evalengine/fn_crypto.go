@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash/crc32"
+
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+type builtinCrc32 struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinCrc32)(nil)
+
+func (call *builtinCrc32) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	b := evalToBinary(arg)
+	return newEvalUint64(uint64(crc32.ChecksumIEEE(b.bytes))), nil
+}
+
+func (call *builtinCrc32) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Uint64, f
+}
+
+type builtinMD5 struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinMD5)(nil)
+
+func (call *builtinMD5) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	b := evalToBinary(arg)
+	sum := md5.Sum(b.bytes)
+	return newEvalText(hexDigest(sum[:]), env.collation()), nil
+}
+
+func (call *builtinMD5) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f
+}
+
+type builtinSHA1 struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSHA1)(nil)
+
+func (call *builtinSHA1) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	b := evalToBinary(arg)
+	sum := sha1.Sum(b.bytes)
+	return newEvalText(hexDigest(sum[:]), env.collation()), nil
+}
+
+func (call *builtinSHA1) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f
+}
+
+type builtinSHA2 struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSHA2)(nil)
+
+func (call *builtinSHA2) eval(env *ExpressionEnv) (eval, error) {
+	arg, length, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil || length == nil {
+		return nil, nil
+	}
+
+	b := evalToBinary(arg)
+	var sum []byte
+	switch evalToNumeric(length).toInt64().i {
+	case 224:
+		s := sha256.Sum224(b.bytes)
+		sum = s[:]
+	case 256, 0:
+		s := sha256.Sum256(b.bytes)
+		sum = s[:]
+	case 384:
+		s := sha512.Sum384(b.bytes)
+		sum = s[:]
+	case 512:
+		s := sha512.Sum512(b.bytes)
+		sum = s[:]
+	default:
+		// like MySQL, unsupported lengths return NULL
+		return nil, nil
+	}
+	return newEvalText(hexDigest(sum), env.collation()), nil
+}
+
+func (call *builtinSHA2) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.VarChar, f1 | f2 | flagNullable
+}
+
+type builtinRandomBytes struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinRandomBytes)(nil)
+
+// maxRandomBytes is the largest length that RANDOM_BYTES accepts
+const maxRandomBytes = 1024
+
+func (call *builtinRandomBytes) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	length := evalToNumeric(arg).toInt64().i
+	if length < 1 || length > maxRandomBytes {
+		return nil, vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.DataOutOfRange, "length value is out of range in 'random_bytes'")
+	}
+
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	return newEvalBinary(buf), nil
+}
+
+func (call *builtinRandomBytes) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarBinary, f
+}
+
+// hexDigest returns the given digest as lower case hex digits, which is
+// how MySQL returns the results of its hash functions
+func hexDigest(sum []byte) []byte {
+	buf := make([]byte, hex.EncodedLen(len(sum)))
+	hex.Encode(buf, sum)
+	return buf
+}
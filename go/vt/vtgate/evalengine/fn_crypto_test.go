@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestCrc32(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CRC32('')", sqltypes.NewUint64(0)},
+		{"CRC32('a')", sqltypes.NewUint64(3904355907)},
+		{"CRC32('MySQL')", sqltypes.NewUint64(3259397556)},
+		{"CRC32('mysql')", sqltypes.NewUint64(2501908538)},
+		{"CRC32('The quick brown fox jumps over the lazy dog')", sqltypes.NewUint64(1095738169)},
+		{"CRC32(123)", sqltypes.NewUint64(2286445522)},
+		{"CRC32('123')", sqltypes.NewUint64(2286445522)},
+		{"CRC32(1.50)", sqltypes.NewUint64(3756579112)},
+		{"CRC32(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestMD5(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"MD5('')", sqltypes.NewVarChar("d41d8cd98f00b204e9800998ecf8427e")},
+		{"MD5('a')", sqltypes.NewVarChar("0cc175b9c0f1b6a831c399e269772661")},
+		{"MD5('abc')", sqltypes.NewVarChar("900150983cd24fb0d6963f7d28e17f72")},
+		{"MD5('message digest')", sqltypes.NewVarChar("f96b697d7cb7938d525a2f31aaf161d0")},
+		{"MD5('The quick brown fox jumps over the lazy dog')", sqltypes.NewVarChar("9e107d9d372bb6826bd81d3542a419d6")},
+		{"MD5(_binary 'abc')", sqltypes.NewVarChar("900150983cd24fb0d6963f7d28e17f72")},
+		{"MD5(0x616263)", sqltypes.NewVarChar("900150983cd24fb0d6963f7d28e17f72")},
+		// numbers are hashed in their string form
+		{"MD5(123)", sqltypes.NewVarChar("202cb962ac59075b964b07152d234b70")},
+		{"MD5('123')", sqltypes.NewVarChar("202cb962ac59075b964b07152d234b70")},
+		{"MD5(1.50)", sqltypes.NewVarChar("a6acbd7fe3dcc4f4328712278f6da218")},
+		{"MD5(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestSHA1(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SHA1('')", sqltypes.NewVarChar("da39a3ee5e6b4b0d3255bfef95601890afd80709")},
+		{"SHA1('abc')", sqltypes.NewVarChar("a9993e364706816aba3e25717850c26c9cd0d89d")},
+		{"SHA('abc')", sqltypes.NewVarChar("a9993e364706816aba3e25717850c26c9cd0d89d")},
+		{"SHA1('abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq')", sqltypes.NewVarChar("84983e441c3bd26ebaae4aa1f95129e5e54670f1")},
+		{"SHA1('The quick brown fox jumps over the lazy dog')", sqltypes.NewVarChar("2fd4e1c67a2d28fced849ee1bb76e7391b93eb12")},
+		{"SHA1(_binary 'abc')", sqltypes.NewVarChar("a9993e364706816aba3e25717850c26c9cd0d89d")},
+		{"SHA1(0x616263)", sqltypes.NewVarChar("a9993e364706816aba3e25717850c26c9cd0d89d")},
+		// numbers are hashed in their string form
+		{"SHA1(123)", sqltypes.NewVarChar("40bd001563085fc35165329ea1ff5c5ecbdbbeef")},
+		{"SHA1('123')", sqltypes.NewVarChar("40bd001563085fc35165329ea1ff5c5ecbdbbeef")},
+		{"SHA1(1.50)", sqltypes.NewVarChar("d334a13c0d4775a67e1e0880da2e444693d5d957")},
+		{"SHA1(NULL)", sqltypes.NULL},
+		{"SHA(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestSHA2(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SHA2('', 224)", sqltypes.NewVarChar("d14a028c2a3a2bc9476102bb288234c415a2b01f828ea62ac5b3e42f")},
+		{"SHA2('', 256)", sqltypes.NewVarChar("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")},
+		{"SHA2('', 384)", sqltypes.NewVarChar("38b060a751ac96384cd9327eb1b1e36a21fdb71114be07434c0cc7bf63f6e1da274edebfe76f65fbd51ad2f14898b95b")},
+		{"SHA2('', 512)", sqltypes.NewVarChar("cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e")},
+		{"SHA2('', 0)", sqltypes.NewVarChar("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")},
+		{"SHA2('abc', 224)", sqltypes.NewVarChar("23097d223405d8228642a477bda255b32aadbce4bda0b3f7e36c9da7")},
+		{"SHA2('abc', 256)", sqltypes.NewVarChar("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad")},
+		{"SHA2('abc', 384)", sqltypes.NewVarChar("cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7")},
+		{"SHA2('abc', 512)", sqltypes.NewVarChar("ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f")},
+		{"SHA2('abc', 0)", sqltypes.NewVarChar("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad")},
+		{"SHA2('The quick brown fox jumps over the lazy dog', 256)", sqltypes.NewVarChar("d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592")},
+		{"SHA2(_binary 'abc', 256)", sqltypes.NewVarChar("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad")},
+		{"SHA2('abc', '512')", sqltypes.NewVarChar("ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f")},
+		// a length that is not a number is 0
+		{"SHA2('abc', 'foo')", sqltypes.NewVarChar("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad")},
+		// numbers are hashed in their string form
+		{"SHA2(123, 256)", sqltypes.NewVarChar("a665a45920422f9d417e4867efdc4fb8a04a1f3fff1fa07e998e86f7f7a27ae3")},
+		{"SHA2(1.50, 224)", sqltypes.NewVarChar("3a98de259f3108554509e053d76dd105283bd3b80890f186710666ad")},
+		// unsupported lengths return NULL
+		{"SHA2('abc', 1)", sqltypes.NULL},
+		{"SHA2('abc', 128)", sqltypes.NULL},
+		{"SHA2('abc', 255)", sqltypes.NULL},
+		{"SHA2('abc', -256)", sqltypes.NULL},
+		{"SHA2('abc', 1024)", sqltypes.NULL},
+		{"SHA2(NULL, 256)", sqltypes.NULL},
+		{"SHA2('abc', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestRandomBytes(t *testing.T) {
+	for _, tc := range []struct {
+		expression string
+		length     int
+	}{
+		{"RANDOM_BYTES(1)", 1},
+		{"RANDOM_BYTES(16)", 16},
+		{"RANDOM_BYTES(1024)", 1024},
+		{"RANDOM_BYTES('8')", 8},
+		{"RANDOM_BYTES(2 * 4)", 8},
+	} {
+		expr := translateForEnv(t, tc.expression)
+		require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.VarBinary, res.Value().Type(), "type of %s", tc.expression)
+		require.Len(t, res.Value().Raw(), tc.length, "length of %s", tc.expression)
+
+		tt, err := env.TypeOf(expr)
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.VarBinary, tt, "type of %s", tc.expression)
+	}
+
+	// every evaluation returns different bytes
+	env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+	expr := translateForEnv(t, "RANDOM_BYTES(32)")
+	first, err := env.Evaluate(expr)
+	require.NoError(t, err)
+	second, err := env.Evaluate(expr)
+	require.NoError(t, err)
+	require.NotEqual(t, first.Value().Raw(), second.Value().Raw())
+
+	testEvaluateCases(t, []evaluateCase{
+		{"RANDOM_BYTES(NULL)", sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{"RANDOM_BYTES(0)", "length value is out of range in 'random_bytes'"},
+		{"RANDOM_BYTES(-1)", "length value is out of range in 'random_bytes'"},
+		{"RANDOM_BYTES(1025)", "length value is out of range in 'random_bytes'"},
+		{"RANDOM_BYTES('foo')", "length value is out of range in 'random_bytes'"},
+	})
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package evalengine
 
 import (
+	"math"
 	"math/bits"
 
 	"vitess.io/vitess/go/sqltypes"
@@ -43,6 +44,9 @@ func (call *builtinHex) eval(env *ExpressionEnv) (eval, error) {
 	switch arg := arg.(type) {
 	case *evalBytes:
 		encoded = hexEncodeBytes(arg.bytes)
+	case *evalFloat, *evalDecimal:
+		f, _ := arg.(evalNumeric).toFloat()
+		encoded = hexEncodeUint(hexFloatToUint(f.f))
 	case evalNumeric:
 		encoded = hexEncodeUint(arg.toUint64().u)
 	default:
@@ -57,6 +61,21 @@ func (call *builtinHex) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 	return sqltypes.VarChar, f
 }
 
+// hexFloatToUint converts a floating point value to the unsigned integer
+// that HEX encodes for it: values are rounded to the nearest integer, and
+// negative values use their 64-bit two's complement representation. Values
+// outside of the 64-bit range are encoded as all ones, like MySQL does.
+func hexFloatToUint(f float64) uint64 {
+	switch {
+	case f <= math.MinInt64 || f >= math.MaxUint64:
+		return math.MaxUint64
+	case f < 0:
+		return uint64(int64(f - 0.5))
+	default:
+		return uint64(f + 0.5)
+	}
+}
+
 const hextable = "0123456789ABCDEF"
 
 func hexEncodeBytes(src []byte) []byte {
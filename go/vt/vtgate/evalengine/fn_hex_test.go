@@ -0,0 +1,39 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"testing"
+
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestHexNegative(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"HEX(-1)", sqltypes.NewVarChar("FFFFFFFFFFFFFFFF")},
+		{"HEX(-2)", sqltypes.NewVarChar("FFFFFFFFFFFFFFFE")},
+		{"HEX(-255)", sqltypes.NewVarChar("FFFFFFFFFFFFFF01")},
+		{"HEX(-9223372036854775808)", sqltypes.NewVarChar("8000000000000000")},
+		{"HEX(-9223372036854775809)", sqltypes.NewVarChar("FFFFFFFFFFFFFFFF")},
+		{"HEX(-1.5)", sqltypes.NewVarChar("FFFFFFFFFFFFFFFE")},
+		{"HEX(-1.5e0)", sqltypes.NewVarChar("FFFFFFFFFFFFFFFE")},
+		{"HEX(-1e30)", sqltypes.NewVarChar("FFFFFFFFFFFFFFFF")},
+		{"HEX(255)", sqltypes.NewVarChar("FF")},
+		{"HEX(18446744073709551615)", sqltypes.NewVarChar("FFFFFFFFFFFFFFFF")},
+		{"HEX(CAST(-1 AS SIGNED))", sqltypes.NewVarChar("FFFFFFFFFFFFFFFF")},
+	})
+}
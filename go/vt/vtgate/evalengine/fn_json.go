@@ -17,10 +17,16 @@ limitations under the License.
 package evalengine
 
 import (
+	"bytes"
+	"strconv"
+	"strings"
+
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/decimal"
 	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/json"
 )
 
@@ -56,6 +62,39 @@ type (
 	builtinJSONKeys struct {
 		CallExpr
 	}
+
+	builtinJSONContains struct {
+		CallExpr
+	}
+
+	builtinJSONValid struct {
+		CallExpr
+	}
+
+	builtinJSONType struct {
+		CallExpr
+	}
+
+	builtinJSONQuote struct {
+		CallExpr
+	}
+
+	builtinJSONMergePatch struct {
+		CallExpr
+	}
+
+	builtinJSONMergePreserve struct {
+		CallExpr
+	}
+
+	builtinJSONModify struct {
+		CallExpr
+		modify sqlparser.JSONValueModifierType
+	}
+
+	builtinJSONRemove struct {
+		CallExpr
+	}
 )
 
 var _ Expr = (*builtinJSONExtract)(nil)
@@ -66,8 +105,18 @@ var _ Expr = (*builtinJSONDepth)(nil)
 var _ Expr = (*builtinJSONLength)(nil)
 var _ Expr = (*builtinJSONContainsPath)(nil)
 var _ Expr = (*builtinJSONKeys)(nil)
+var _ Expr = (*builtinJSONContains)(nil)
+var _ Expr = (*builtinJSONValid)(nil)
+var _ Expr = (*builtinJSONType)(nil)
+var _ Expr = (*builtinJSONQuote)(nil)
+var _ Expr = (*builtinJSONMergePatch)(nil)
+var _ Expr = (*builtinJSONMergePreserve)(nil)
+var _ Expr = (*builtinJSONModify)(nil)
+var _ Expr = (*builtinJSONRemove)(nil)
 
 var errInvalidPathForTransform = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "In this situation, path expressions may not contain the * and ** tokens or an array range.")
+var errInvalidPathForArrayInsert = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "A path expression is not a path to a cell in an array.")
+var errVacuousPath = vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "The path expression '$' is not allowed in this context.")
 
 func (call *builtinJSONExtract) eval(env *ExpressionEnv) (eval, error) {
 	args, err := call.args(env)
@@ -146,6 +195,32 @@ func (call *builtinJSONUnquote) typeof(env *ExpressionEnv) (sqltypes.Type, typeF
 	return sqltypes.Blob, f
 }
 
+func (call *builtinJSONQuote) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	// only strings can be quoted: MySQL rejects numbers, temporal values and
+	// even JSON documents
+	b, ok := arg.(*evalBytes)
+	if !ok || sqltypes.IsDate(b.SQLType()) {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Incorrect type for argument 1 in function json_quote.")
+	}
+	text, err := evalToVarchar(b, collations.CollationUtf8mb4ID, true)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalRaw(sqltypes.VarChar, json.NewString(text.bytes).MarshalTo(nil), collationJSON), nil
+}
+
+func (call *builtinJSONQuote) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f
+}
+
 func (call *builtinJSONObject) eval(env *ExpressionEnv) (eval, error) {
 	j := json.NewObject()
 	obj, _ := j.Object()
@@ -174,6 +249,9 @@ func (call *builtinJSONObject) eval(env *ExpressionEnv) (eval, error) {
 
 		obj.Set(key1.string(), val1, json.Set)
 	}
+	if err := checkJSONDocument(env, "json_object", j); err != nil {
+		return nil, err
+	}
 	return j, nil
 }
 
@@ -194,13 +272,286 @@ func (call *builtinJSONArray) eval(env *ExpressionEnv) (eval, error) {
 		}
 		ary = append(ary, arg1)
 	}
-	return json.NewArray(ary), nil
+	j := json.NewArray(ary)
+	if err := checkJSONDocument(env, "json_array", j); err != nil {
+		return nil, err
+	}
+	return j, nil
 }
 
 func (call *builtinJSONArray) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 	return sqltypes.TypeJSON, 0
 }
 
+// jsonMergePatch applies patch to target following RFC 7396. The result is a
+// new value, so neither of the inputs is modified.
+func jsonMergePatch(target, patch *json.Value) *json.Value {
+	po, ok := patch.Object()
+	if !ok {
+		return patch
+	}
+
+	res := json.NewObject()
+	obj, _ := res.Object()
+	if target != nil {
+		if to, ok := target.Object(); ok {
+			to.Visit(func(key []byte, v *json.Value) {
+				obj.Set(string(key), v, json.Set)
+			})
+		}
+	}
+	po.Visit(func(key []byte, v *json.Value) {
+		k := string(key)
+		if v.Type() == json.TypeNull {
+			obj.Del(k)
+		} else {
+			obj.Set(k, jsonMergePatch(obj.Get(k), v), json.Set)
+		}
+	})
+	return res
+}
+
+func (call *builtinJSONMergePatch) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// like in MySQL, a NULL argument makes the result NULL unless it's
+	// followed by a patch that is not an object, since that replaces the
+	// whole document
+	var doc *json.Value
+	for i, arg := range args {
+		if arg == nil {
+			doc = nil
+			continue
+		}
+		patch, err := intoJSON(call.Method, arg)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case i == 0:
+			doc = patch
+		case patch.Type() != json.TypeObject:
+			doc = patch
+		case doc != nil:
+			doc = jsonMergePatch(doc, patch)
+		}
+	}
+	if doc == nil {
+		return nil, nil
+	}
+	if err := checkJSONDocument(env, "json_merge_patch", doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (call *builtinJSONMergePatch) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range call.Arguments {
+		_, af := arg.typeof(env)
+		f |= af & flagNullable
+	}
+	return sqltypes.TypeJSON, f
+}
+
+// jsonMergePreserve merges two values like MySQL's JSON_MERGE_PRESERVE: two
+// objects are merged into one, merging the values of any key they have in
+// common, and anything else is concatenated as arrays, wrapping the values
+// that are not arrays. The result is a new value, so neither of the inputs is
+// modified.
+func jsonMergePreserve(left, right *json.Value) *json.Value {
+	lo, lok := left.Object()
+	ro, rok := right.Object()
+	if lok && rok {
+		res := json.NewObject()
+		obj, _ := res.Object()
+		lo.Visit(func(key []byte, v *json.Value) {
+			obj.Set(string(key), v, json.Set)
+		})
+		ro.Visit(func(key []byte, v *json.Value) {
+			k := string(key)
+			if prev := obj.Get(k); prev != nil {
+				v = jsonMergePreserve(prev, v)
+			}
+			obj.Set(k, v, json.Set)
+		})
+		return res
+	}
+
+	var ary []*json.Value
+	for _, v := range []*json.Value{left, right} {
+		if a, ok := v.Array(); ok {
+			ary = append(ary, a...)
+		} else {
+			ary = append(ary, v)
+		}
+	}
+	return json.NewArray(ary)
+}
+
+func (call *builtinJSONMergePreserve) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc *json.Value
+	for i, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+		j, err := intoJSON(call.Method, arg)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			doc = j
+		} else {
+			doc = jsonMergePreserve(doc, j)
+		}
+	}
+	if err := checkJSONDocument(env, "json_merge_preserve", doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (call *builtinJSONMergePreserve) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range call.Arguments {
+		_, af := arg.typeof(env)
+		f |= af & flagNullable
+	}
+	return sqltypes.TypeJSON, f
+}
+
+func (call *builtinJSONModify) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []*json.Path
+	var values []*json.Value
+	for i := 1; i < len(args); i += 2 {
+		if args[i] == nil {
+			return nil, nil
+		}
+		jp, err := intoJSONPath(args[i])
+		if err != nil {
+			return nil, err
+		}
+		if jp.ContainsWildcards() {
+			return nil, errInvalidPathForTransform
+		}
+		if call.modify == sqlparser.JSONArrayInsertType && !jp.EndsInArrayLocation() {
+			return nil, errInvalidPathForArrayInsert
+		}
+		val, err := evalToJSON(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, jp)
+		values = append(values, val.Clone())
+	}
+
+	var transform json.Transformation
+	switch call.modify {
+	case sqlparser.JSONSetType:
+		transform = json.Set
+	case sqlparser.JSONInsertType:
+		transform = json.Insert
+	case sqlparser.JSONReplaceType:
+		transform = json.Replace
+	case sqlparser.JSONArrayAppendType:
+		transform = json.ArrayAppend
+	case sqlparser.JSONArrayInsertType:
+		transform = json.ArrayInsert
+	}
+
+	// the document is modified in place, so it must be copied first: it may
+	// be shared with other expressions, for instance if it's a constant
+	res, err := json.ApplyTransform(transform, doc.Clone(), paths, values)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkJSONDocument(env, strings.ToLower(call.Method), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (call *builtinJSONModify) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	for i := 1; i < len(call.Arguments); i += 2 {
+		_, pf := call.Arguments[i].typeof(env)
+		f |= pf & flagNullable
+	}
+	return sqltypes.TypeJSON, f
+}
+
+func (call *builtinJSONRemove) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+	doc, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []*json.Path
+	for _, arg := range args[1:] {
+		if arg == nil {
+			return nil, nil
+		}
+		jp, err := intoJSONPath(arg)
+		if err != nil {
+			return nil, err
+		}
+		if jp.ContainsWildcards() {
+			return nil, errInvalidPathForTransform
+		}
+		if jp.IsRoot() {
+			return nil, errVacuousPath
+		}
+		paths = append(paths, jp)
+	}
+
+	// the paths are applied one after the other, so removing an element
+	// from an array shifts the positions seen by the paths that follow
+	res, err := json.ApplyTransform(json.Remove, doc.Clone(), paths, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkJSONDocument(env, "json_remove", res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (call *builtinJSONRemove) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range call.Arguments {
+		_, af := arg.typeof(env)
+		f |= af & flagNullable
+	}
+	return sqltypes.TypeJSON, f
+}
+
 func (call *builtinJSONDepth) eval(env *ExpressionEnv) (eval, error) {
 	arg, err := call.arg1(env)
 	if err != nil {
@@ -221,6 +572,84 @@ func (call *builtinJSONDepth) typeof(env *ExpressionEnv) (sqltypes.Type, typeFla
 	return sqltypes.Int64, f
 }
 
+func (call *builtinJSONValid) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	// unlike other JSON functions, JSON_VALID doesn't fail on values that are
+	// not JSON documents, it just reports them as invalid
+	switch arg := arg.(type) {
+	case *evalJSON:
+		// values that are already typed as JSON have been parsed before
+		return newEvalBool(true), nil
+	case *evalBytes:
+		var p json.Parser
+		_, err := p.ParseBytes(arg.bytes)
+		return newEvalBool(err == nil), nil
+	default:
+		return newEvalBool(false), nil
+	}
+}
+
+func (call *builtinJSONValid) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f
+}
+
+// jsonTypeName returns the name that MySQL's JSON_TYPE gives to the type of
+// the value. Numbers that are written as integers are INTEGER or UNSIGNED
+// INTEGER depending on their range, and any other number is a DOUBLE.
+func jsonTypeName(j *json.Value) string {
+	switch j.Type() {
+	case json.TypeObject:
+		return "OBJECT"
+	case json.TypeArray:
+		return "ARRAY"
+	case json.TypeString:
+		return "STRING"
+	case json.TypeTrue, json.TypeFalse:
+		return "BOOLEAN"
+	case json.TypeNumber:
+		num := j.Raw()
+		if strings.ContainsAny(num, ".eE") {
+			return "DOUBLE"
+		}
+		if _, err := strconv.ParseInt(num, 10, 64); err == nil {
+			return "INTEGER"
+		}
+		if _, err := strconv.ParseUint(num, 10, 64); err == nil {
+			return "UNSIGNED INTEGER"
+		}
+		return "DOUBLE"
+	default:
+		return "NULL"
+	}
+}
+
+func (call *builtinJSONType) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+	j, err := intoJSON("JSON_TYPE", arg)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalRaw(sqltypes.VarChar, []byte(jsonTypeName(j)), collationJSON), nil
+}
+
+func (call *builtinJSONType) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f
+}
+
 func (call *builtinJSONLength) eval(env *ExpressionEnv) (eval, error) {
 	arg, err := call.Arguments[0].eval(env)
 	if err != nil {
@@ -392,3 +821,144 @@ func (call *builtinJSONKeys) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag
 	_, f := call.Arguments[0].typeof(env)
 	return sqltypes.TypeJSON, f | flagNullable
 }
+
+func (call *builtinJSONContains) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	target, err := intoJSON(call.Method, args[0])
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := intoJSON(call.Method, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 3 {
+		jp, err := intoJSONPath(args[2])
+		if err != nil {
+			return nil, err
+		}
+		if jp.ContainsWildcards() {
+			return nil, errInvalidPathForTransform
+		}
+		var matched *json.Value
+		jp.Match(target, true, func(value *json.Value) {
+			matched = value
+		})
+		if matched == nil {
+			// the path does not address any location in the target
+			return nil, nil
+		}
+		target = matched
+	}
+
+	return newEvalBool(jsonContains(target, candidate)), nil
+}
+
+// jsonContains returns whether the candidate document is contained in the
+// target document, following MySQL's rules: scalars must be equal, all the
+// members of a candidate object must be contained in the same member of a
+// target object, and a candidate is contained in an array if it's contained
+// in any of its elements, or, for candidate arrays, if all its elements are.
+func jsonContains(target, candidate *json.Value) bool {
+	switch target.Type() {
+	case json.TypeArray:
+		elems, _ := target.Array()
+		if celems, ok := candidate.Array(); ok {
+			for _, c := range celems {
+				if !jsonArrayContainsElement(elems, c) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, t := range elems {
+			if jsonContains(t, candidate) {
+				return true
+			}
+		}
+		return false
+
+	case json.TypeObject:
+		cobj, ok := candidate.Object()
+		if !ok {
+			return false
+		}
+		tobj, _ := target.Object()
+		contained := true
+		cobj.Visit(func(key []byte, cv *json.Value) {
+			if !contained {
+				return
+			}
+			tv := tobj.Get(string(key))
+			contained = tv != nil && jsonContains(tv, cv)
+		})
+		return contained
+
+	default:
+		return jsonScalarEqual(target, candidate)
+	}
+}
+
+// jsonArrayContainsElement returns whether an element of a candidate array
+// is contained in the elements of a target array: scalars must be equal to
+// one of the elements, and arrays or objects must be contained in an element
+// of the same type.
+func jsonArrayContainsElement(elems []*json.Value, candidate *json.Value) bool {
+	ct := candidate.Type()
+	for _, t := range elems {
+		switch ct {
+		case json.TypeArray, json.TypeObject:
+			if t.Type() == ct && jsonContains(t, candidate) {
+				return true
+			}
+		default:
+			if jsonScalarEqual(t, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonScalarEqual(a, b *json.Value) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Type() {
+	case json.TypeNumber:
+		da, erra := decimal.NewFromString(a.Raw())
+		db, errb := decimal.NewFromString(b.Raw())
+		if erra != nil || errb != nil {
+			return parseStringToFloat(a.Raw()) == parseStringToFloat(b.Raw())
+		}
+		return da.Equal(db)
+	case json.TypeString:
+		sa, _ := a.StringBytes()
+		sb, _ := b.StringBytes()
+		return bytes.Equal(sa, sb)
+	case json.TypeArray, json.TypeObject:
+		return false
+	default:
+		// true, false and null only need their types to match
+		return true
+	}
+}
+
+func (call *builtinJSONContains) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range call.Arguments {
+		_, af := arg.typeof(env)
+		f |= af
+	}
+	return sqltypes.Int64, f | flagNullable
+}
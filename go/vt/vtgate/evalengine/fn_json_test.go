@@ -0,0 +1,534 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestJSONDepth(t *testing.T) {
+	const nesting = 250
+	deep := strings.Repeat("[", nesting) + strings.Repeat("]", nesting)
+
+	testEvaluateCases(t, []evaluateCase{
+		{"JSON_DEPTH('1')", sqltypes.NewInt64(1)},
+		{"JSON_DEPTH('[]')", sqltypes.NewInt64(1)},
+		{"JSON_DEPTH('[1, [2, [3]]]')", sqltypes.NewInt64(4)},
+		{`JSON_DEPTH('{"a": {"b": [1]}}')`, sqltypes.NewInt64(4)},
+		{fmt.Sprintf("JSON_DEPTH('%s')", deep), sqltypes.NewInt64(nesting)},
+		{"JSON_DEPTH(NULL)", sqltypes.NULL},
+		{"JSON_LENGTH('[1, 2, 3]')", sqltypes.NewInt64(3)},
+		{`JSON_LENGTH('{"a": [1, 2]}', '$.a')`, sqltypes.NewInt64(2)},
+	})
+}
+
+func TestJSONUnquoteUTF8(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{`JSON_UNQUOTE('"café"')`, sqltypes.MakeTrusted(sqltypes.Blob, []byte("café"))},
+		{`JSON_UNQUOTE('"caf\\u00e9"')`, sqltypes.MakeTrusted(sqltypes.Blob, []byte("café"))},
+		{`JSON_UNQUOTE('"\\u65e5\\u672c"')`, sqltypes.MakeTrusted(sqltypes.Blob, []byte("日本"))},
+		{`JSON_UNQUOTE('"\\ud83d\\ude0a"')`, sqltypes.MakeTrusted(sqltypes.Blob, []byte("😊"))},
+		{`JSON_UNQUOTE('"中文 \\u6d4b\\u8bd5"')`, sqltypes.MakeTrusted(sqltypes.Blob, []byte("中文 测试"))},
+		{`JSON_UNQUOTE(JSON_EXTRACT('{"a": "\\u00e9t\\u00e9"}', '$.a'))`, sqltypes.MakeTrusted(sqltypes.Blob, []byte("été"))},
+	})
+}
+
+func TestJSONContainsPath(t *testing.T) {
+	const doc = `'{"a": 1, "b": [1, 2, {"c": 3}], "d": {"e": "x"}}'`
+
+	testEvaluateCases(t, []evaluateCase{
+		{fmt.Sprintf("JSON_CONTAINS(%s, '1', '$.a')", doc), sqltypes.NewInt64(1)},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '2', '$.a')", doc), sqltypes.NewInt64(0)},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '[2, 1]', '$.b')", doc), sqltypes.NewInt64(1)},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '{\"c\": 3}', '$.b')", doc), sqltypes.NewInt64(1)},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '\"x\"', '$.d.e')", doc), sqltypes.NewInt64(1)},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '{\"e\": \"y\"}', '$.d')", doc), sqltypes.NewInt64(0)},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '1', '$.missing')", doc), sqltypes.NULL},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '1', '$.d.missing')", doc), sqltypes.NULL},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '1', '$.b[10]')", doc), sqltypes.NULL},
+		{fmt.Sprintf("JSON_CONTAINS(%s, '1', NULL)", doc), sqltypes.NULL},
+	})
+}
+
+func TestJSONContains(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// scalars
+		{`JSON_CONTAINS('1', '1')`, sqltypes.NewInt64(1)},
+		{`JSON_CONTAINS('1', '2')`, sqltypes.NewInt64(0)},
+		{`JSON_CONTAINS('"a"', '"a"')`, sqltypes.NewInt64(1)},
+		{`JSON_CONTAINS('[1, 2, 3]', '2')`, sqltypes.NewInt64(1)},
+		{`JSON_CONTAINS('[1, 2, 3]', '4')`, sqltypes.NewInt64(0)},
+		{`JSON_CONTAINS('{"a": 1}', '1')`, sqltypes.NewInt64(0)},
+
+		// nested objects and arrays
+		{`JSON_CONTAINS('{"a": 1, "b": {"c": [1, 2], "d": "x"}}', '{"b": {"d": "x"}}')`, sqltypes.NewInt64(1)},
+		{`JSON_CONTAINS('{"a": 1, "b": {"c": [1, 2], "d": "x"}}', '{"b": {"c": [2]}}')`, sqltypes.NewInt64(1)},
+		{`JSON_CONTAINS('{"a": 1, "b": {"c": [1, 2], "d": "x"}}', '{"b": {"c": 3}}')`, sqltypes.NewInt64(0)},
+		{`JSON_CONTAINS('{"a": 1, "b": {"c": [1, 2], "d": "x"}}', '{"a": 1, "e": 1}')`, sqltypes.NewInt64(0)},
+
+		// the optional path
+		{`JSON_CONTAINS('{"a": {"b": [1, 2]}}', '2', '$.a.b')`, sqltypes.NewInt64(1)},
+		{`JSON_CONTAINS('{"a": {"b": [1, 2]}}', '{"b": [1]}', '$.a')`, sqltypes.NewInt64(1)},
+		{`JSON_CONTAINS('{"a": {"b": [1, 2]}}', '2', '$.a')`, sqltypes.NewInt64(0)},
+
+		{`JSON_CONTAINS(NULL, '1')`, sqltypes.NULL},
+		{`JSON_CONTAINS('1', NULL)`, sqltypes.NULL},
+		{`JSON_CONTAINS(NULL, NULL, '$')`, sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`JSON_CONTAINS('[1', '1')`, `cannot parse JSON: cannot parse array: unexpected end of array; unparsed tail: ""`},
+		{`JSON_CONTAINS('1', 'foo')`, `cannot parse JSON: unexpected value found: "foo"; unparsed tail: "foo"`},
+		{`JSON_CONTAINS('[1]', '1', 'a')`, "Invalid JSON path expression. The error is around character position 1."},
+		{`JSON_CONTAINS('[1]', '1', '$[*]')`, "In this situation, path expressions may not contain the * and ** tokens or an array range."},
+	})
+}
+
+func TestJSONValid(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{`JSON_VALID('{"a": [1, 2, {"b": null}]}')`, sqltypes.NewInt64(1)},
+		{`JSON_VALID('[]')`, sqltypes.NewInt64(1)},
+		{`JSON_VALID('"foo"')`, sqltypes.NewInt64(1)},
+		{`JSON_VALID('1.5')`, sqltypes.NewInt64(1)},
+		{`JSON_VALID('null')`, sqltypes.NewInt64(1)},
+		{`JSON_VALID(JSON_ARRAY(1, 2))`, sqltypes.NewInt64(1)},
+
+		// malformed documents are not an error
+		{`JSON_VALID('{"a": 1')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('[1, 2,]')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('foo')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('1-2')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('2023-01-05')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('01')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('.5')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('[1, NaN]')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID('')`, sqltypes.NewInt64(0)},
+		{`JSON_VALID(1)`, sqltypes.NewInt64(0)},
+
+		{`JSON_VALID(NULL)`, sqltypes.NULL},
+	})
+}
+
+func TestJSONValidColumn(t *testing.T) {
+	expr := translateForEnv(t, "JSON_VALID(column0)")
+
+	var cases = []struct {
+		value    sqltypes.Value
+		expected int64
+	}{
+		// columns that are already typed as JSON are always valid
+		{sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`{"a": [1, 2]}`)), 1},
+		{sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`"foo"`)), 1},
+		{sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`null`)), 1},
+		// strings have to be parsed
+		{sqltypes.NewVarChar(`{"a": [1, 2]}`), 1},
+		{sqltypes.NewVarChar(`{"a": [1, 2]`), 0},
+		{sqltypes.NewVarChar(`foo`), 0},
+	}
+
+	for _, tc := range cases {
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		env.Row = []sqltypes.Value{tc.value}
+		env.Fields = []*querypb.Field{{Type: tc.value.Type()}}
+
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		assert.Equal(t, sqltypes.NewInt64(tc.expected), res.Value(), "JSON_VALID(%v)", tc.value)
+	}
+}
+
+func TestJSONType(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{`JSON_TYPE('{"a": 1}')`, sqltypes.NewVarChar("OBJECT")},
+		{`JSON_TYPE('[1, 2]')`, sqltypes.NewVarChar("ARRAY")},
+		{`JSON_TYPE('"foo"')`, sqltypes.NewVarChar("STRING")},
+		{`JSON_TYPE('true')`, sqltypes.NewVarChar("BOOLEAN")},
+		{`JSON_TYPE('false')`, sqltypes.NewVarChar("BOOLEAN")},
+		{`JSON_TYPE('null')`, sqltypes.NewVarChar("NULL")},
+
+		// numbers
+		{`JSON_TYPE('1')`, sqltypes.NewVarChar("INTEGER")},
+		{`JSON_TYPE('-9223372036854775808')`, sqltypes.NewVarChar("INTEGER")},
+		{`JSON_TYPE('9223372036854775808')`, sqltypes.NewVarChar("UNSIGNED INTEGER")},
+		{`JSON_TYPE('18446744073709551616')`, sqltypes.NewVarChar("DOUBLE")},
+		{`JSON_TYPE('1.5')`, sqltypes.NewVarChar("DOUBLE")},
+		{`JSON_TYPE('1e3')`, sqltypes.NewVarChar("DOUBLE")},
+		{`JSON_TYPE(CAST(1.5e0 AS JSON))`, sqltypes.NewVarChar("DOUBLE")},
+
+		{`JSON_TYPE(JSON_EXTRACT('{"a": [1, 2]}', '$.a'))`, sqltypes.NewVarChar("ARRAY")},
+		{`JSON_TYPE(NULL)`, sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`JSON_TYPE('{"a": 1')`, `cannot parse JSON: cannot parse object: unexpected end of object; unparsed tail: ""`},
+		{`JSON_TYPE(1)`, "Invalid data type for JSON data to function JSON_TYPE; a JSON string or JSON type is required."},
+		{`JSON_TYPE('2023-01-05')`, `cannot parse JSON: cannot parse number: invalid number "2023-01-05"; unparsed tail: "2023-01-05"`},
+		{`JSON_TYPE(CAST('2023-01-05' AS DATE))`, "Invalid data type for JSON data to function JSON_TYPE; a JSON string or JSON type is required."},
+	})
+}
+
+func TestJSONQuote(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{`JSON_QUOTE('foo')`, sqltypes.NewVarChar(`"foo"`)},
+		{`JSON_QUOTE('')`, sqltypes.NewVarChar(`""`)},
+		{`JSON_QUOTE('null')`, sqltypes.NewVarChar(`"null"`)},
+		{`JSON_QUOTE('[1, 2]')`, sqltypes.NewVarChar(`"[1, 2]"`)},
+		{`JSON_QUOTE('say "hi"')`, sqltypes.NewVarChar(`"say \"hi\""`)},
+		{`JSON_QUOTE('C:\\temp')`, sqltypes.NewVarChar(`"C:\\temp"`)},
+		{`JSON_QUOTE('a\nb\tc\rd')`, sqltypes.NewVarChar(`"a\nb\tc\rd"`)},
+		{`JSON_QUOTE(CONCAT('a', CHAR(1), 'b', CHAR(31)))`, sqltypes.NewVarChar(`"a\u0001b\u001f"`)},
+		{`JSON_QUOTE('ñandú 😊')`, sqltypes.NewVarChar(`"ñandú 😊"`)},
+		{`JSON_QUOTE(_latin1 X'E9')`, sqltypes.NewVarChar(`"é"`)},
+		{`JSON_QUOTE(NULL)`, sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`JSON_QUOTE(1)`, "Incorrect type for argument 1 in function json_quote."},
+		{`JSON_QUOTE(JSON_ARRAY())`, "Incorrect type for argument 1 in function json_quote."},
+	})
+}
+
+func TestJSONMergePatch(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{`JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"a": 3, "c": 4}')`, jsonValue(`{"a": 3, "b": 2, "c": 4}`)},
+		{`JSON_MERGE_PATCH('{"a": {"x": 1}}', '{"a": {"y": 2}}')`, jsonValue(`{"a": {"x": 1, "y": 2}}`)},
+
+		// null members delete the key from the target
+		{`JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"b": null}')`, jsonValue(`{"a": 1}`)},
+		{`JSON_MERGE_PATCH('{"a": {"x": 1, "y": 2}}', '{"a": {"x": null}, "z": null}')`, jsonValue(`{"a": {"y": 2}}`)},
+
+		// patches that are not objects replace the whole value
+		{`JSON_MERGE_PATCH('{"a": 1}', '[1, 2]')`, jsonValue(`[1, 2]`)},
+		{`JSON_MERGE_PATCH('{"a": {"x": 1}}', '{"a": 5}')`, jsonValue(`{"a": 5}`)},
+		{`JSON_MERGE_PATCH('1', 'true')`, jsonValue(`true`)},
+		{`JSON_MERGE_PATCH('[1, 2]', '{"id": 47}')`, jsonValue(`{"id": 47}`)},
+
+		// arguments are merged from left to right
+		{`JSON_MERGE_PATCH('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6}')`, jsonValue(`{"a": 5, "b": 2, "c": 4, "d": 6}`)},
+		{`JSON_MERGE_PATCH('{"a": 1}', '{"a": null}', '{"a": 2}')`, jsonValue(`{"a": 2}`)},
+		{`JSON_MERGE_PATCH('{"a": 1}', '"x"', '{"b": 2}')`, jsonValue(`{"b": 2}`)},
+
+		{`JSON_MERGE_PATCH('{"a": 1}', NULL)`, sqltypes.NULL},
+		{`JSON_MERGE_PATCH(NULL, '{"a": 1}')`, sqltypes.NULL},
+		{`JSON_MERGE_PATCH('{"a": 1}', NULL, '{"b": 2}')`, sqltypes.NULL},
+		{`JSON_MERGE_PATCH(NULL, '[1]')`, jsonValue(`[1]`)},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`JSON_MERGE_PATCH('{"a": 1}', '{"b"')`, `cannot parse JSON: cannot parse object: missing ':' after object key; unparsed tail: ""`},
+		{`JSON_MERGE_PATCH('{"a": 1}', 1)`, "Invalid data type for JSON data to function JSON_MERGE_PATCH; a JSON string or JSON type is required."},
+	})
+}
+
+func TestJSONMergePreserve(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// arrays are concatenated
+		{`JSON_MERGE_PRESERVE('[1, 2]', '[true, false]')`, jsonValue(`[1, 2, true, false]`)},
+		{`JSON_MERGE_PRESERVE('[1]', '[2]', '[[3]]')`, jsonValue(`[1, 2, [3]]`)},
+
+		// objects are merged, and the values of duplicate keys are merged too
+		{`JSON_MERGE_PRESERVE('{"name": "x"}', '{"id": 47}')`, jsonValue(`{"id": 47, "name": "x"}`)},
+		{`JSON_MERGE_PRESERVE('{"a": 1, "b": 2}', '{"a": 3, "c": 4}')`, jsonValue(`{"a": [1, 3], "b": 2, "c": 4}`)},
+		{`JSON_MERGE_PRESERVE('{"a": 1, "b": 2}', '{"a": 3, "c": 4}', '{"a": 5, "d": 6}')`, jsonValue(`{"a": [1, 3, 5], "b": 2, "c": 4, "d": 6}`)},
+		{`JSON_MERGE_PRESERVE('{"a": {"x": 1}}', '{"a": {"x": 2, "y": 3}}')`, jsonValue(`{"a": {"x": [1, 2], "y": 3}}`)},
+		{`JSON_MERGE_PRESERVE('{"a": [1]}', '{"a": 2}')`, jsonValue(`{"a": [1, 2]}`)},
+
+		// values that are not arrays are wrapped before the concatenation
+		{`JSON_MERGE_PRESERVE('1', 'true')`, jsonValue(`[1, true]`)},
+		{`JSON_MERGE_PRESERVE('1', '[2, 3]')`, jsonValue(`[1, 2, 3]`)},
+		{`JSON_MERGE_PRESERVE('[1, 2]', '{"id": 47}')`, jsonValue(`[1, 2, {"id": 47}]`)},
+		{`JSON_MERGE_PRESERVE('{"id": 47}', '"x"')`, jsonValue(`[{"id": 47}, "x"]`)},
+
+		{`JSON_MERGE('[1]', '2')`, jsonValue(`[1, 2]`)},
+
+		{`JSON_MERGE_PRESERVE('[1]', NULL)`, sqltypes.NULL},
+		{`JSON_MERGE_PRESERVE(NULL, '[1]')`, sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`JSON_MERGE_PRESERVE('[1]', '[2')`, `cannot parse JSON: cannot parse array: unexpected end of array; unparsed tail: ""`},
+		{`JSON_MERGE_PRESERVE('[1]', 1)`, "Invalid data type for JSON data to function JSON_MERGE_PRESERVE; a JSON string or JSON type is required."},
+	})
+}
+
+func TestJSONModify(t *testing.T) {
+	const doc = `'{"a": 1, "b": [2, 3]}'`
+
+	testEvaluateCases(t, []evaluateCase{
+		// an existing member
+		{fmt.Sprintf(`JSON_SET(%s, '$.a', 10)`, doc), jsonValue(`{"a": 10, "b": [2, 3]}`)},
+		{fmt.Sprintf(`JSON_INSERT(%s, '$.a', 10)`, doc), jsonValue(`{"a": 1, "b": [2, 3]}`)},
+		{fmt.Sprintf(`JSON_REPLACE(%s, '$.a', 10)`, doc), jsonValue(`{"a": 10, "b": [2, 3]}`)},
+
+		// a missing member
+		{fmt.Sprintf(`JSON_SET(%s, '$.c', 'x')`, doc), jsonValue(`{"a": 1, "b": [2, 3], "c": "x"}`)},
+		{fmt.Sprintf(`JSON_INSERT(%s, '$.c', 'x')`, doc), jsonValue(`{"a": 1, "b": [2, 3], "c": "x"}`)},
+		{fmt.Sprintf(`JSON_REPLACE(%s, '$.c', 'x')`, doc), jsonValue(`{"a": 1, "b": [2, 3]}`)},
+
+		// an existing array element
+		{fmt.Sprintf(`JSON_SET(%s, '$.b[0]', 20)`, doc), jsonValue(`{"a": 1, "b": [20, 3]}`)},
+		{fmt.Sprintf(`JSON_INSERT(%s, '$.b[0]', 20)`, doc), jsonValue(`{"a": 1, "b": [2, 3]}`)},
+		{fmt.Sprintf(`JSON_REPLACE(%s, '$.b[last]', 30)`, doc), jsonValue(`{"a": 1, "b": [2, 30]}`)},
+
+		// a missing array element is appended, however far it is
+		{fmt.Sprintf(`JSON_SET(%s, '$.b[5]', 4)`, doc), jsonValue(`{"a": 1, "b": [2, 3, 4]}`)},
+		{fmt.Sprintf(`JSON_INSERT(%s, '$.b[2]', 4)`, doc), jsonValue(`{"a": 1, "b": [2, 3, 4]}`)},
+		{fmt.Sprintf(`JSON_REPLACE(%s, '$.b[2]', 4)`, doc), jsonValue(`{"a": 1, "b": [2, 3]}`)},
+
+		// values that are not arrays are wrapped when setting a position
+		{fmt.Sprintf(`JSON_SET(%s, '$.a[1]', 5)`, doc), jsonValue(`{"a": [1, 5], "b": [2, 3]}`)},
+		{fmt.Sprintf(`JSON_INSERT(%s, '$.a[1]', 5)`, doc), jsonValue(`{"a": [1, 5], "b": [2, 3]}`)},
+		{fmt.Sprintf(`JSON_REPLACE(%s, '$.a[1]', 5)`, doc), jsonValue(`{"a": 1, "b": [2, 3]}`)},
+		{fmt.Sprintf(`JSON_REPLACE(%s, '$.a[0]', 5)`, doc), jsonValue(`{"a": 5, "b": [2, 3]}`)},
+
+		// the whole document
+		{fmt.Sprintf(`JSON_SET(%s, '$', 1)`, doc), jsonValue(`1`)},
+		{fmt.Sprintf(`JSON_INSERT(%s, '$', 1)`, doc), jsonValue(`{"a": 1, "b": [2, 3]}`)},
+
+		// paths are applied from left to right
+		{fmt.Sprintf(`JSON_SET(%s, '$.c', JSON_OBJECT(), '$.c.d', 1)`, doc), jsonValue(`{"a": 1, "b": [2, 3], "c": {"d": 1}}`)},
+		{`JSON_INSERT('[1]', '$[1]', 2, '$[1]', 3)`, jsonValue(`[1, 2]`)},
+
+		// missing intermediate values are not created
+		{fmt.Sprintf(`JSON_SET(%s, '$.x.y', 1)`, doc), jsonValue(`{"a": 1, "b": [2, 3]}`)},
+
+		// strings are inserted as strings, and JSON values as such
+		{`JSON_SET('{}', '$.a', '[1]', '$.b', JSON_ARRAY(1), '$.c', NULL)`, jsonValue(`{"a": "[1]", "b": [1], "c": null}`)},
+
+		{`JSON_SET(NULL, '$.a', 1)`, sqltypes.NULL},
+		{fmt.Sprintf(`JSON_SET(%s, NULL, 1)`, doc), sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{fmt.Sprintf(`JSON_SET(%s, '$.*', 1)`, doc), "In this situation, path expressions may not contain the * and ** tokens or an array range."},
+		{fmt.Sprintf(`JSON_REPLACE(%s, '$.b[0 to 1]', 1)`, doc), "In this situation, path expressions may not contain the * and ** tokens or an array range."},
+		{fmt.Sprintf(`JSON_INSERT(%s, 'a', 1)`, doc), "Invalid JSON path expression. The error is around character position 1."},
+		{`JSON_SET('{"a"', '$.a', 1)`, `cannot parse JSON: cannot parse object: missing ':' after object key; unparsed tail: ""`},
+	})
+}
+
+func TestJSONModifyConstant(t *testing.T) {
+	// a constant document must not be modified when it's evaluated
+	env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+	expr := translateForEnv(t, `JSON_SET(JSON_ARRAY(1), '$[0]', JSON_OBJECT(), '$[0].a', 2)`)
+	for i := 0; i < 2; i++ {
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		assert.Equal(t, `[{"a": 2}]`, res.Value().ToString())
+	}
+}
+
+func TestJSONArrayAppendInsert(t *testing.T) {
+	const doc = `'["a", ["b", "c"], "d"]'`
+
+	testEvaluateCases(t, []evaluateCase{
+		{fmt.Sprintf(`JSON_ARRAY_APPEND(%s, '$[1]', 1)`, doc), jsonValue(`["a", ["b", "c", 1], "d"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_APPEND(%s, '$', 1)`, doc), jsonValue(`["a", ["b", "c"], "d", 1]`)},
+		{fmt.Sprintf(`JSON_ARRAY_APPEND(%s, '$[1][0]', 3)`, doc), jsonValue(`["a", [["b", 3], "c"], "d"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_APPEND(%s, '$[0]', 1, '$[0]', 2)`, doc), jsonValue(`[["a", 1, 2], ["b", "c"], "d"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_APPEND(%s, '$[5]', 1)`, doc), jsonValue(`["a", ["b", "c"], "d"]`)},
+		{`JSON_ARRAY_APPEND('{"a": 1, "b": [2]}', '$.b', 'x', '$.c', 'y')`, jsonValue(`{"a": 1, "b": [2, "x"]}`)},
+
+		// scalars are wrapped into an array before appending to them
+		{`JSON_ARRAY_APPEND('1', '$', 2)`, jsonValue(`[1, 2]`)},
+		{`JSON_ARRAY_APPEND('{"a": 1}', '$.a', 2)`, jsonValue(`{"a": [1, 2]}`)},
+		{`JSON_ARRAY_APPEND('{"a": 1}', '$', 2)`, jsonValue(`[{"a": 1}, 2]`)},
+		{`JSON_ARRAY_APPEND('{"a": 1}', '$.a', NULL)`, jsonValue(`{"a": [1, null]}`)},
+
+		{fmt.Sprintf(`JSON_ARRAY_INSERT(%s, '$[0]', 1)`, doc), jsonValue(`[1, "a", ["b", "c"], "d"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_INSERT(%s, '$[1]', 'x')`, doc), jsonValue(`["a", "x", ["b", "c"], "d"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_INSERT(%s, '$[100]', 'x')`, doc), jsonValue(`["a", ["b", "c"], "d", "x"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_INSERT(%s, '$[last]', 'x')`, doc), jsonValue(`["a", ["b", "c"], "x", "d"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_INSERT(%s, '$[1][0]', 'x')`, doc), jsonValue(`["a", ["x", "b", "c"], "d"]`)},
+		{fmt.Sprintf(`JSON_ARRAY_INSERT(%s, '$[0]', 'x', '$[2][1]', 'y')`, doc), jsonValue(`["x", "a", ["b", "y", "c"], "d"]`)},
+		{`JSON_ARRAY_INSERT('[]', '$[0]', 1)`, jsonValue(`[1]`)},
+		{`JSON_ARRAY_INSERT('{"a": [1, 2]}', '$.a[0]', 0)`, jsonValue(`{"a": [0, 1, 2]}`)},
+
+		// paths that don't select a position of an array are ignored
+		{`JSON_ARRAY_INSERT('{"a": 1}', '$.a[0]', 2)`, jsonValue(`{"a": 1}`)},
+		{`JSON_ARRAY_INSERT('1', '$[0]', 2)`, jsonValue(`1`)},
+		{`JSON_ARRAY_INSERT('{"a": 1}', '$.b[0]', 2)`, jsonValue(`{"a": 1}`)},
+
+		{`JSON_ARRAY_APPEND(NULL, '$', 1)`, sqltypes.NULL},
+		{`JSON_ARRAY_APPEND('[1]', NULL, 1)`, sqltypes.NULL},
+		{`JSON_ARRAY_INSERT(NULL, '$[0]', 1)`, sqltypes.NULL},
+		{`JSON_ARRAY_INSERT('[1]', NULL, 1)`, sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`JSON_ARRAY_APPEND('[1]', '$[*]', 1)`, "In this situation, path expressions may not contain the * and ** tokens or an array range."},
+		{`JSON_ARRAY_INSERT('[1]', '$**[0]', 1)`, "In this situation, path expressions may not contain the * and ** tokens or an array range."},
+		{`JSON_ARRAY_INSERT('[1]', '$', 1)`, "A path expression is not a path to a cell in an array."},
+		{`JSON_ARRAY_INSERT('{"a": [1]}', '$.a', 1)`, "A path expression is not a path to a cell in an array."},
+		{`JSON_ARRAY_APPEND('[1]', 'a', 1)`, "Invalid JSON path expression. The error is around character position 1."},
+	})
+}
+
+func TestCastToJSON(t *testing.T) {
+	// text is parsed as a JSON document, while the arguments of the JSON
+	// functions are turned into JSON strings
+	testEvaluateCases(t, []evaluateCase{
+		{`CAST('[true, false]' AS JSON)`, jsonValue(`[true, false]`)},
+		{`CAST('{"a": 1}' AS JSON)`, jsonValue(`{"a": 1}`)},
+		{`CAST('"foo"' AS JSON)`, jsonValue(`"foo"`)},
+		{`CAST('10' AS JSON)`, jsonValue(`10`)},
+		{`CAST(10 AS JSON)`, jsonValue(`10`)},
+		{`JSON_ARRAY('[true, false]')`, jsonValue(`["[true, false]"]`)},
+		{`JSON_ARRAY(CAST('[true, false]' AS JSON))`, jsonValue(`[[true, false]]`)},
+		{`JSON_INSERT('{"a": 1}', '$.c', CAST('[true, false]' AS JSON))`, jsonValue(`{"a": 1, "c": [true, false]}`)},
+		{`CAST(NULL AS JSON)`, sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`CAST('foo' AS JSON)`, "Invalid JSON text in argument 1 to function cast_as_json."},
+		{`CAST('1-2' AS JSON)`, "Invalid JSON text in argument 1 to function cast_as_json."},
+	})
+}
+
+func TestJSONRemove(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{`JSON_REMOVE('[1, 2, 3]', '$[1]')`, jsonValue(`[1, 3]`)},
+		{`JSON_REMOVE('{"a": 1, "b": [1, 2]}', '$.a')`, jsonValue(`{"b": [1, 2]}`)},
+		{`JSON_REMOVE('{"a": 1, "b": [1, 2]}', '$.b[last]')`, jsonValue(`{"a": 1, "b": [1]}`)},
+
+		// the paths are applied from left to right, so the second removal
+		// sees the array after the first one
+		{`JSON_REMOVE('[1, 2, 3, 4]', '$[0]', '$[0]')`, jsonValue(`[3, 4]`)},
+		{`JSON_REMOVE('[1, 2, 3, 4]', '$[1]', '$[2]')`, jsonValue(`[1, 3]`)},
+		{`JSON_REMOVE('{"a": [1, 2, 3]}', '$.a[0]', '$.a[last]')`, jsonValue(`{"a": [2]}`)},
+
+		// paths that don't exist are ignored
+		{`JSON_REMOVE('[1, 2]', '$[5]')`, jsonValue(`[1, 2]`)},
+		{`JSON_REMOVE('{"a": 1}', '$.b', '$.a.b', '$[1]')`, jsonValue(`{"a": 1}`)},
+		{`JSON_REMOVE('1', '$[0]')`, jsonValue(`1`)},
+
+		{`JSON_REMOVE(NULL, '$[0]')`, sqltypes.NULL},
+		{`JSON_REMOVE('[1, 2]', NULL)`, sqltypes.NULL},
+		{`JSON_REMOVE('[1, 2]', '$[0]', NULL)`, sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{`JSON_REMOVE('[1, 2]', '$')`, "The path expression '$' is not allowed in this context."},
+		{`JSON_REMOVE('[1, 2]', '$[0]', '$')`, "The path expression '$' is not allowed in this context."},
+		{`JSON_REMOVE('[1, 2]', '$[*]')`, "In this situation, path expressions may not contain the * and ** tokens or an array range."},
+		{`JSON_REMOVE('[1, 2]', '$[0 to 1]')`, "In this situation, path expressions may not contain the * and ** tokens or an array range."},
+		{`JSON_REMOVE('[1, 2]', 'a')`, "Invalid JSON path expression. The error is around character position 1."},
+	})
+}
+
+func TestJSONKeysOrder(t *testing.T) {
+	// MySQL sorts the keys of an object by length first, and then byte-wise
+	testEvaluateCases(t, []evaluateCase{
+		{`JSON_KEYS('{"b": 1, "aa": 2, "a": 3}')`, sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`["a", "b", "aa"]`))},
+		{`JSON_KEYS('{"abc": 1, "b": 2, "ab": 3, "B": 4}')`, sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`["B", "b", "ab", "abc"]`))},
+		{`JSON_KEYS('{"x": {"zz": 1, "y": 2}}', '$.x')`, sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`["y", "zz"]`))},
+		{`JSON_KEYS(JSON_OBJECT('bb', 1, 'c', 2, 'a', 3))`, sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`["a", "c", "bb"]`))},
+		{`JSON_OBJECT('bb', 1, 'c', 2, 'a', 3)`, sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`{"a": 3, "c": 2, "bb": 1}`))},
+		{`JSON_EXTRACT('{"bb": 1, "c": 2}', '$.bb')`, sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`1`))},
+	})
+}
+
+func TestJSONExtractPaths(t *testing.T) {
+	const doc = `'{"a": 1, "b": [2, 3], "c": {"d": "x"}}'`
+
+	testEvaluateCases(t, []evaluateCase{
+		// a single path without wildcards returns the bare value
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.a')", doc), jsonValue(`1`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.b')", doc), jsonValue(`[2, 3]`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.b[1]')", doc), jsonValue(`3`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.c')", doc), jsonValue(`{"d": "x"}`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.c.d')", doc), jsonValue(`"x"`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.e')", doc), sqltypes.NULL},
+
+		// several paths always return an array, even with a single match
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.a', '$.c.d')", doc), jsonValue(`[1, "x"]`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.a', '$.e')", doc), jsonValue(`[1]`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.b', '$.a')", doc), jsonValue(`[[2, 3], 1]`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.a', '$.a')", doc), jsonValue(`[1, 1]`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.e', '$.f')", doc), sqltypes.NULL},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.a', NULL)", doc), sqltypes.NULL},
+
+		// so does a single path with wildcards
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.b[*]')", doc), jsonValue(`[2, 3]`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$.c.*')", doc), jsonValue(`["x"]`)},
+		{fmt.Sprintf("JSON_EXTRACT(%s, '$**.d')", doc), jsonValue(`["x"]`)},
+	})
+}
+
+func TestJSONSizeLimit(t *testing.T) {
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{"JSON_ARRAY(REPEAT('a', 64 * 1024 * 1024))", "Result of json_array() was larger than max_allowed_packet (67108864) - truncated"},
+		{"JSON_OBJECT('a', REPEAT('a', 64 * 1024 * 1024))", "Result of json_object() was larger than max_allowed_packet (67108864) - truncated"},
+	})
+
+	cases := []struct {
+		expression string
+		expected   string
+		err        string
+	}{
+		{"JSON_ARRAY(REPEAT('a', 10))", `["aaaaaaaaaa"]`, ""},
+		{"JSON_ARRAY(REPEAT('a', 14))", `["aaaaaaaaaaaaaa"]`, ""},
+		{"JSON_ARRAY(REPEAT('a', 15))", "", "Result of json_array() was larger than max_allowed_packet (18) - truncated"},
+		{"JSON_ARRAY(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)", "", "Result of json_array() was larger than max_allowed_packet (18) - truncated"},
+		{"JSON_OBJECT('a', REPEAT('b', 10))", "", "Result of json_object() was larger than max_allowed_packet (18) - truncated"},
+		{"JSON_OBJECT('a', 1)", `{"a": 1}`, ""},
+		{"JSON_SET('[1]', '$[1]', REPEAT('a', 15))", "", "Result of json_set() was larger than max_allowed_packet (18) - truncated"},
+		{"JSON_REMOVE('[1, 2, 3, 4, 5]', '$[0]')", "[2, 3, 4, 5]", ""},
+		{"JSON_REMOVE('[1, 2, 3, 4, 5, 6, 7, 8]', '$[0]')", "", "Result of json_remove() was larger than max_allowed_packet (18) - truncated"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			// the expressions are not simplified, because folding them into
+			// constants would evaluate them with the default limit
+			expr, err := convert(t, "SELECT "+tc.expression, false)
+			require.NoError(t, err)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.MaxAllowedPacket = 18
+
+			res, err := env.Evaluate(expr)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res.Value().ToString())
+		})
+	}
+}
+
+func TestJSONDepthLimit(t *testing.T) {
+	deep := strings.Repeat("[", 300) + strings.Repeat("]", 300)
+
+	testEvaluateCases(t, []evaluateCase{
+		{fmt.Sprintf("JSON_DEPTH(JSON_ARRAY(JSON_EXTRACT('%s', '$')))", deep[1:len(deep)-1]), sqltypes.NewInt64(300)},
+	})
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{fmt.Sprintf("JSON_ARRAY(JSON_EXTRACT('%s', '$'))", deep), "The JSON document exceeds the maximum depth of 300."},
+		{fmt.Sprintf("JSON_OBJECT('a', JSON_EXTRACT('%s', '$'))", deep), "The JSON document exceeds the maximum depth of 300."},
+	})
+}
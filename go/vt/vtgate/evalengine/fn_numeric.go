@@ -18,10 +18,41 @@ package evalengine
 
 import (
 	"math"
+	"math/rand"
+	"strings"
 
 	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/decimal"
 )
 
+// integralDecimal returns the result of CEIL or FLOOR for a DECIMAL argument,
+// given the already rounded value. It's an integer when it fits, but the type
+// of the result must be known before evaluating any row, so that only happens
+// for constant arguments: any other argument always results in a DECIMAL.
+func integralDecimal(arg Expr, dec decimal.Decimal) eval {
+	if arg.constant() {
+		if i, ok := dec.Int64(); ok {
+			return newEvalInt64(i)
+		}
+	}
+	return newEvalDecimalWithPrec(dec, 0)
+}
+
+// integralDecimalType returns the type that integralDecimal returns for the
+// given DECIMAL argument, rounded with the given function.
+func integralDecimalType(env *ExpressionEnv, arg Expr, round func(decimal.Decimal) decimal.Decimal) sqltypes.Type {
+	if arg.constant() {
+		if num, err := arg.eval(env); err == nil {
+			if num, ok := num.(*evalDecimal); ok {
+				return integralDecimal(arg, round(num.dec)).SQLType()
+			}
+		}
+	}
+	return sqltypes.Decimal
+}
+
 type builtinCeil struct {
 	CallExpr
 }
@@ -41,13 +72,7 @@ func (call *builtinCeil) eval(env *ExpressionEnv) (eval, error) {
 	case *evalInt64, *evalUint64:
 		return num, nil
 	case *evalDecimal:
-		dec := num.dec
-		dec = dec.Ceil()
-		intnum, isfit := dec.Int64()
-		if isfit {
-			return newEvalInt64(intnum), nil
-		}
-		return newEvalDecimalWithPrec(dec, 0), nil
+		return integralDecimal(call.Arguments[0], num.dec.Ceil()), nil
 	default:
 		f, _ := evalToNumeric(num).toFloat()
 		return newEvalFloat(math.Ceil(f.f)), nil
@@ -56,11 +81,944 @@ func (call *builtinCeil) eval(env *ExpressionEnv) (eval, error) {
 
 func (call *builtinCeil) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
 	t, f := call.Arguments[0].typeof(env)
-	if sqltypes.IsIntegral(t) {
+	switch {
+	case sqltypes.IsUnsigned(t):
+		return sqltypes.Uint64, f
+	case sqltypes.IsIntegral(t):
+		return sqltypes.Int64, f
+	case sqltypes.Decimal == t:
+		return integralDecimalType(env, call.Arguments[0], decimal.Decimal.Ceil), f
+	default:
+		return sqltypes.Float64, f
+	}
+}
+
+type builtinFloor struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinFloor)(nil)
+
+func (call *builtinFloor) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	switch num := arg.(type) {
+	case *evalInt64, *evalUint64:
+		return num, nil
+	case *evalDecimal:
+		return integralDecimal(call.Arguments[0], num.dec.Floor()), nil
+	default:
+		f, _ := evalToNumeric(num).toFloat()
+		return newEvalFloat(math.Floor(f.f)), nil
+	}
+}
+
+func (call *builtinFloor) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	t, f := call.Arguments[0].typeof(env)
+	switch {
+	case sqltypes.IsUnsigned(t):
+		return sqltypes.Uint64, f
+	case sqltypes.IsIntegral(t):
+		return sqltypes.Int64, f
+	case sqltypes.Decimal == t:
+		return integralDecimalType(env, call.Arguments[0], decimal.Decimal.Floor), f
+	default:
+		return sqltypes.Float64, f
+	}
+}
+
+type builtinSign struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSign)(nil)
+
+func (call *builtinSign) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	switch num := evalToNumeric(arg).(type) {
+	case *evalInt64:
+		switch {
+		case num.i < 0:
+			return newEvalInt64(-1), nil
+		case num.i > 0:
+			return newEvalInt64(1), nil
+		default:
+			return newEvalInt64(0), nil
+		}
+	case *evalUint64:
+		if num.u > 0 {
+			return newEvalInt64(1), nil
+		}
+		return newEvalInt64(0), nil
+	case *evalDecimal:
+		return newEvalInt64(int64(num.dec.Sign())), nil
+	case *evalFloat:
+		// negative zero compares equal to zero, so it has no sign
+		switch {
+		case num.f < 0:
+			return newEvalInt64(-1), nil
+		case num.f > 0:
+			return newEvalInt64(1), nil
+		default:
+			return newEvalInt64(0), nil
+		}
+	default:
+		panic("unsupported")
+	}
+}
+
+func (call *builtinSign) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f
+}
+
+type builtinPow struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinPow)(nil)
+
+func (call *builtinPow) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg1 == nil || arg2 == nil {
+		return nil, nil
+	}
+
+	base, _ := evalToNumeric(arg1).toFloat()
+	exp, _ := evalToNumeric(arg2).toFloat()
+	return newEvalFloatFinite(math.Pow(base.f, exp.f)), nil
+}
+
+func (call *builtinPow) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.Float64, f1 | f2 | flagNullable
+}
+
+// newEvalFloatFinite returns the given float as an eval, or NULL if the
+// float is NaN or infinite, which is how MySQL surfaces domain errors in
+// its floating point math functions
+func newEvalFloatFinite(f float64) eval {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return newEvalFloat(f)
+}
+
+type builtinSqrt struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSqrt)(nil)
+
+func (call *builtinSqrt) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return newEvalFloatFinite(math.Sqrt(f.f)), nil
+}
+
+func (call *builtinSqrt) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f | flagNullable
+}
+
+type builtinExp struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinExp)(nil)
+
+func (call *builtinExp) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	a := math.Exp(f.f)
+	if math.IsInf(a, 0) {
+		return nil, errDoubleOutOfRange(call.Method, f.f)
+	}
+	return newEvalFloat(a), nil
+}
+
+func (call *builtinExp) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinLn struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinLn)(nil)
+
+func (call *builtinLn) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return mathLog(math.Log, f.f), nil
+}
+
+func (call *builtinLn) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f | flagNullable
+}
+
+type builtinLog struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinLog)(nil)
+
+func (call *builtinLog) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	x, _ := evalToNumeric(args[len(args)-1]).toFloat()
+	if len(args) == 1 {
+		return mathLog(math.Log, x.f), nil
+	}
+
+	// LOG(B, X) is the logarithm of X in base B
+	base, _ := evalToNumeric(args[0]).toFloat()
+	if base.f <= 0 || base.f == 1 || x.f <= 0 {
+		return nil, nil
+	}
+	return newEvalFloat(math.Log(x.f) / math.Log(base.f)), nil
+}
+
+func (call *builtinLog) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range call.Arguments {
+		_, af := arg.typeof(env)
+		f |= af
+	}
+	return sqltypes.Float64, f | flagNullable
+}
+
+type builtinLog2 struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinLog2)(nil)
+
+func (call *builtinLog2) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return mathLog(math.Log2, f.f), nil
+}
+
+func (call *builtinLog2) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f | flagNullable
+}
+
+type builtinLog10 struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinLog10)(nil)
+
+func (call *builtinLog10) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return mathLog(math.Log10, f.f), nil
+}
+
+func (call *builtinLog10) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f | flagNullable
+}
+
+// mathLog applies the given logarithm function to f. Logarithms are only
+// defined for positive values; MySQL returns NULL for any other input.
+func mathLog(log func(float64) float64, f float64) eval {
+	if f <= 0 {
+		return nil
+	}
+	return newEvalFloat(log(f))
+}
+
+type builtinPi struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinPi)(nil)
+
+func (call *builtinPi) eval(env *ExpressionEnv) (eval, error) {
+	return newEvalFloat(math.Pi), nil
+}
+
+func (call *builtinPi) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	return sqltypes.Float64, 0
+}
+
+type builtinSin struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSin)(nil)
+
+func (call *builtinSin) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return newEvalFloat(math.Sin(f.f)), nil
+}
+
+func (call *builtinSin) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinCos struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinCos)(nil)
+
+func (call *builtinCos) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return newEvalFloat(math.Cos(f.f)), nil
+}
+
+func (call *builtinCos) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinTan struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinTan)(nil)
+
+func (call *builtinTan) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return newEvalFloat(math.Tan(f.f)), nil
+}
+
+func (call *builtinTan) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinCot struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinCot)(nil)
+
+func (call *builtinCot) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	cot := 1.0 / math.Tan(f.f)
+	if math.IsInf(cot, 0) || math.IsNaN(cot) {
+		// MySQL does not return NULL for a zero tangent, it fails the query
+		return nil, errDoubleOutOfRange(call.Method, f.f)
+	}
+	return newEvalFloat(cot), nil
+}
+
+func (call *builtinCot) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinAsin struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinAsin)(nil)
+
+func (call *builtinAsin) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	if f.f < -1 || f.f > 1 {
+		return nil, nil
+	}
+	return newEvalFloat(math.Asin(f.f)), nil
+}
+
+func (call *builtinAsin) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f | flagNullable
+}
+
+type builtinAcos struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinAcos)(nil)
+
+func (call *builtinAcos) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	if f.f < -1 || f.f > 1 {
+		return nil, nil
+	}
+	return newEvalFloat(math.Acos(f.f)), nil
+}
+
+func (call *builtinAcos) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f | flagNullable
+}
+
+type builtinAtan struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinAtan)(nil)
+
+func (call *builtinAtan) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return newEvalFloat(math.Atan(f.f)), nil
+}
+
+func (call *builtinAtan) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinAtan2 struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinAtan2)(nil)
+
+func (call *builtinAtan2) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg1 == nil || arg2 == nil {
+		return nil, nil
+	}
+
+	// ATAN2(Y, X) uses the signs of both arguments to pick the quadrant
+	y, _ := evalToNumeric(arg1).toFloat()
+	x, _ := evalToNumeric(arg2).toFloat()
+	return newEvalFloat(math.Atan2(y.f, x.f)), nil
+}
+
+func (call *builtinAtan2) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.Float64, f1 | f2
+}
+
+type builtinTruncate struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinTruncate)(nil)
+
+// truncateDecimals returns the number of decimals to truncate to, out of
+// the second argument of TRUNCATE. Unsigned values that would overflow an
+// int64 are capped, since they truncate nothing anyway.
+func truncateDecimals(arg eval) int64 {
+	switch d := evalToNumeric(arg).(type) {
+	case *evalUint64:
+		if d.u > math.MaxInt64 {
+			return math.MaxInt64
+		}
+		return int64(d.u)
+	default:
+		return d.toInt64().i
+	}
+}
+
+func (call *builtinTruncate) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg1 == nil || arg2 == nil {
+		return nil, nil
+	}
+
+	d := truncateDecimals(arg2)
+
+	switch num := evalToNumeric(temporalOperand(env, call.Arguments[0], arg1)).(type) {
+	case *evalInt64:
+		if d >= 0 {
+			return num, nil
+		}
+		if -d > 18 {
+			return newEvalInt64(0), nil
+		}
+		p := int64(math.Pow10(int(-d)))
+		return newEvalInt64(num.i / p * p), nil
+	case *evalUint64:
+		if d >= 0 {
+			return num, nil
+		}
+		if -d > 19 {
+			return newEvalUint64(0), nil
+		}
+		p := uint64(math.Pow10(int(-d)))
+		return newEvalUint64(num.u / p * p), nil
+	case *evalDecimal:
+		if d > decimal.MyMaxScale {
+			d = decimal.MyMaxScale
+		} else if d < -decimal.MyMaxPrecision {
+			d = -decimal.MyMaxPrecision
+		}
+		prec := int32(d)
+		if prec < 0 {
+			prec = 0
+		}
+		return newEvalDecimalWithPrec(num.dec.Truncate(int32(d)), prec), nil
+	default:
+		f, _ := num.toFloat()
+		return newEvalFloat(truncateFloat(f.f, d)), nil
+	}
+}
+
+// truncateFloat truncates f towards zero to the given number of decimals,
+// following MySQL's my_double_round
+func truncateFloat(f float64, d int64) float64 {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	p := math.Pow(10, float64(abs))
+
+	switch {
+	case d < 0 && math.IsInf(p, 0):
+		return 0
+	case d >= 0 && math.IsInf(f*p, 0):
+		return f
+	case d < 0:
+		return math.Trunc(f/p) * p
+	default:
+		return math.Trunc(f*p) / p
+	}
+}
+
+func (call *builtinTruncate) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	t, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	f := f1 | f2&flagNullable
+	switch t = makeNumericalType(env, call.Arguments[0], t, f1); {
+	case sqltypes.IsSigned(t):
 		return sqltypes.Int64, f
-	} else if sqltypes.Decimal == t {
+	case sqltypes.IsUnsigned(t):
+		return sqltypes.Uint64, f
+	case t == sqltypes.Decimal:
 		return sqltypes.Decimal, f
-	} else {
+	default:
 		return sqltypes.Float64, f
 	}
 }
+
+type builtinConv struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinConv)(nil)
+
+const convDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// convDigit returns the value of the given character as a digit in any
+// base up to 36, or -1 if it's not a digit at all. Letters are accepted
+// in both upper and lower case.
+func convDigit(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + 10
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 10
+	default:
+		return -1
+	}
+}
+
+// convParse parses the number at the start of s in the given base, stopping
+// at the first character that is not a valid digit, like MySQL's strntoull.
+// Numbers that overflow an uint64 are clamped to its maximum value, and
+// negative numbers return their two's complement.
+func convParse(s []byte, base int) (u uint64, neg bool, overflow bool) {
+	i := 0
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		neg = s[i] == '-'
+		i++
+	}
+	for ; i < len(s); i++ {
+		d := convDigit(s[i])
+		if d < 0 || d >= base {
+			break
+		}
+		if u > (math.MaxUint64-uint64(d))/uint64(base) {
+			overflow = true
+			continue
+		}
+		u = u*uint64(base) + uint64(d)
+	}
+	if overflow {
+		return math.MaxUint64, neg, true
+	}
+	return u, neg, false
+}
+
+func (call *builtinConv) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	fromBase := evalToNumeric(args[1]).toInt64().i
+	toBase := evalToNumeric(args[2]).toInt64().i
+	if !convValidBase(fromBase) || !convValidBase(toBase) {
+		return nil, nil
+	}
+
+	var u uint64
+	if fromBase < 0 {
+		// a negative base parses the number as a signed integer
+		n, neg, overflow := convParse(args[0].ToRawBytes(), int(-fromBase))
+		switch {
+		case overflow || (!neg && n > math.MaxInt64):
+			if neg {
+				u = 1 << 63
+			} else {
+				u = math.MaxInt64
+			}
+		case neg && n > 1<<63:
+			u = 1 << 63
+		case neg:
+			u = -n
+		default:
+			u = n
+		}
+	} else {
+		n, neg, overflow := convParse(args[0].ToRawBytes(), int(fromBase))
+		u = n
+		if neg && !overflow {
+			u = -n
+		}
+	}
+
+	var buf []byte
+	if toBase < 0 && int64(u) < 0 {
+		buf = append(buf, '-')
+		buf = convFormat(buf, -u, int(-toBase))
+	} else {
+		if toBase < 0 {
+			toBase = -toBase
+		}
+		buf = convFormat(buf, u, int(toBase))
+	}
+	return newEvalText(buf, env.collation()), nil
+}
+
+func convValidBase(base int64) bool {
+	return (base >= 2 && base <= 36) || (base <= -2 && base >= -36)
+}
+
+// convFormat appends the digits of u in the given base, using
+// upper case letters for digits above 9
+func convFormat(buf []byte, u uint64, base int) []byte {
+	var a [64]byte
+	i := len(a)
+	b := uint64(base)
+	for u >= b {
+		i--
+		a[i] = convDigits[u%b]
+		u /= b
+	}
+	i--
+	a[i] = convDigits[u]
+	return append(buf, a[i:]...)
+}
+
+func (call *builtinConv) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range call.Arguments {
+		_, af := arg.typeof(env)
+		f |= af
+	}
+	return sqltypes.VarChar, f | flagNullable
+}
+
+// convToBase implements BIN and OCT, which are shorthands for converting
+// their argument with CONV(N, 10, base). Negative numbers are converted as
+// their unsigned 64-bit two's complement.
+func convToBase(env *ExpressionEnv, call *CallExpr, base int) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil || arg == nil {
+		return nil, err
+	}
+	u, neg, overflow := convParse(arg.ToRawBytes(), 10)
+	if neg && !overflow {
+		u = -u
+	}
+	return newEvalText(convFormat(nil, u, base), env.collation()), nil
+}
+
+type builtinBin struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinBin)(nil)
+
+func (call *builtinBin) eval(env *ExpressionEnv) (eval, error) {
+	return convToBase(env, &call.CallExpr, 2)
+}
+
+func (call *builtinBin) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f
+}
+
+type builtinOct struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinOct)(nil)
+
+func (call *builtinOct) eval(env *ExpressionEnv) (eval, error) {
+	return convToBase(env, &call.CallExpr, 8)
+}
+
+func (call *builtinOct) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f
+}
+
+type builtinDegrees struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinDegrees)(nil)
+
+func (call *builtinDegrees) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return newEvalFloat(f.f * (180 / math.Pi)), nil
+}
+
+func (call *builtinDegrees) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinRadians struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinRadians)(nil)
+
+func (call *builtinRadians) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	f, _ := evalToNumeric(arg).toFloat()
+	return newEvalFloat(f.f * (math.Pi / 180)), nil
+}
+
+func (call *builtinRadians) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Float64, f
+}
+
+type builtinRand struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinRand)(nil)
+
+// mysqlRandMax is the modulus of the pseudo-random number generator used by MySQL
+const mysqlRandMax = 0x3FFFFFFF
+
+// mysqlRand is a port of MySQL's my_rnd pseudo-random number generator, so
+// that seeded RAND(N) calls return the same values as MySQL
+type mysqlRand struct {
+	seed1, seed2 uint32
+}
+
+func newMySQLRand(seed int64) *mysqlRand {
+	n := uint64(uint32(seed))
+	return &mysqlRand{
+		seed1: uint32(n*0x10001+55555555) % mysqlRandMax,
+		seed2: uint32(n*0x10000001) % mysqlRandMax,
+	}
+}
+
+func (r *mysqlRand) float64() float64 {
+	r.seed1 = uint32((uint64(r.seed1)*3 + uint64(r.seed2)) % mysqlRandMax)
+	r.seed2 = uint32((uint64(r.seed1) + uint64(r.seed2) + 33) % mysqlRandMax)
+	return float64(r.seed1) / float64(mysqlRandMax)
+}
+
+func (call *builtinRand) eval(env *ExpressionEnv) (eval, error) {
+	if len(call.Arguments) == 0 {
+		return newEvalFloat(rand.Float64()), nil
+	}
+
+	// like in MySQL, a constant seed initializes the generator once per
+	// statement and the following calls continue its sequence, while any
+	// other seed initializes it again on every call
+	constant := call.Arguments[0].constant()
+	if r, ok := env.rand[call]; ok && constant {
+		return newEvalFloat(r.float64()), nil
+	}
+
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// a NULL seed behaves like a seed of 0
+	var seed int64
+	if arg != nil {
+		seed = evalToNumeric(arg).toInt64().i
+	}
+	r := newMySQLRand(seed)
+	if constant {
+		if env.rand == nil {
+			env.rand = make(map[*builtinRand]*mysqlRand)
+		}
+		env.rand[call] = r
+	}
+	return newEvalFloat(r.float64()), nil
+}
+
+func (call *builtinRand) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	for _, arg := range call.Arguments {
+		arg.typeof(env)
+	}
+	return sqltypes.Float64, 0
+}
+
+func errDoubleOutOfRange(method string, arg float64) error {
+	return vterrors.NewErrorf(vtrpcpb.Code_INVALID_ARGUMENT, vterrors.DataOutOfRange, "DOUBLE value is out of range in '%s(%v)'", strings.ToLower(method), arg)
+}
@@ -0,0 +1,572 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestPow(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"POW(2, 3)", sqltypes.NewFloat64(8)},
+		{"POWER(2, 3)", sqltypes.NewFloat64(8)},
+		{"POW(2, -1)", sqltypes.NewFloat64(0.5)},
+		{"POW(4, 0.5)", sqltypes.NewFloat64(2)},
+		{"POW(2, 0.5)", sqltypes.NewFloat64(1.4142135623730951)},
+		{"POW('9', '0.5')", sqltypes.NewFloat64(3)},
+		{"POW(0, 0)", sqltypes.NewFloat64(1)},
+		{"POW(0, -1)", sqltypes.NULL},
+		{"POW(-8, 0.5)", sqltypes.NULL},
+		{"POW(-2, 3)", sqltypes.NewFloat64(-8)},
+		{"POW(NULL, 2)", sqltypes.NULL},
+		{"POW(2, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestSign(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SIGN(-32)", sqltypes.NewInt64(-1)},
+		{"SIGN(0)", sqltypes.NewInt64(0)},
+		{"SIGN(234)", sqltypes.NewInt64(1)},
+		{"SIGN(18446744073709551615)", sqltypes.NewInt64(1)},
+		{"SIGN(-1.5)", sqltypes.NewInt64(-1)},
+		{"SIGN(-0.000001)", sqltypes.NewInt64(-1)},
+		{"SIGN(-99999999999999999999999999999999.5)", sqltypes.NewInt64(-1)},
+		{"SIGN(0.0)", sqltypes.NewInt64(0)},
+		{"SIGN(-0.0)", sqltypes.NewInt64(0)},
+		{"SIGN(1.5e0)", sqltypes.NewInt64(1)},
+		{"SIGN(1e-300)", sqltypes.NewInt64(1)},
+		{"SIGN(0.0e0)", sqltypes.NewInt64(0)},
+		{"SIGN(-0.0e0)", sqltypes.NewInt64(0)},
+		{"SIGN('-2.5')", sqltypes.NewInt64(-1)},
+		{"SIGN('abc')", sqltypes.NewInt64(0)},
+		{"SIGN(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestSignType(t *testing.T) {
+	for _, expr := range []string{
+		"SIGN(2.5)",
+		"SIGN(-2.5)",
+		"SIGN(0.000)",
+		"SIGN(2.5e0)",
+		"SIGN(-1e300)",
+		"SIGN(18446744073709551615)",
+		"SIGN('2.5')",
+		"SIGN(CAST(2.5 AS DECIMAL(10, 2)))",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			e := translateForEnv(t, expr)
+
+			tt, err := env.TypeOf(e)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.Int64, tt)
+
+			res, err := env.Evaluate(e)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.Int64, res.Value().Type())
+		})
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SQRT(16)", sqltypes.NewFloat64(4)},
+		{"SQRT(2)", sqltypes.NewFloat64(1.4142135623730951)},
+		{"SQRT(0)", sqltypes.NewFloat64(0)},
+		{"SQRT('0.25')", sqltypes.NewFloat64(0.5)},
+		{"SQRT(-1)", sqltypes.NULL},
+		{"SQRT(-0.5)", sqltypes.NULL},
+		{"SQRT(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestCeilFloorLargeDecimal(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CEIL(1.5)", sqltypes.NewInt64(2)},
+		{"CEIL(-1.5)", sqltypes.NewInt64(-1)},
+		{"FLOOR(1.5)", sqltypes.NewInt64(1)},
+		{"FLOOR(-1.5)", sqltypes.NewInt64(-2)},
+		{"FLOOR(1.5e0)", sqltypes.NewFloat64(1)},
+		{"FLOOR(NULL)", sqltypes.NULL},
+		{"CEIL(9223372036854775810.4)", sqltypes.NewDecimal("9223372036854775811")},
+		{"FLOOR(-9223372036854775810.4)", sqltypes.NewDecimal("-9223372036854775811")},
+		{"CEIL(99999999999999999999999999999999999999999999999999999999999999999)", sqltypes.NewDecimal("99999999999999999999999999999999999999999999999999999999999999999")},
+		{"FLOOR(-99999999999999999999999999999999999999999999999999999999999999999)", sqltypes.NewDecimal("-99999999999999999999999999999999999999999999999999999999999999999")},
+		{"CEIL(9999999999999999999999999999999999999999999999999999999999999999.5)", sqltypes.NewDecimal("10000000000000000000000000000000000000000000000000000000000000000")},
+		{"FLOOR(9999999999999999999999999999999999999999999999999999999999999999.5)", sqltypes.NewDecimal("9999999999999999999999999999999999999999999999999999999999999999")},
+		{"CEIL(-9999999999999999999999999999999999999999999999999999999999999999.5)", sqltypes.NewDecimal("-9999999999999999999999999999999999999999999999999999999999999999")},
+		{"FLOOR(-9999999999999999999999999999999999999999999999999999999999999999.5)", sqltypes.NewDecimal("-10000000000000000000000000000000000000000000000000000000000000000")},
+	})
+}
+
+func TestCeilFloorTypeOf(t *testing.T) {
+	const big = "99999999999999999999999999999999999999999999999999999999999999999"
+
+	// constant arguments
+	for _, fn := range []string{"CEIL", "FLOOR"} {
+		for _, arg := range []string{"1.5", "-1.5", "9223372036854775810.4", big, "-" + big, "1", "18446744073709551615", "1.5e0", "'1.5'"} {
+			expression := fmt.Sprintf("%s(%s)", fn, arg)
+			expr := translateForEnv(t, expression)
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+
+			typ, err := env.TypeOf(expr)
+			require.NoError(t, err)
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, typ, res.Value().Type(), "%s", expression)
+		}
+	}
+
+	// columns, whose values are not known before evaluating each row
+	for _, fn := range []string{"CEIL", "FLOOR"} {
+		expr := translateForEnv(t, fn+"(column0)")
+
+		for _, value := range []sqltypes.Value{
+			sqltypes.NewDecimal("1.5"),
+			sqltypes.NewDecimal("-1.5"),
+			sqltypes.NewDecimal(big),
+			sqltypes.NewDecimal("-" + big),
+			sqltypes.NewInt64(-1),
+			sqltypes.NewUint64(18446744073709551615),
+			sqltypes.NewFloat64(1.5),
+		} {
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Fields = []*querypb.Field{{Type: value.Type()}}
+			typ, err := env.TypeOf(expr)
+			require.NoError(t, err)
+
+			env.Row = []sqltypes.Value{value}
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, typ, res.Value().Type(), "%s(%v)", fn, value)
+		}
+	}
+}
+
+func TestExpLog(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"EXP(0)", sqltypes.NewFloat64(1)},
+		{"EXP(1)", sqltypes.NewFloat64(math.E)},
+		{"EXP(NULL)", sqltypes.NULL},
+		{"LN(1)", sqltypes.NewFloat64(0)},
+		{"LN(0)", sqltypes.NULL},
+		{"LN(-1)", sqltypes.NULL},
+		{"LN(NULL)", sqltypes.NULL},
+		{"LOG(1)", sqltypes.NewFloat64(0)},
+		{"LOG(0)", sqltypes.NULL},
+		{"LOG(2, 8)", sqltypes.NewFloat64(3)},
+		{"LOG(10, 100)", sqltypes.NewFloat64(2)},
+		{"LOG(2, -8)", sqltypes.NULL},
+		{"LOG(2, 0)", sqltypes.NULL},
+		{"LOG(1, 8)", sqltypes.NULL},
+		{"LOG(0, 8)", sqltypes.NULL},
+		{"LOG(-2, 8)", sqltypes.NULL},
+		{"LOG(NULL, 8)", sqltypes.NULL},
+		{"LOG(2, NULL)", sqltypes.NULL},
+		{"LOG2(8)", sqltypes.NewFloat64(3)},
+		{"LOG2(-8)", sqltypes.NULL},
+		{"LOG10(1000)", sqltypes.NewFloat64(3)},
+		{"LOG10(0)", sqltypes.NULL},
+	})
+}
+
+func TestTrig(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SIN(0)", sqltypes.NewFloat64(0)},
+		{"SIN(1.5707963267948966e0)", sqltypes.NewFloat64(1)},
+		{"SIN(3.141592653589793e0)", sqltypes.NewFloat64(math.Sin(math.Pi))},
+		{"COS(0)", sqltypes.NewFloat64(1)},
+		{"COS(1.5707963267948966e0)", sqltypes.NewFloat64(math.Cos(math.Pi / 2))},
+		{"COS(3.141592653589793e0)", sqltypes.NewFloat64(-1)},
+		{"TAN(0)", sqltypes.NewFloat64(0)},
+		{"TAN(0.7853981633974483e0)", sqltypes.NewFloat64(math.Tan(math.Pi / 4))},
+		{"TAN(3.141592653589793e0)", sqltypes.NewFloat64(math.Tan(math.Pi))},
+		{"COT(1.5707963267948966e0)", sqltypes.NewFloat64(1 / math.Tan(math.Pi/2))},
+		{"COT(0.7853981633974483e0)", sqltypes.NewFloat64(1 / math.Tan(math.Pi/4))},
+		{"SIN(NULL)", sqltypes.NULL},
+		{"COS(NULL)", sqltypes.NULL},
+		{"TAN(NULL)", sqltypes.NULL},
+		{"COT(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestPi(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"PI()", sqltypes.NewFloat64(math.Pi)},
+		{"PI() * 2", sqltypes.NewFloat64(2 * math.Pi)},
+		{"SIN(PI())", sqltypes.NewFloat64(math.Sin(math.Pi))},
+		{"COS(PI())", sqltypes.NewFloat64(-1)},
+		{"TAN(PI() / 4)", sqltypes.NewFloat64(math.Tan(math.Pi / 4))},
+	})
+}
+
+func TestInverseTrig(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"ASIN(1)", sqltypes.NewFloat64(math.Pi / 2)},
+		{"ASIN(-1)", sqltypes.NewFloat64(-math.Pi / 2)},
+		{"ASIN(1.5)", sqltypes.NULL},
+		{"ASIN(-1.5)", sqltypes.NULL},
+		{"ACOS(1)", sqltypes.NewFloat64(0)},
+		{"ACOS(-1)", sqltypes.NewFloat64(math.Pi)},
+		{"ACOS(2)", sqltypes.NULL},
+		{"ACOS(-2)", sqltypes.NULL},
+		{"ATAN(1)", sqltypes.NewFloat64(math.Pi / 4)},
+		{"ATAN(-1)", sqltypes.NewFloat64(-math.Pi / 4)},
+		{"ATAN2(1, 1)", sqltypes.NewFloat64(math.Pi / 4)},
+		{"ATAN2(1, -1)", sqltypes.NewFloat64(3 * math.Pi / 4)},
+		{"ATAN2(-1, -1)", sqltypes.NewFloat64(-3 * math.Pi / 4)},
+		{"ATAN2(-1, 1)", sqltypes.NewFloat64(-math.Pi / 4)},
+		{"ATAN2(0, -1)", sqltypes.NewFloat64(math.Pi)},
+		{"ATAN(-1, -1)", sqltypes.NewFloat64(-3 * math.Pi / 4)},
+		{"ASIN(NULL)", sqltypes.NULL},
+		{"ACOS(NULL)", sqltypes.NULL},
+		{"ATAN(NULL)", sqltypes.NULL},
+		{"ATAN2(NULL, 1)", sqltypes.NULL},
+		{"ATAN2(1, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"TRUNCATE(1.223, 1)", sqltypes.NewDecimal("1.2")},
+		{"TRUNCATE(1.999, 1)", sqltypes.NewDecimal("1.9")},
+		{"TRUNCATE(1.999, 0)", sqltypes.NewDecimal("1")},
+		{"TRUNCATE(-1.999, 1)", sqltypes.NewDecimal("-1.9")},
+		{"TRUNCATE(1.5, 3)", sqltypes.NewDecimal("1.500")},
+		{"TRUNCATE(122.5, -2)", sqltypes.NewDecimal("100")},
+		{"TRUNCATE(-122.5, -1)", sqltypes.NewDecimal("-120")},
+		{"TRUNCATE(122.5, -3)", sqltypes.NewDecimal("0")},
+		{"TRUNCATE(122, 2)", sqltypes.NewInt64(122)},
+		{"TRUNCATE(122, -2)", sqltypes.NewInt64(100)},
+		{"TRUNCATE(-122, -1)", sqltypes.NewInt64(-120)},
+		{"TRUNCATE(9223372036854775807, -19)", sqltypes.NewInt64(0)},
+		{"TRUNCATE(18446744073709551615, -19)", sqltypes.NewUint64(10000000000000000000)},
+		{"TRUNCATE(18446744073709551615, -20)", sqltypes.NewUint64(0)},
+		{"TRUNCATE(1.999e0, 1)", sqltypes.NewFloat64(1.9)},
+		{"TRUNCATE(-1.999e0, 2)", sqltypes.NewFloat64(-1.99)},
+		{"TRUNCATE(1234.5e0, -2)", sqltypes.NewFloat64(1200)},
+		{"TRUNCATE(1234.5e0, -400)", sqltypes.NewFloat64(0)},
+		{"TRUNCATE('1.999', 1)", sqltypes.NewFloat64(1.9)},
+		{"TRUNCATE(NULL, 1)", sqltypes.NULL},
+		{"TRUNCATE(1.5, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestTruncateTemporal(t *testing.T) {
+	testColumnTypeOf(t, "TRUNCATE(column0, 0)", []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Date, []byte("2023-01-05")),
+		sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2023-01-05 10:00:00")),
+		sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2023-01-05 10:00:00.5")),
+		sqltypes.MakeTrusted(sqltypes.Time, []byte("10:00:00")),
+	}, []sqltypes.Value{
+		sqltypes.NewInt64(20230105),
+		sqltypes.NewInt64(20230105100000),
+		sqltypes.NewDecimal("20230105100000"),
+		sqltypes.NewInt64(100000),
+	})
+}
+
+func TestDegreesRadians(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"RADIANS(180)", sqltypes.NewFloat64(math.Pi)},
+		{"RADIANS(90)", sqltypes.NewFloat64(math.Pi / 2)},
+		{"RADIANS(-45)", sqltypes.NewFloat64(-math.Pi / 4)},
+		{"RADIANS(0)", sqltypes.NewFloat64(0)},
+		{"DEGREES(PI())", sqltypes.NewFloat64(180)},
+		{"DEGREES(PI() / 2)", sqltypes.NewFloat64(90)},
+		{"DEGREES(-PI())", sqltypes.NewFloat64(-180)},
+		{"DEGREES(RADIANS(30))", sqltypes.NewFloat64(29.999999999999996)},
+		{"DEGREES('1.5')", sqltypes.NewFloat64(1.5 * (180 / math.Pi))},
+		{"RADIANS(NULL)", sqltypes.NULL},
+		{"DEGREES(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestConv(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CONV('FF', 16, 2)", sqltypes.NewVarChar("11111111")},
+		{"CONV('ff', 16, 10)", sqltypes.NewVarChar("255")},
+		{"CONV('a', 16, 2)", sqltypes.NewVarChar("1010")},
+		{"CONV(255, 10, 16)", sqltypes.NewVarChar("FF")},
+		{"CONV('6E', 18, 8)", sqltypes.NewVarChar("172")},
+		{"CONV('zz', 36, 10)", sqltypes.NewVarChar("1295")},
+		// output digits above 9 are always upper case
+		{"CONV(1295, 10, 36)", sqltypes.NewVarChar("ZZ")},
+		{"CONV('hello', 36, 36)", sqltypes.NewVarChar("HELLO")},
+		{"CONV(-1295, 10, -36)", sqltypes.NewVarChar("-ZZ")},
+		{"CONV(3054, 10, 16)", sqltypes.NewVarChar("BEE")},
+		{"CONV('12xyz', 10, 10)", sqltypes.NewVarChar("12")},
+		{"CONV('xyz', 10, 10)", sqltypes.NewVarChar("0")},
+		{"CONV(1.9, 10, 10)", sqltypes.NewVarChar("1")},
+		{"CONV(-17, 10, -18)", sqltypes.NewVarChar("-H")},
+		{"CONV(-1, 10, 10)", sqltypes.NewVarChar("18446744073709551615")},
+		{"CONV(-1, -10, -10)", sqltypes.NewVarChar("-1")},
+		{"CONV('FFFFFFFFFFFFFFFFFFFF', 16, 10)", sqltypes.NewVarChar("18446744073709551615")},
+		{"CONV('FFFFFFFFFFFFFFFFFFFF', -16, -10)", sqltypes.NewVarChar("9223372036854775807")},
+		{"CONV('-FFFFFFFFFFFFFFFFFFFF', -16, -10)", sqltypes.NewVarChar("-9223372036854775808")},
+		{"CONV(10, 1, 10)", sqltypes.NULL},
+		{"CONV(10, 10, 37)", sqltypes.NULL},
+		{"CONV(10, -37, 10)", sqltypes.NULL},
+		{"CONV(NULL, 10, 2)", sqltypes.NULL},
+		{"CONV(10, NULL, 2)", sqltypes.NULL},
+		{"CONV(10, 10, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestConvCaseInsensitive(t *testing.T) {
+	var cases []evaluateCase
+	for _, tc := range []struct {
+		digits   string
+		from, to int
+		expected string
+	}{
+		{"ff", 16, 2, "11111111"},
+		{"deadbeef", 16, 10, "3735928559"},
+		{"7fffffffffffffff", 16, 10, "9223372036854775807"},
+		{"-ff", -16, -10, "-255"},
+		{"vitess", 36, 10, "1906056748"},
+		{"h", 18, 10, "17"},
+	} {
+		for _, digits := range []string{tc.digits, strings.ToUpper(tc.digits)} {
+			cases = append(cases, evaluateCase{
+				expression: fmt.Sprintf("CONV('%s', %d, %d)", digits, tc.from, tc.to),
+				expected:   sqltypes.NewVarChar(tc.expected),
+			})
+		}
+	}
+	cases = append(cases,
+		evaluateCase{"CONV('DeadBeef', 16, 10)", sqltypes.NewVarChar("3735928559")},
+		evaluateCase{"CONV(_binary 'ff', 16, 10)", sqltypes.NewVarChar("255")},
+		// letters are still only digits if they are below the base
+		evaluateCase{"CONV('fg', 16, 10)", sqltypes.NewVarChar("15")},
+		evaluateCase{"CONV('FG', 16, 10)", sqltypes.NewVarChar("15")},
+	)
+	testEvaluateCases(t, cases)
+}
+
+func TestBinOct(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"BIN(12)", sqltypes.NewVarChar("1100")},
+		{"OCT(12)", sqltypes.NewVarChar("14")},
+		{"BIN(0)", sqltypes.NewVarChar("0")},
+		{"OCT(0)", sqltypes.NewVarChar("0")},
+		{"BIN('12abc')", sqltypes.NewVarChar("1100")},
+		{"BIN(12.9)", sqltypes.NewVarChar("1100")},
+		{"OCT('foo')", sqltypes.NewVarChar("0")},
+		// negative numbers are converted as unsigned 64-bit integers
+		{"BIN(-1)", sqltypes.NewVarChar("1111111111111111111111111111111111111111111111111111111111111111")},
+		{"OCT(-1)", sqltypes.NewVarChar("1777777777777777777777")},
+		{"BIN(-12)", sqltypes.NewVarChar("1111111111111111111111111111111111111111111111111111111111110100")},
+		{"OCT(-12)", sqltypes.NewVarChar("1777777777777777777764")},
+		{"BIN(18446744073709551615)", sqltypes.NewVarChar("1111111111111111111111111111111111111111111111111111111111111111")},
+		{"BIN(NULL)", sqltypes.NULL},
+		{"OCT(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestRand(t *testing.T) {
+	// values from MySQL for the first row of a seeded RAND
+	testEvaluateCases(t, []evaluateCase{
+		{"RAND(0)", sqltypes.NewFloat64(0.15522042769493574)},
+		{"RAND(1)", sqltypes.NewFloat64(0.40540353712197724)},
+		{"RAND(3)", sqltypes.NewFloat64(0.9057697559760601)},
+		{"RAND(NULL)", sqltypes.NewFloat64(0.15522042769493574)},
+	})
+
+	for _, expression := range []string{"RAND()", "RAND(3)", "RAND(1 + 2)"} {
+		expr := translateForEnv(t, expression)
+		require.IsType(t, &builtinRand{}, expr, "%s must not be folded into a constant", expression)
+		require.False(t, expr.constant())
+
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		first, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.Float64, first.Value().Type())
+
+		f, err := first.Value().ToFloat64()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, f, 0.0)
+		require.Less(t, f, 1.0)
+
+	}
+
+	// a constant seed starts a sequence that the following rows continue,
+	// with the same values as MySQL
+	for _, expression := range []string{"RAND(3)", "RAND(1 + 2)"} {
+		expr := translateForEnv(t, expression)
+
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		for _, want := range []float64{0.9057697559760601, 0.37307905813034536, 0.14808605345719125} {
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.NewFloat64(want), res.Value(), "%s", expression)
+		}
+
+		// every statement starts the sequence again
+		env = EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewFloat64(0.9057697559760601), res.Value(), "%s", expression)
+	}
+
+	// a seed that is not constant initializes the generator on every row
+	expr := translateForEnv(t, "RAND(column0)")
+
+	env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+	env.Fields = []*querypb.Field{{Type: sqltypes.Int64}}
+	for _, tc := range []struct {
+		seed int64
+		want float64
+	}{
+		{3, 0.9057697559760601},
+		{3, 0.9057697559760601},
+		{1, 0.40540353712197724},
+	} {
+		env.Row = []sqltypes.Value{sqltypes.NewInt64(tc.seed)}
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewFloat64(tc.want), res.Value(), "RAND(%d)", tc.seed)
+	}
+}
+
+func TestMathOutOfRange(t *testing.T) {
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{"COT(0)", "DOUBLE value is out of range in 'cot(0)'"},
+		{"EXP(1000)", "DOUBLE value is out of range in 'exp(1000)'"},
+	})
+}
+
+func TestCastDecimalRounding(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CAST(2.5 AS DECIMAL(1,0))", sqltypes.NewDecimal("3")},
+		{"CAST(-2.5 AS DECIMAL(1,0))", sqltypes.NewDecimal("-3")},
+		{"CAST(2.4 AS DECIMAL(1,0))", sqltypes.NewDecimal("2")},
+		{"CAST(-2.4 AS DECIMAL(1,0))", sqltypes.NewDecimal("-2")},
+		{"CAST(1.25 AS DECIMAL(3,1))", sqltypes.NewDecimal("1.3")},
+		{"CAST(-1.25 AS DECIMAL(3,1))", sqltypes.NewDecimal("-1.3")},
+		{"CAST(1.249 AS DECIMAL(3,2))", sqltypes.NewDecimal("1.25")},
+		{"CAST(0.5 AS DECIMAL)", sqltypes.NewDecimal("1")},
+		{"CAST(-0.5 AS DECIMAL)", sqltypes.NewDecimal("-1")},
+		{"CAST(2.5e0 AS DECIMAL(1,0))", sqltypes.NewDecimal("3")},
+		{"CAST('-2.5' AS DECIMAL(1,0))", sqltypes.NewDecimal("-3")},
+		{"CAST(9.5 AS DECIMAL(1,0))", sqltypes.NewDecimal("9")},
+		{"CAST(2 AS DECIMAL(3,1))", sqltypes.NewDecimal("2.0")},
+	})
+}
+
+func TestCastDecimalToSigned(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CAST(2.5 AS SIGNED)", sqltypes.NewInt64(3)},
+		{"CAST(2.4 AS SIGNED)", sqltypes.NewInt64(2)},
+		{"CAST(-2.5 AS SIGNED)", sqltypes.NewInt64(-3)},
+		{"CAST(-2.4 AS SIGNED)", sqltypes.NewInt64(-2)},
+		{"CAST(0.5 AS SIGNED)", sqltypes.NewInt64(1)},
+		{"CAST(-0.49999 AS SIGNED)", sqltypes.NewInt64(0)},
+		{"CONVERT(-2.5, SIGNED)", sqltypes.NewInt64(-3)},
+		{"CAST(9223372036854775806.5 AS SIGNED)", sqltypes.NewInt64(math.MaxInt64)},
+		{"CAST(9223372036854775807.5 AS SIGNED)", sqltypes.NewInt64(math.MaxInt64)},
+		{"CAST(-9223372036854775808.5 AS SIGNED)", sqltypes.NewInt64(math.MinInt64)},
+		{"CAST(99999999999999999999.5 AS SIGNED)", sqltypes.NewInt64(math.MaxInt64)},
+	})
+}
+
+func TestCastDecimalToChar(t *testing.T) {
+	// decimals keep the trailing zeros of their scale when converted to text
+	testEvaluateCases(t, []evaluateCase{
+		{"CAST(1.50 AS CHAR)", sqltypes.NewVarChar("1.50")},
+		{"CAST(1.500 AS CHAR)", sqltypes.NewVarChar("1.500")},
+		{"CAST(0.00 AS CHAR)", sqltypes.NewVarChar("0.00")},
+		{"CAST(-0.10 AS CHAR)", sqltypes.NewVarChar("-0.10")},
+		{"CAST(CAST(2 AS DECIMAL(10,2)) AS CHAR)", sqltypes.NewVarChar("2.00")},
+		{"CAST(CAST(1.5 AS DECIMAL(10,3)) AS CHAR)", sqltypes.NewVarChar("1.500")},
+		{"CAST(CAST(1.555 AS DECIMAL(10,2)) AS CHAR)", sqltypes.NewVarChar("1.56")},
+		{"CAST(CAST(12 AS DECIMAL(10,0)) AS CHAR)", sqltypes.NewVarChar("12")},
+		{"CAST(CAST(-7 AS DECIMAL(30,10)) AS CHAR)", sqltypes.NewVarChar("-7.0000000000")},
+		{"CAST(1.50 * 2 AS CHAR)", sqltypes.NewVarChar("3.00")},
+		{"CAST(1.50 + 1 AS CHAR)", sqltypes.NewVarChar("2.50")},
+		{"CONVERT(1.50, CHAR)", sqltypes.NewVarChar("1.50")},
+		{"CAST(1.50 AS CHAR(3))", sqltypes.NewVarChar("1.5")},
+	})
+}
+
+func TestMod(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// decimals keep their fractional remainder, with the largest scale
+		// of both arguments
+		{"MOD(5.5, 2)", sqltypes.NewDecimal("1.5")},
+		{"5.5 % 2", sqltypes.NewDecimal("1.5")},
+		{"5.5 MOD 2", sqltypes.NewDecimal("1.5")},
+		{"5.50 % 2", sqltypes.NewDecimal("1.50")},
+		{"4.0 % 2", sqltypes.NewDecimal("0.0")},
+		{"7 % 2.5", sqltypes.NewDecimal("2.0")},
+		{"5.5 % 2.25", sqltypes.NewDecimal("1.00")},
+		{"12.345 % 0.1", sqltypes.NewDecimal("0.045")},
+		{"-5.5 % 2", sqltypes.NewDecimal("-1.5")},
+		{"5.5 % -2", sqltypes.NewDecimal("1.5")},
+		{"18446744073709551615 % 1.5", sqltypes.NewDecimal("0.0")},
+		{"5.5 % 0", sqltypes.NULL},
+		{"5 % 0.00", sqltypes.NULL},
+
+		{"MOD(5, 3)", sqltypes.NewInt64(2)},
+		{"-5 % 3", sqltypes.NewInt64(-2)},
+		{"5 % -3", sqltypes.NewInt64(2)},
+		{"-9223372036854775808 % -1", sqltypes.NewInt64(0)},
+		{"18446744073709551615 % 10", sqltypes.NewUint64(5)},
+		{"18446744073709551615 % -10", sqltypes.NewUint64(5)},
+		{"-7 % 18446744073709551615", sqltypes.NewInt64(-7)},
+		{"5 % 0", sqltypes.NULL},
+
+		{"5.5e0 % 2", sqltypes.NewFloat64(1.5)},
+		{"-5.5e0 % 2", sqltypes.NewFloat64(-1.5)},
+		{"5.5 % 2e0", sqltypes.NewFloat64(1.5)},
+		{"'5.5' % 2", sqltypes.NewFloat64(1.5)},
+		{"5 % 0e0", sqltypes.NULL},
+
+		{"MOD(NULL, 2)", sqltypes.NULL},
+		{"MOD(2, NULL)", sqltypes.NULL},
+	})
+
+	types := []struct {
+		expression string
+		expected   sqltypes.Type
+	}{
+		{"5.5 % 2", sqltypes.Decimal},
+		{"5 % 2.5", sqltypes.Decimal},
+		{"5 % 2", sqltypes.Int64},
+		{"-5 % 18446744073709551615", sqltypes.Int64},
+		{"18446744073709551615 % -5", sqltypes.Uint64},
+		{"5.5e0 % 2", sqltypes.Float64},
+		{"'5.5' % 2", sqltypes.Float64},
+	}
+	for _, tc := range types {
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		tt, err := env.TypeOf(translateForEnv(t, tc.expression))
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, tt, "type of %s", tc.expression)
+	}
+}
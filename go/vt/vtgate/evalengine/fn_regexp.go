@@ -0,0 +1,336 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"vitess.io/vitess/go/hack"
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/charset"
+	"vitess.io/vitess/go/sqltypes"
+	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/vterrors"
+)
+
+// regexpFlags are the matching options of a regular expression, which can
+// be changed with the match_type argument of the REGEXP functions
+type regexpFlags uint8
+
+const (
+	regexpCaseInsensitive regexpFlags = 1 << iota
+	regexpMultiline
+	regexpDotAll
+)
+
+// regexpDefaultFlags returns the flags used for a regular expression when no
+// match_type is given: like in MySQL, matching is case insensitive only when
+// the collation of the arguments is case insensitive.
+func regexpDefaultFlags(col collations.ID) regexpFlags {
+	if col == collations.CollationBinaryID {
+		return 0
+	}
+	if strings.HasSuffix(col.Get().Name(), "_ci") {
+		return regexpCaseInsensitive
+	}
+	return 0
+}
+
+// regexpMatchType applies the options of a match_type argument to the given
+// flags. When options contradict each other, the rightmost one takes precedence.
+func regexpMatchType(matchType eval, flags regexpFlags, method string) (regexpFlags, error) {
+	for _, c := range matchType.ToRawBytes() {
+		switch c {
+		case 'c':
+			flags &^= regexpCaseInsensitive
+		case 'i':
+			flags |= regexpCaseInsensitive
+		case 'm':
+			flags |= regexpMultiline
+		case 'n':
+			flags |= regexpDotAll
+		case 'u':
+			// Unix-only line endings are the only line endings we support
+		default:
+			return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Incorrect arguments to %s.", strings.ToLower(method))
+		}
+	}
+	return flags, nil
+}
+
+func compileRegexp(pattern []byte, flags regexpFlags) (*regexp.Regexp, error) {
+	var opts string
+	if flags&regexpCaseInsensitive != 0 {
+		opts += "i"
+	}
+	if flags&regexpMultiline != 0 {
+		opts += "m"
+	}
+	if flags&regexpDotAll != 0 {
+		opts += "s"
+	}
+
+	var prefix string
+	if opts != "" {
+		prefix = "(?" + opts + ")"
+	}
+	re, err := regexp.Compile(prefix + hack.String(pattern))
+	if err != nil {
+		// the options are not part of the pattern written by the user, so
+		// they must not show up in the error
+		if serr, ok := err.(*syntax.Error); ok {
+			serr.Expr = strings.TrimPrefix(serr.Expr, prefix)
+		}
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Illegal argument to a regular expression: %v", err)
+	}
+	return re, nil
+}
+
+// regexpCompileOnce compiles the pattern of a REGEXP call like compileRegexp,
+// but only once during the statement when the pattern and match_type of the
+// call are constant, instead of compiling it again for every row.
+func regexpCompileOnce(env *ExpressionEnv, call Expr, constant bool, pattern []byte, flags regexpFlags) (*regexp.Regexp, error) {
+	if re, ok := env.regexps[call]; ok && constant {
+		return re, nil
+	}
+	re, err := compileRegexp(pattern, flags)
+	if err != nil {
+		return nil, err
+	}
+	if constant {
+		if env.regexps == nil {
+			env.regexps = make(map[Expr]*regexp.Regexp)
+		}
+		env.regexps[call] = re
+	}
+	return re, nil
+}
+
+// regexpConstant returns whether the pattern and the match_type of a REGEXP
+// call, found at the given argument positions, are constant.
+func regexpConstant(args []Expr, pattern, matchType int) bool {
+	if !args[pattern].constant() {
+		return false
+	}
+	return len(args) <= matchType || args[matchType].constant()
+}
+
+// regexpArguments converts the input and pattern of a REGEXP function into
+// UTF-8 text under their merged collation, which is what our regular
+// expression engine operates on.
+func regexpArguments(env *ExpressionEnv, input, pattern eval) ([]byte, []byte, collations.ID, error) {
+	in, ok := input.(*evalBytes)
+	if !ok || sqltypes.IsDate(in.SQLType()) {
+		var err error
+		if in, err = evalToVarchar(input, env.DefaultCollation, true); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	pat, ok := pattern.(*evalBytes)
+	if !ok || sqltypes.IsDate(pat.SQLType()) {
+		var err error
+		if pat, err = evalToVarchar(pattern, env.DefaultCollation, true); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
+	left, right, col, err := mergeCollations(in, pat)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	inb, patb := left.ToRawBytes(), right.ToRawBytes()
+	if col != collations.CollationBinaryID {
+		switch cs := col.Get().Charset().(type) {
+		case charset.Charset_utf8mb3, charset.Charset_utf8mb4:
+		default:
+			utf8 := charset.Charset_utf8mb4{}
+			if inb, err = charset.Convert(nil, utf8, inb, cs); err != nil {
+				return nil, nil, 0, err
+			}
+			if patb, err = charset.Convert(nil, utf8, patb, cs); err != nil {
+				return nil, nil, 0, err
+			}
+		}
+	}
+	return inb, patb, col, nil
+}
+
+type builtinRegexpLike struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinRegexpLike)(nil)
+
+func (r *builtinRegexpLike) eval(env *ExpressionEnv) (eval, error) {
+	args, err := r.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	input, pattern, col, err := regexpArguments(env, args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	flags := regexpDefaultFlags(col)
+	if len(args) > 2 {
+		flags, err = regexpMatchType(args[2], flags, r.Method)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	re, err := regexpCompileOnce(env, r, regexpConstant(r.Arguments, 1, 2), pattern, flags)
+	if err != nil {
+		return nil, err
+	}
+	return newEvalBool(re.Match(input)), nil
+}
+
+func (r *builtinRegexpLike) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range r.Arguments {
+		_, af := arg.typeof(env)
+		f |= af
+	}
+	return sqltypes.Int64, f
+}
+
+type builtinRegexpInstr struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinRegexpInstr)(nil)
+
+// regexpByteOffset returns the offset in bytes of the 0-based character
+// position pos in the given input. The boolean result is false if the input
+// is shorter than pos characters.
+func regexpByteOffset(input []byte, pos int64, col collations.ID) (int, bool) {
+	if col == collations.CollationBinaryID {
+		return int(pos), pos <= int64(len(input))
+	}
+	off := 0
+	for ; pos > 0; pos-- {
+		if off >= len(input) {
+			return 0, false
+		}
+		_, size := utf8.DecodeRune(input[off:])
+		off += size
+	}
+	return off, true
+}
+
+// regexpCharPosition returns the 1-based character position of the given
+// byte offset in the input.
+func regexpCharPosition(input []byte, off int, col collations.ID) int64 {
+	if col == collations.CollationBinaryID {
+		return int64(off) + 1
+	}
+	return int64(utf8.RuneCount(input[:off])) + 1
+}
+
+func (r *builtinRegexpInstr) eval(env *ExpressionEnv) (eval, error) {
+	args, err := r.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	input, pattern, col, err := regexpArguments(env, args[0], args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	pos := int64(1)
+	if len(args) > 2 {
+		pos = evalToNumeric(args[2]).toInt64().i
+	}
+	occurrence := int64(1)
+	if len(args) > 3 {
+		occurrence = evalToNumeric(args[3]).toInt64().i
+	}
+	// return_option 0 returns the position of the first character of the
+	// match, and 1 returns the position of the character following it
+	var afterMatch bool
+	if len(args) > 4 {
+		switch evalToNumeric(args[4]).toInt64().i {
+		case 0:
+		case 1:
+			afterMatch = true
+		default:
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Incorrect arguments to %s.", strings.ToLower(r.Method))
+		}
+	}
+
+	flags := regexpDefaultFlags(col)
+	if len(args) > 5 {
+		flags, err = regexpMatchType(args[5], flags, r.Method)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	re, err := regexpCompileOnce(env, r, regexpConstant(r.Arguments, 1, 5), pattern, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	var start int
+	var ok bool
+	if pos >= 1 {
+		start, ok = regexpByteOffset(input, pos-1, col)
+	}
+	if !ok {
+		return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Index out of bounds in regular expression search.")
+	}
+	if occurrence < 1 {
+		occurrence = 1
+	}
+
+	matches := re.FindAllIndex(input[start:], int(occurrence))
+	if int64(len(matches)) < occurrence {
+		return newEvalInt64(0), nil
+	}
+	match := matches[occurrence-1]
+	off := start + match[0]
+	if afterMatch {
+		off = start + match[1]
+	}
+	return newEvalInt64(regexpCharPosition(input, off, col)), nil
+}
+
+func (r *builtinRegexpInstr) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range r.Arguments {
+		_, af := arg.typeof(env)
+		f |= af
+	}
+	return sqltypes.Int64, f
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+)
+
+func TestRegexpLikeCollation(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"REGEXP_LIKE('Vitess', 'vitess')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_0900_ai_ci, '^VIT')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_general_ci, 'ESS$')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_bin, 'vitess')", sqltypes.NewInt64(0)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_bin, 'Vitess')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_0900_as_cs, 'vitess')", sqltypes.NewInt64(0)},
+		{"REGEXP_LIKE(_binary 'Vitess', 'vitess')", sqltypes.NewInt64(0)},
+		{"REGEXP_LIKE(_latin1 'Vitess' COLLATE latin1_swedish_ci, 'VITESS')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE(_latin1 'Vitess' COLLATE latin1_bin, 'VITESS')", sqltypes.NewInt64(0)},
+	})
+}
+
+func TestRegexpLikeMatchType(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_0900_ai_ci, 'vitess', 'c')", sqltypes.NewInt64(0)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_bin, 'vitess', 'i')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_bin, 'vitess', 'ic')", sqltypes.NewInt64(0)},
+		{"REGEXP_LIKE('Vitess' COLLATE utf8mb4_bin, 'vitess', 'ci')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE('a\\nb', '^b$')", sqltypes.NewInt64(0)},
+		{"REGEXP_LIKE('a\\nb', '^b$', 'm')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE('a\\nb', 'a.b')", sqltypes.NewInt64(0)},
+		{"REGEXP_LIKE('a\\nb', 'a.b', 'n')", sqltypes.NewInt64(1)},
+		{"REGEXP_LIKE(NULL, 'a')", sqltypes.NULL},
+		{"REGEXP_LIKE('a', NULL)", sqltypes.NULL},
+		{"REGEXP_LIKE('a', 'a', NULL)", sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{"REGEXP_LIKE('a', 'a', 'x')", "Incorrect arguments to regexp_like."},
+	})
+}
+
+func TestRegexpOperator(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"'Vitess' REGEXP '^vit'", sqltypes.NewInt64(1)},
+		{"'Vitess' RLIKE 'ess$'", sqltypes.NewInt64(1)},
+		{"'Vitess' REGEXP 'mysql'", sqltypes.NewInt64(0)},
+		{"'Vitess' NOT REGEXP 'mysql'", sqltypes.NewInt64(1)},
+		{"'Vitess' NOT RLIKE '^v'", sqltypes.NewInt64(0)},
+		{"'Vitess' COLLATE utf8mb4_bin REGEXP 'vitess'", sqltypes.NewInt64(0)},
+		{"NULL REGEXP 'x'", sqltypes.NULL},
+		{"'x' REGEXP NULL", sqltypes.NULL},
+		{"NULL REGEXP NULL", sqltypes.NULL},
+		{"NULL NOT REGEXP 'x'", sqltypes.NULL},
+		{"'x' NOT REGEXP NULL", sqltypes.NULL},
+		{"NOT ('Vitess' REGEXP '^v')", sqltypes.NewInt64(0)},
+		{"NOT (NULL REGEXP 'x')", sqltypes.NULL},
+		// NULL arguments return NULL before the pattern is compiled, so
+		// invalid patterns are not an error
+		{"NULL REGEXP '('", sqltypes.NULL},
+		{"REGEXP_LIKE(NULL, '(')", sqltypes.NULL},
+		{"REGEXP_LIKE('a', '(', NULL)", sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{"'a' REGEXP '('", "Illegal argument to a regular expression: error parsing regexp: missing closing ): `(`"},
+		{"'a' COLLATE utf8mb4_bin REGEXP '('", "Illegal argument to a regular expression: error parsing regexp: missing closing ): `(`"},
+		{"REGEXP_LIKE('a', 'a**', 'mn')", "Illegal argument to a regular expression: error parsing regexp: invalid nested repetition operator: `**`"},
+	})
+}
+
+func TestRegexpInstr(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"REGEXP_INSTR('dog cat dog', 'dog')", sqltypes.NewInt64(1)},
+		{"REGEXP_INSTR('dog cat dog', 'dog', 2)", sqltypes.NewInt64(9)},
+		{"REGEXP_INSTR('dog cat dog', 'dog', 1, 2)", sqltypes.NewInt64(9)},
+		{"REGEXP_INSTR('dog cat dog', 'dog', 1, 3)", sqltypes.NewInt64(0)},
+		{"REGEXP_INSTR('dog cat dog', 'dog', 1, 0)", sqltypes.NewInt64(1)},
+		{"REGEXP_INSTR('aa aaa aaaa', 'a{4}')", sqltypes.NewInt64(8)},
+		{"REGEXP_INSTR('aaa', 'aa', 2)", sqltypes.NewInt64(2)},
+		{"REGEXP_INSTR('abc', 'x')", sqltypes.NewInt64(0)},
+		{"REGEXP_INSTR('abc', 'c', 4)", sqltypes.NewInt64(0)},
+		// return_option 0 is the start of the match, 1 is the position after it
+		{"REGEXP_INSTR('dog cat dog', 'cat', 1, 1, 0)", sqltypes.NewInt64(5)},
+		{"REGEXP_INSTR('dog cat dog', 'cat', 1, 1, 1)", sqltypes.NewInt64(8)},
+		{"REGEXP_INSTR('dog cat dog', 'dog', 1, 2, 0)", sqltypes.NewInt64(9)},
+		{"REGEXP_INSTR('dog cat dog', 'dog', 1, 2, 1)", sqltypes.NewInt64(12)},
+		{"REGEXP_INSTR('dog cat dog', 'dog', 1, 3, 1)", sqltypes.NewInt64(0)},
+		{"REGEXP_INSTR('abc', '', 1, 1, 1)", sqltypes.NewInt64(1)},
+		// positions are counted in characters
+		{"REGEXP_INSTR('ñandú ñu', 'ñu')", sqltypes.NewInt64(7)},
+		{"REGEXP_INSTR('ñandú ñu', 'ñu', 1, 1, 1)", sqltypes.NewInt64(9)},
+		{"REGEXP_INSTR('ñandú ñu', 'n', 2)", sqltypes.NewInt64(3)},
+		{"REGEXP_INSTR(_binary 'ñu', 'u')", sqltypes.NewInt64(3)},
+		{"REGEXP_INSTR('Dog', 'dog', 1, 1, 0, 'c')", sqltypes.NewInt64(0)},
+		{"REGEXP_INSTR('Dog', 'dog', 1, 1, 1, 'i')", sqltypes.NewInt64(4)},
+		{"REGEXP_INSTR(NULL, 'a')", sqltypes.NULL},
+		{"REGEXP_INSTR('a', NULL)", sqltypes.NULL},
+		{"REGEXP_INSTR('a', 'a', NULL)", sqltypes.NULL},
+		{"REGEXP_INSTR('a', 'a', 1, 1, NULL)", sqltypes.NULL},
+	})
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{"REGEXP_INSTR('abc', 'a', 0)", "Index out of bounds in regular expression search."},
+		{"REGEXP_INSTR('abc', 'a', 5)", "Index out of bounds in regular expression search."},
+		{"REGEXP_INSTR('abc', 'a', 1, 1, 2)", "Incorrect arguments to regexp_instr."},
+		{"REGEXP_INSTR('abc', 'a', 1, 1, 0, 'x')", "Incorrect arguments to regexp_instr."},
+	})
+}
+
+func TestRegexpCompileOnce(t *testing.T) {
+	exprs := []Expr{
+		translateForEnv(t, "REGEXP_LIKE(column0, 'v.t')"),
+		translateForEnv(t, "REGEXP_LIKE('vitess', column0)"),
+	}
+
+	env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+	env.Row = []sqltypes.Value{sqltypes.NewVarChar("vitess")}
+	res, err := env.Evaluate(exprs[0])
+	require.NoError(t, err)
+	require.Equal(t, sqltypes.NewInt64(1), res.Value())
+
+	// a constant pattern is compiled on the first row and reused on the rest
+	require.Len(t, env.regexps, 1)
+	re := env.regexps[exprs[0]]
+	require.NotNil(t, re)
+
+	env.Row = []sqltypes.Value{sqltypes.NewVarChar("mysql")}
+	res, err = env.Evaluate(exprs[0])
+	require.NoError(t, err)
+	require.Equal(t, sqltypes.NewInt64(0), res.Value())
+	require.Same(t, re, env.regexps[exprs[0]])
+
+	// a pattern that changes on every row is not kept
+	for pattern, expected := range map[string]int64{"^vit": 1, "ess$": 1, "^ess": 0} {
+		env.Row = []sqltypes.Value{sqltypes.NewVarChar(pattern)}
+		res, err = env.Evaluate(exprs[1])
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewInt64(expected), res.Value(), pattern)
+		require.Len(t, env.regexps, 1)
+	}
+}
@@ -18,11 +18,14 @@ package evalengine
 
 import (
 	"bytes"
+	"math"
+	"strconv"
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/mysql/collations/charset"
 	"vitess.io/vitess/go/sqltypes"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
+	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
 )
 
@@ -281,9 +284,972 @@ func (c *builtinWeightString) eval(env *ExpressionEnv) (eval, error) {
 		tc = collationBinary
 		weights = make([]byte, 0, c.Len)
 		length = collations.PadToMax
+		// the result must be exactly as long as the cast: shorter inputs are
+		// padded with zero bytes, and longer ones are truncated
+		if c.HasLen && len(text) > c.Len {
+			text = text[:c.Len]
+		}
 	}
 
 	collation := tc.Collation.Get()
 	weights = collation.WeightString(weights, text, length)
 	return newEvalBinary(weights), nil
 }
+
+type builtinTrim struct {
+	CallExpr
+	trim sqlparser.TrimType
+}
+
+var _ Expr = (*builtinTrim)(nil)
+
+// trimSpace is the default padding removed by TRIM, LTRIM and RTRIM when no
+// explicit remstr is given. MySQL only strips ASCII spaces here: tabs, newlines
+// and any other whitespace are left untouched.
+var trimSpace = []byte{' '}
+
+func (call *builtinTrim) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.Arguments[0].eval(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	text, ok := arg.(*evalBytes)
+	if !ok || sqltypes.IsDate(text.SQLType()) {
+		text, err = evalToVarchar(arg, env.DefaultCollation, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pat := trimSpace
+	if len(call.Arguments) > 1 {
+		rem, err := call.Arguments[1].eval(env)
+		if err != nil {
+			return nil, err
+		}
+		if rem == nil {
+			return nil, nil
+		}
+		pat = rem.ToRawBytes()
+		if rb, ok := rem.(*evalBytes); ok && !text.isBinary() && !rb.isBinary() {
+			pat, err = charset.Convert(nil, text.col.Collation.Get().Charset(), pat, rb.col.Collation.Get().Charset())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	trimmed := text.bytes
+	if len(pat) > 0 {
+		if call.trim != sqlparser.TrailingTrimType {
+			for bytes.HasPrefix(trimmed, pat) {
+				trimmed = trimmed[len(pat):]
+			}
+		}
+		if call.trim != sqlparser.LeadingTrimType {
+			for bytes.HasSuffix(trimmed, pat) {
+				trimmed = trimmed[:len(trimmed)-len(pat)]
+			}
+		}
+	}
+	return newEvalRaw(text.SQLType(), trimmed, text.col), nil
+}
+
+func (call *builtinTrim) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	for _, arg := range call.Arguments[1:] {
+		_, f2 := arg.typeof(env)
+		f |= f2 & flagNullable
+	}
+	if sqltypes.IsBinary(tt) {
+		return sqltypes.VarBinary, f
+	}
+	return sqltypes.VarChar, f
+}
+
+type builtinSubstring struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSubstring)(nil)
+
+func (call *builtinSubstring) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	text, ok := args[0].(*evalBytes)
+	if !ok || sqltypes.IsDate(text.SQLType()) {
+		text, err = evalToVarchar(args[0], env.DefaultCollation, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cs charset.Charset
+	length := int64(len(text.bytes))
+	if !text.isBinary() {
+		cs = text.col.Collation.Get().Charset()
+		length = int64(charset.Length(cs, text.bytes))
+	}
+
+	// positions are 1-based; negative positions count from the end of the
+	// string, and a position of 0 always yields an empty string
+	pos := evalToNumeric(args[1]).toInt64().i
+	switch {
+	case pos > 0:
+		pos--
+	case pos < 0:
+		pos += length
+		if pos < 0 {
+			pos = length
+		}
+	default:
+		pos = length
+	}
+	if pos > length {
+		pos = length
+	}
+
+	count := length - pos
+	if len(args) > 2 {
+		switch n := evalToNumeric(args[2]).(type) {
+		case *evalUint64:
+			// unsigned lengths are never negative, even if they overflow
+			// an int64
+			if n.u < uint64(count) {
+				count = int64(n.u)
+			}
+		default:
+			if i := n.toInt64().i; i < count {
+				count = i
+			}
+		}
+		if count < 0 {
+			count = 0
+		}
+	}
+
+	var sub []byte
+	if cs == nil {
+		sub = text.bytes[pos : pos+count]
+	} else {
+		sub = text.bytes[len(charset.Slice(cs, text.bytes, 0, int(pos))):]
+		sub = charset.Slice(cs, sub, 0, int(count))
+	}
+	return newEvalRaw(text.SQLType(), sub, text.col), nil
+}
+
+func (call *builtinSubstring) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	for _, arg := range call.Arguments[1:] {
+		_, f2 := arg.typeof(env)
+		f |= f2 & flagNullable
+	}
+	if sqltypes.IsBinary(tt) {
+		return sqltypes.VarBinary, f
+	}
+	return sqltypes.VarChar, f
+}
+
+type builtinConcat struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinConcat)(nil)
+
+type builtinConcatWS struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinConcatWS)(nil)
+
+// concatCollation returns the collation with which the given argument takes
+// part in a string concatenation. Values that are not strings are converted
+// into strings in the connection's collation.
+func concatCollation(env *ExpressionEnv, arg eval) collations.TypedCollation {
+	if b, ok := arg.(*evalBytes); ok && !sqltypes.IsDate(b.SQLType()) {
+		return b.col
+	}
+	return collations.TypedCollation{
+		Collation:    env.DefaultCollation,
+		Coercibility: collations.CoerceNumeric,
+		Repertoire:   collations.RepertoireASCII,
+	}
+}
+
+func (call *builtinConcat) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var ca collationAggregation
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+		if err := ca.add(collations.Local(), concatCollation(env, arg)); err != nil {
+			return nil, err
+		}
+	}
+	tc := ca.result()
+
+	// values that are not strings, like the 1 or 0 of a comparison, are
+	// concatenated as their textual representation
+	var buf = []byte{}
+	for _, arg := range args {
+		text, err := evalToVarchar(arg, tc.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, text.bytes...)
+	}
+
+	tt := sqltypes.VarChar
+	if tc.Collation == collations.CollationBinaryID {
+		tt = sqltypes.VarBinary
+	}
+	return newEvalRaw(tt, buf, tc), nil
+}
+
+func (call *builtinConcat) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	var binary bool
+	for _, arg := range call.Arguments {
+		tt, f2 := arg.typeof(env)
+		f |= f2 & flagNullable
+		binary = binary || sqltypes.IsBinary(tt)
+	}
+	if binary {
+		return sqltypes.VarBinary, f
+	}
+	return sqltypes.VarChar, f
+}
+
+func (call *builtinConcatWS) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+
+	var ca collationAggregation
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		if err := ca.add(collations.Local(), concatCollation(env, arg)); err != nil {
+			return nil, err
+		}
+	}
+	tc := ca.result()
+
+	sep, err := evalToVarchar(args[0], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// NULL values are skipped entirely, so they are not surrounded by separators
+	var buf = []byte{}
+	var first = true
+	for _, arg := range args[1:] {
+		if arg == nil {
+			continue
+		}
+		text, err := evalToVarchar(arg, tc.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf = append(buf, sep.bytes...)
+		}
+		buf = append(buf, text.bytes...)
+		first = false
+	}
+
+	tt := sqltypes.VarChar
+	if tc.Collation == collations.CollationBinaryID {
+		tt = sqltypes.VarBinary
+	}
+	return newEvalRaw(tt, buf, tc), nil
+}
+
+func (call *builtinConcatWS) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	binary := sqltypes.IsBinary(tt)
+	for _, arg := range call.Arguments[1:] {
+		tt, _ := arg.typeof(env)
+		binary = binary || sqltypes.IsBinary(tt)
+	}
+	if binary {
+		return sqltypes.VarBinary, f
+	}
+	return sqltypes.VarChar, f
+}
+
+type builtinField struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinField)(nil)
+
+func (call *builtinField) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil {
+		return newEvalInt64(0), nil
+	}
+
+	// Like MySQL, the comparison mode depends on the types of all the
+	// arguments: strings are only compared as strings when every argument
+	// is a string, and numbers are compared as doubles unless they are
+	// all exact numeric values.
+	var allText, allExact = true, true
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		switch tt := arg.SQLType(); {
+		case typeIsTextual(tt):
+			allExact = false
+		case sqltypes.IsIntegral(tt) || tt == sqltypes.Decimal:
+			allText = false
+		default:
+			allText = false
+			allExact = false
+		}
+	}
+
+	switch {
+	case allText:
+		var ca collationAggregation
+		for _, arg := range args {
+			if arg == nil {
+				continue
+			}
+			if err := ca.add(collations.Local(), evalCollation(arg)); err != nil {
+				return nil, err
+			}
+		}
+		tc := ca.result()
+		col := tc.Collation.Get()
+
+		str, err := evalToVarchar(args[0], tc.Collation, true)
+		if err != nil {
+			return nil, err
+		}
+		for i, arg := range args[1:] {
+			if arg == nil {
+				continue
+			}
+			other, err := evalToVarchar(arg, tc.Collation, true)
+			if err != nil {
+				return nil, err
+			}
+			if col.Collate(str.bytes, other.bytes, false) == 0 {
+				return newEvalInt64(int64(i + 1)), nil
+			}
+		}
+
+	case allExact:
+		for i, arg := range args[1:] {
+			if arg == nil {
+				continue
+			}
+			cmp, err := compareNumeric(args[0], arg)
+			if err != nil {
+				return nil, err
+			}
+			if cmp == 0 {
+				return newEvalInt64(int64(i + 1)), nil
+			}
+		}
+
+	default:
+		f, _ := evalToNumeric(args[0]).toFloat()
+		for i, arg := range args[1:] {
+			if arg == nil {
+				continue
+			}
+			other, _ := evalToNumeric(arg).toFloat()
+			if f.f == other.f {
+				return newEvalInt64(int64(i + 1)), nil
+			}
+		}
+	}
+	return newEvalInt64(0), nil
+}
+
+func (call *builtinField) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	for _, arg := range call.Arguments {
+		arg.typeof(env)
+	}
+	return sqltypes.Int64, 0
+}
+
+type builtinPad struct {
+	CallExpr
+	left bool
+}
+
+var _ Expr = (*builtinPad)(nil)
+
+func (call *builtinPad) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	// unlike a length of 0, which yields an empty string, a negative
+	// length always yields NULL
+	length := evalToNumeric(args[1]).toInt64().i
+	if length < 0 || length > env.maxAllowedPacket() {
+		return nil, nil
+	}
+
+	var ca collationAggregation
+	if err := ca.add(collations.Local(), concatCollation(env, args[0])); err != nil {
+		return nil, err
+	}
+	if err := ca.add(collations.Local(), concatCollation(env, args[2])); err != nil {
+		return nil, err
+	}
+	tc := ca.result()
+
+	text, err := evalToVarchar(args[0], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+	pad, err := evalToVarchar(args[2], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tt := sqltypes.VarChar
+	if tc.Collation == collations.CollationBinaryID {
+		tt = sqltypes.VarBinary
+	}
+
+	var cs charset.Charset
+	textLength := len(text.bytes)
+	padLength := len(pad.bytes)
+	if tt != sqltypes.VarBinary {
+		cs = tc.Collation.Get().Charset()
+		textLength = charset.Length(cs, text.bytes)
+		padLength = charset.Length(cs, pad.bytes)
+	}
+
+	if int64(textLength) >= length {
+		if cs == nil {
+			return newEvalRaw(tt, text.bytes[:length], tc), nil
+		}
+		return newEvalRaw(tt, charset.Slice(cs, text.bytes, 0, int(length)), tc), nil
+	}
+	if padLength == 0 {
+		return nil, nil
+	}
+
+	fill := int(length) - textLength
+	buf := make([]byte, 0, len(text.bytes)+fill/padLength*len(pad.bytes)+len(pad.bytes))
+	if !call.left {
+		buf = append(buf, text.bytes...)
+	}
+	for ; fill >= padLength; fill -= padLength {
+		buf = append(buf, pad.bytes...)
+	}
+	if fill > 0 {
+		if cs == nil {
+			buf = append(buf, pad.bytes[:fill]...)
+		} else {
+			buf = append(buf, charset.Slice(cs, pad.bytes, 0, fill)...)
+		}
+	}
+	if call.left {
+		buf = append(buf, text.bytes...)
+	}
+	return newEvalRaw(tt, buf, tc), nil
+}
+
+func (call *builtinPad) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	binary := sqltypes.IsBinary(tt)
+	for _, arg := range call.Arguments[1:] {
+		tt, f2 := arg.typeof(env)
+		binary = binary || sqltypes.IsBinary(tt)
+		f |= f2
+	}
+	f |= flagNullable
+	if binary {
+		return sqltypes.VarBinary, f
+	}
+	return sqltypes.VarChar, f
+}
+
+type builtinSubstringIndex struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSubstringIndex)(nil)
+
+func (call *builtinSubstringIndex) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	// the result keeps the collation of the string, unless the delimiter
+	// has a stronger one
+	var ca collationAggregation
+	if err := ca.add(collations.Local(), concatCollation(env, args[0])); err != nil {
+		return nil, err
+	}
+	if err := ca.add(collations.Local(), concatCollation(env, args[1])); err != nil {
+		return nil, err
+	}
+	tc := ca.result()
+
+	text, err := evalToVarchar(args[0], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+	delim, err := evalToVarchar(args[1], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tt := sqltypes.VarChar
+	if tc.Collation == collations.CollationBinaryID {
+		tt = sqltypes.VarBinary
+	}
+
+	count := evalToNumeric(args[2]).toInt64().i
+	if count == 0 || len(delim.bytes) == 0 {
+		return newEvalRaw(tt, []byte{}, tc), nil
+	}
+
+	// like in MySQL, the delimiter is always matched case-sensitively, and
+	// its occurrences are counted without overlapping
+	str := text.bytes
+	if count > 0 {
+		offset := 0
+		for ; count > 0; count-- {
+			idx := bytes.Index(str[offset:], delim.bytes)
+			if idx < 0 {
+				return newEvalRaw(tt, str, tc), nil
+			}
+			offset += idx
+			if count > 1 {
+				offset += len(delim.bytes)
+			}
+		}
+		return newEvalRaw(tt, str[:offset], tc), nil
+	}
+
+	end := len(str)
+	for ; count < 0; count++ {
+		idx := bytes.LastIndex(str[:end], delim.bytes)
+		if idx < 0 {
+			return newEvalRaw(tt, str, tc), nil
+		}
+		end = idx
+	}
+	return newEvalRaw(tt, str[end+len(delim.bytes):], tc), nil
+}
+
+func (call *builtinSubstringIndex) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	binary := sqltypes.IsBinary(tt)
+	for _, arg := range call.Arguments[1:] {
+		tt, f2 := arg.typeof(env)
+		binary = binary || sqltypes.IsBinary(tt)
+		f |= f2 & flagNullable
+	}
+	if binary {
+		return sqltypes.VarBinary, f
+	}
+	return sqltypes.VarChar, f
+}
+
+type builtinLocate struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinLocate)(nil)
+
+// builtinInstr implements INSTR, which is LOCATE with its arguments swapped.
+type builtinInstr struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinInstr)(nil)
+
+// locate returns the 1-based position of the first occurrence of substr in
+// str, starting at the given position if it's not nil. Like in MySQL, the
+// search uses the collation of the arguments, and positions are counted in
+// characters unless the arguments are binary, in which case they are counted
+// in bytes.
+func locate(env *ExpressionEnv, substr, str, start eval) (eval, error) {
+	var ca collationAggregation
+	if err := ca.add(collations.Local(), concatCollation(env, str)); err != nil {
+		return nil, err
+	}
+	if err := ca.add(collations.Local(), concatCollation(env, substr)); err != nil {
+		return nil, err
+	}
+	tc := ca.result()
+
+	text, err := evalToVarchar(str, tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := evalToVarchar(substr, tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var cs charset.Charset
+	coll := tc.Collation.Get()
+	if tc.Collation != collations.CollationBinaryID {
+		cs = coll.Charset()
+	}
+
+	var pos int64
+	var offset int
+	if start != nil {
+		// MySQL checks the start position against the length of the string
+		// in bytes, even when positions are counted in characters
+		pos = evalToNumeric(start).toInt64().i
+		if pos <= 0 || pos > int64(len(text.bytes)) {
+			return newEvalInt64(0), nil
+		}
+		pos--
+		offset = int(pos)
+		if cs != nil {
+			offset = len(charset.Slice(cs, text.bytes, 0, offset))
+		}
+		if offset+len(sub.bytes) > len(text.bytes) {
+			return newEvalInt64(0), nil
+		}
+	}
+
+	if len(sub.bytes) == 0 {
+		// like in MySQL, an empty string is found at the byte offset of the
+		// start position
+		return newEvalInt64(int64(offset) + 1), nil
+	}
+
+	if cs == nil {
+		idx := bytes.Index(text.bytes[offset:], sub.bytes)
+		if idx < 0 {
+			return newEvalInt64(0), nil
+		}
+		return newEvalInt64(pos + int64(idx) + 1), nil
+	}
+
+	haystack := text.bytes[offset:]
+	for b := 0; b+len(sub.bytes) <= len(haystack); pos++ {
+		if coll.Collate(haystack[b:b+len(sub.bytes)], sub.bytes, true) == 0 {
+			return newEvalInt64(pos + 1), nil
+		}
+		_, size := cs.DecodeRune(haystack[b:])
+		if size < 1 {
+			size = 1
+		}
+		b += size
+	}
+	return newEvalInt64(0), nil
+}
+
+func (call *builtinLocate) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+	var start eval
+	if len(args) > 2 {
+		start = args[2]
+	}
+	return locate(env, args[0], args[1], start)
+}
+
+func (call *builtinLocate) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	var f typeFlag
+	for _, arg := range call.Arguments {
+		_, f2 := arg.typeof(env)
+		f |= f2 & flagNullable
+	}
+	return sqltypes.Int64, f
+}
+
+func (call *builtinInstr) eval(env *ExpressionEnv) (eval, error) {
+	str, substr, err := call.arg2(env)
+	if err != nil || str == nil || substr == nil {
+		return nil, err
+	}
+	return locate(env, substr, str, nil)
+}
+
+func (call *builtinInstr) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.Int64, (f1 | f2) & flagNullable
+}
+
+type builtinChar struct {
+	CallExpr
+	collate collations.ID
+}
+
+var _ Expr = (*builtinChar)(nil)
+
+// encodeChar appends the bytes of the given code to buf, in big-endian order
+// and without leading zero bytes, like MySQL's CHAR does.
+func encodeChar(buf []byte, code uint32) []byte {
+	switch {
+	case code&0xFF000000 != 0:
+		buf = append(buf, byte(code>>24), byte(code>>16), byte(code>>8), byte(code))
+	case code&0xFF0000 != 0:
+		buf = append(buf, byte(code>>16), byte(code>>8), byte(code))
+	case code&0xFF00 != 0:
+		buf = append(buf, byte(code>>8), byte(code))
+	default:
+		buf = append(buf, byte(code))
+	}
+	return buf
+}
+
+func (call *builtinChar) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// NULL arguments are skipped
+	buf := make([]byte, 0, len(args))
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		buf = encodeChar(buf, uint32(evalToNumeric(arg).toInt64().i))
+	}
+
+	if call.collate == collations.CollationBinaryID {
+		return newEvalBinary(buf), nil
+	}
+	// like in MySQL, the result is NULL if it's not valid in the charset
+	// given with USING
+	cs := call.collate.Get().Charset()
+	if !charset.Validate(cs, buf) {
+		return nil, nil
+	}
+	return newEvalText(buf, collations.TypedCollation{
+		Collation:    call.collate,
+		Coercibility: collations.CoerceCoercible,
+		Repertoire:   collations.RepertoireUnicode,
+	}), nil
+}
+
+func (call *builtinChar) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	for _, arg := range call.Arguments {
+		arg.typeof(env)
+	}
+	if call.collate == collations.CollationBinaryID {
+		return sqltypes.VarBinary, 0
+	}
+	return sqltypes.VarChar, flagNullable
+}
+
+type builtinReplace struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinReplace)(nil)
+
+func (call *builtinReplace) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	// the collation of the result is derived from all three arguments
+	var ca collationAggregation
+	for _, arg := range args {
+		if err := ca.add(collations.Local(), concatCollation(env, arg)); err != nil {
+			return nil, err
+		}
+	}
+	tc := ca.result()
+
+	text, err := evalToVarchar(args[0], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+	from, err := evalToVarchar(args[1], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+	to, err := evalToVarchar(args[2], tc.Collation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tt := sqltypes.VarChar
+	var cs charset.Charset
+	if tc.Collation == collations.CollationBinaryID {
+		tt = sqltypes.VarBinary
+	} else {
+		cs = tc.Collation.Get().Charset()
+	}
+
+	if len(from.bytes) == 0 {
+		return newEvalRaw(tt, text.bytes, tc), nil
+	}
+
+	// like in MySQL, the search is case-sensitive, and it only matches at
+	// the boundaries of characters
+	str := text.bytes
+	buf := make([]byte, 0, len(str))
+	last := 0
+	for b := 0; b+len(from.bytes) <= len(str); {
+		if bytes.HasPrefix(str[b:], from.bytes) {
+			buf = append(buf, str[last:b]...)
+			buf = append(buf, to.bytes...)
+			b += len(from.bytes)
+			last = b
+			continue
+		}
+		size := 1
+		if cs != nil {
+			if _, size = cs.DecodeRune(str[b:]); size < 1 {
+				size = 1
+			}
+		}
+		b += size
+	}
+	buf = append(buf, str[last:]...)
+	return newEvalRaw(tt, buf, tc), nil
+}
+
+func (call *builtinReplace) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	binary := sqltypes.IsBinary(tt)
+	for _, arg := range call.Arguments[1:] {
+		tt, f2 := arg.typeof(env)
+		binary = binary || sqltypes.IsBinary(tt)
+		f |= f2 & flagNullable
+	}
+	if binary {
+		return sqltypes.VarBinary, f
+	}
+	return sqltypes.VarChar, f
+}
+
+type builtinFormat struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinFormat)(nil)
+
+// formatMaxDecimals is the largest number of decimals that FORMAT will
+// output, like MySQL's FORMAT_MAX_DECIMALS.
+const formatMaxDecimals = 30
+
+// formatGroupDigits adds a ',' between every group of three digits of the
+// integral part of num, which must be a number formatted with exactly dec
+// decimals. The sign of negative numbers stays in front of the digits.
+func formatGroupDigits(num []byte, dec int64) []byte {
+	var sign []byte
+	if len(num) > 0 && num[0] == '-' {
+		sign, num = num[:1], num[1:]
+	}
+	end := len(num)
+	if dec > 0 {
+		end -= int(dec) + 1
+	}
+	integral, frac := num[:end], num[end:]
+
+	buf := make([]byte, 0, len(sign)+len(integral)+len(integral)/3+len(frac))
+	buf = append(buf, sign...)
+	for i, c := range integral {
+		if i > 0 && (len(integral)-i)%3 == 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, c)
+	}
+	return append(buf, frac...)
+}
+
+func (call *builtinFormat) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg1 == nil || arg2 == nil {
+		return nil, nil
+	}
+
+	dec := truncateDecimals(arg2)
+	if dec < 0 {
+		dec = 0
+	} else if dec > formatMaxDecimals {
+		dec = formatMaxDecimals
+	}
+
+	// like in MySQL, integers and decimals are rounded as decimals, while
+	// any other value is rounded as a float with my_double_round
+	var num []byte
+	switch arg := arg1.(type) {
+	case *evalInt64, *evalUint64, *evalDecimal:
+		d := evalToNumeric(arg).toDecimal(0, 0)
+		num = d.dec.Round(int32(dec)).FormatMySQL(int32(dec))
+	default:
+		f, _ := evalToNumeric(arg).toFloat()
+		if p := math.Pow10(int(dec)); !math.IsInf(f.f*p, 0) {
+			f.f = math.RoundToEven(f.f*p) / p
+		}
+		num = strconv.AppendFloat(nil, f.f, 'f', int(dec), 64)
+	}
+
+	return newEvalText(formatGroupDigits(num, dec), env.collation()), nil
+}
+
+func (call *builtinFormat) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.VarChar, f1 | f2
+}
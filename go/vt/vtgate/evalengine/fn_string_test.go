@@ -0,0 +1,475 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+)
+
+func TestTrim(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"TRIM('  abc  ')", sqltypes.NewVarChar("abc")},
+		{"LTRIM('  abc  ')", sqltypes.NewVarChar("abc  ")},
+		{"RTRIM('  abc  ')", sqltypes.NewVarChar("  abc")},
+		{"TRIM(BOTH FROM '  abc  ')", sqltypes.NewVarChar("abc")},
+		{"TRIM('\\tabc\\t')", sqltypes.NewVarChar("\tabc\t")},
+		{"TRIM('\\nabc\\n')", sqltypes.NewVarChar("\nabc\n")},
+		{"TRIM(' \\t abc \\n ')", sqltypes.NewVarChar("\t abc \n")},
+		{"LTRIM('\\t abc')", sqltypes.NewVarChar("\t abc")},
+		{"RTRIM('abc \\n')", sqltypes.NewVarChar("abc \n")},
+		{"TRIM(LEADING 'x' FROM 'xxabcxx')", sqltypes.NewVarChar("abcxx")},
+		{"TRIM(TRAILING 'x' FROM 'xxabcxx')", sqltypes.NewVarChar("xxabc")},
+		{"TRIM('x' FROM 'xxabcxx')", sqltypes.NewVarChar("abc")},
+		{"TRIM(NULL)", sqltypes.NULL},
+		{"TRIM(NULL FROM 'abc')", sqltypes.NULL},
+	})
+}
+
+func TestTrimMultiCharRemstr(t *testing.T) {
+	// a remstr with several characters is only removed as a whole sequence,
+	// and never as a set of individual characters
+	testEvaluateCases(t, []evaluateCase{
+		{"TRIM('xyz' FROM 'xyzxyzab')", sqltypes.NewVarChar("ab")},
+		{"TRIM('xyz' FROM 'abxyzxyz')", sqltypes.NewVarChar("ab")},
+		{"TRIM('xyz' FROM 'xyzabxyz')", sqltypes.NewVarChar("ab")},
+		{"TRIM('xyz' FROM 'zyxabzyx')", sqltypes.NewVarChar("zyxabzyx")},
+		{"TRIM('xyz' FROM 'xxyyzzab')", sqltypes.NewVarChar("xxyyzzab")},
+		{"TRIM('xyz' FROM 'xyab')", sqltypes.NewVarChar("xyab")},
+		{"TRIM('xyz' FROM 'xyzxyab')", sqltypes.NewVarChar("xyab")},
+		{"TRIM(LEADING 'xy' FROM 'xyxxyab')", sqltypes.NewVarChar("xxyab")},
+		{"TRIM(TRAILING 'xy' FROM 'abyxyxy')", sqltypes.NewVarChar("aby")},
+		{"TRIM('ab' FROM 'abababa')", sqltypes.NewVarChar("a")},
+		{"TRIM('aa' FROM 'aaaaa')", sqltypes.NewVarChar("a")},
+		{"TRIM('xyz' FROM 'xyz')", sqltypes.NewVarChar("")},
+		{"TRIM('abc' FROM 'ab')", sqltypes.NewVarChar("ab")},
+		{"TRIM('ñá' FROM 'ñáñáxñá')", sqltypes.NewVarChar("x")},
+		{"TRIM(_binary 'xyz' FROM _binary 'xyzxyzab')", sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("ab"))},
+	})
+}
+
+func TestSubstring(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SUBSTRING('abcdef', 3)", sqltypes.NewVarChar("cdef")},
+		{"SUBSTRING('abcdef' FROM 3 FOR 2)", sqltypes.NewVarChar("cd")},
+		{"SUBSTRING('abcdef', -2)", sqltypes.NewVarChar("ef")},
+		{"SUBSTRING('abcdef', -10)", sqltypes.NewVarChar("")},
+		{"SUBSTRING('abcdef', 0)", sqltypes.NewVarChar("")},
+		{"SUBSTRING('abcdef', 10)", sqltypes.NewVarChar("")},
+		{"SUBSTRING('abcdef', 2, -1)", sqltypes.NewVarChar("")},
+		{"SUBSTR('abcdef', 2, 100)", sqltypes.NewVarChar("bcdef")},
+		{"SUBSTRING('ñandú', 2, 3)", sqltypes.NewVarChar("and")},
+		{"SUBSTRING('abcdef' FROM LENGTH('abcdef') - 2)", sqltypes.NewVarChar("def")},
+		{"SUBSTRING('abcdef' FROM 1 + 1 FOR 4 - 2)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING(NULL, 1)", sqltypes.NULL},
+		{"SUBSTRING('abcdef', NULL)", sqltypes.NULL},
+		{"SUBSTRING('abcdef', 1, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestSubstringOverlongLength(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SUBSTRING('abc', 2, 100)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', 2, 2)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', 2, 3)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', 2, 1)", sqltypes.NewVarChar("b")},
+		{"SUBSTRING('abc', 1, 3)", sqltypes.NewVarChar("abc")},
+		{"SUBSTRING('abc', 3, 1)", sqltypes.NewVarChar("c")},
+		{"SUBSTRING('abc', 3, 2)", sqltypes.NewVarChar("c")},
+		{"SUBSTRING('abc', -2, 2)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', -2, 5)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', 2, 9223372036854775807)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', 2, 18446744073709551615)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', 2, 1e30)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('abc', 2, 100000000000000000000000000000)", sqltypes.NewVarChar("bc")},
+		{"SUBSTRING('ñandú', 4, 2)", sqltypes.NewVarChar("dú")},
+		{"SUBSTRING('ñandú', 4, 3)", sqltypes.NewVarChar("dú")},
+		{"SUBSTRING(_binary 'abc', 2, 100)", sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("bc"))},
+	})
+}
+
+func TestSubstringComputedPosition(t *testing.T) {
+	expr := translateForEnv(t, "SUBSTRING(column0 FROM LENGTH(column0) - 2 FOR column1)")
+
+	var cases = []struct {
+		str      string
+		count    int64
+		expected string
+	}{
+		{"abcdef", 3, "def"},
+		{"abcdef", 1, "d"},
+		{"vitess", 2, "es"},
+		{"ab", 3, ""},
+		{"xyz", 3, "xyz"},
+	}
+
+	for _, tc := range cases {
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		env.Row = []sqltypes.Value{sqltypes.NewVarChar(tc.str), sqltypes.NewInt64(tc.count)}
+		env.Fields = []*querypb.Field{{Type: sqltypes.VarChar}, {Type: sqltypes.Int64}}
+
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		assert.Equal(t, sqltypes.NewVarChar(tc.expected), res.Value(), "SUBSTRING(%q FROM LENGTH(%q) - 2 FOR %d)", tc.str, tc.str, tc.count)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CONCAT('a', 'b', 'c')", sqltypes.NewVarChar("abc")},
+		{"CONCAT('a')", sqltypes.NewVarChar("a")},
+		{"CONCAT('a', '', 'b')", sqltypes.NewVarChar("ab")},
+		{"CONCAT('a', 1, 2.5)", sqltypes.NewVarChar("a12.5")},
+		{"CONCAT('x', 1 = 1)", sqltypes.NewVarChar("x1")},
+		{"CONCAT('x', 1 = 2, 'y')", sqltypes.NewVarChar("x0y")},
+		{"CONCAT('x', 'a' < 'b')", sqltypes.NewVarChar("x1")},
+		{"CONCAT('a', NULL, 'b')", sqltypes.NULL},
+		{"CONCAT('a', NULL = 1)", sqltypes.NULL},
+		{"CONCAT(_binary 'a', 'b')", sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("ab"))},
+	})
+}
+
+func TestConcatWS(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CONCAT_WS(',', 'a', 'b')", sqltypes.NewVarChar("a,b")},
+		{"CONCAT_WS(',', 'a', NULL, 'b')", sqltypes.NewVarChar("a,b")},
+		{"CONCAT_WS(',', NULL, 'a', NULL, NULL, 'b', NULL)", sqltypes.NewVarChar("a,b")},
+		{"CONCAT_WS(',', NULL, NULL)", sqltypes.NewVarChar("")},
+		{"CONCAT_WS(',', 'a', '', 'b')", sqltypes.NewVarChar("a,,b")},
+		{"CONCAT_WS(', ', 'a', NULL, 1, 2.5)", sqltypes.NewVarChar("a, 1, 2.5")},
+		{"CONCAT_WS('-', 'a')", sqltypes.NewVarChar("a")},
+		{"CONCAT_WS(NULL, 'a', 'b')", sqltypes.NULL},
+		{"CONCAT_WS(_binary ',', 'a', NULL, 'b')", sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("a,b"))},
+	})
+}
+
+func TestPad(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"LPAD('abc', 6, 'xy')", sqltypes.NewVarChar("xyxabc")},
+		{"RPAD('abc', 6, 'xy')", sqltypes.NewVarChar("abcxyx")},
+		{"LPAD('abc', 2, 'x')", sqltypes.NewVarChar("ab")},
+		{"RPAD('abc', 2, 'x')", sqltypes.NewVarChar("ab")},
+		{"LPAD('abc', 3, 'x')", sqltypes.NewVarChar("abc")},
+		{"LPAD('ñandú', 7, 'é')", sqltypes.NewVarChar("ééñandú")},
+		{"RPAD('ñandú', 3, 'x')", sqltypes.NewVarChar("ñan")},
+		{"LPAD(12, 5, 0)", sqltypes.NewVarChar("00012")},
+		// a zero length yields an empty string, a negative length yields NULL
+		{"LPAD('abc', 0, 'x')", sqltypes.NewVarChar("")},
+		{"RPAD('abc', 0, 'x')", sqltypes.NewVarChar("")},
+		{"LPAD('abc', -1, 'x')", sqltypes.NULL},
+		{"RPAD('abc', -1, 'x')", sqltypes.NULL},
+		{"LPAD('', -5, 'x')", sqltypes.NULL},
+		{"LPAD('abc', 5, '')", sqltypes.NULL},
+		{"LPAD('abc', 2, '')", sqltypes.NewVarChar("ab")},
+		{"LPAD(NULL, 5, 'x')", sqltypes.NULL},
+		{"RPAD('abc', NULL, 'x')", sqltypes.NULL},
+		{"RPAD('abc', 5, NULL)", sqltypes.NULL},
+		{"RPAD(_binary 'ab', 4, 'c')", sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("abcc"))},
+	})
+}
+
+func TestPadMaxAllowedPacket(t *testing.T) {
+	expr := translateForEnv(t, "RPAD('abc', :length, 'x')")
+
+	for _, tc := range []struct {
+		length   int64
+		expected sqltypes.Value
+	}{
+		{8, sqltypes.NewVarChar("abcxxxxx")},
+		{9, sqltypes.NULL},
+	} {
+		env := EnvWithBindVars(map[string]*querypb.BindVariable{
+			"length": sqltypes.Int64BindVariable(tc.length),
+		}, collations.CollationUtf8mb4ID)
+		env.MaxAllowedPacket = 8
+
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, res.Value())
+	}
+}
+
+func TestASCII(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"ASCII('abc')", sqltypes.NewInt64(97)},
+		{"ASCII('')", sqltypes.NewInt64(0)},
+		{"ASCII('é')", sqltypes.NewInt64(0xc3)},
+		{"ASCII(NULL)", sqltypes.NULL},
+		// numbers are converted to their string form first
+		{"ASCII(123)", sqltypes.NewInt64(49)},
+		{"ASCII(-123)", sqltypes.NewInt64(45)},
+		{"ASCII(9.5)", sqltypes.NewInt64(57)},
+		{"ASCII(0.25)", sqltypes.NewInt64(48)},
+		{"ASCII(-0.25)", sqltypes.NewInt64(45)},
+		{"ASCII(18446744073709551615)", sqltypes.NewInt64(49)},
+		{"ASCII(7e0)", sqltypes.NewInt64(55)},
+	})
+}
+
+func TestSubstringIndex(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SUBSTRING_INDEX('www.mysql.com', '.', 2)", sqltypes.NewVarChar("www.mysql")},
+		{"SUBSTRING_INDEX('www.mysql.com', '.', -2)", sqltypes.NewVarChar("mysql.com")},
+		{"SUBSTRING_INDEX('www.mysql.com', '.', 1)", sqltypes.NewVarChar("www")},
+		{"SUBSTRING_INDEX('www.mysql.com', '.', -1)", sqltypes.NewVarChar("com")},
+		{"SUBSTRING_INDEX('www.mysql.com', '.', 5)", sqltypes.NewVarChar("www.mysql.com")},
+		{"SUBSTRING_INDEX('www.mysql.com', '.', -5)", sqltypes.NewVarChar("www.mysql.com")},
+		{"SUBSTRING_INDEX('www.mysql.com', '.', 0)", sqltypes.NewVarChar("")},
+		{"SUBSTRING_INDEX('www.mysql.com', '', 1)", sqltypes.NewVarChar("")},
+		{"SUBSTRING_INDEX('www.mysql.com', 'X', 1)", sqltypes.NewVarChar("www.mysql.com")},
+		{"SUBSTRING_INDEX('aXbxc', 'x', 1)", sqltypes.NewVarChar("aXb")},
+		{"SUBSTRING_INDEX('ñandú.ñu', '.', -1)", sqltypes.NewVarChar("ñu")},
+		{"SUBSTRING_INDEX('1.2.3', '.', '2')", sqltypes.NewVarChar("1.2")},
+		{"SUBSTRING_INDEX(1.25, '.', 1)", sqltypes.NewVarChar("1")},
+		{"SUBSTRING_INDEX(_binary 'a,b', ',', 1)", sqltypes.MakeTrusted(sqltypes.VarBinary, []byte("a"))},
+		{"SUBSTRING_INDEX(NULL, '.', 1)", sqltypes.NULL},
+		{"SUBSTRING_INDEX('a.b', NULL, 1)", sqltypes.NULL},
+		{"SUBSTRING_INDEX('a.b', '.', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestSubstringIndexMultiCharDelimiter(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SUBSTRING_INDEX('a::b::c', '::', 1)", sqltypes.NewVarChar("a")},
+		{"SUBSTRING_INDEX('a::b::c', '::', 2)", sqltypes.NewVarChar("a::b")},
+		{"SUBSTRING_INDEX('a::b::c', '::', 3)", sqltypes.NewVarChar("a::b::c")},
+		{"SUBSTRING_INDEX('a::b::c', '::', -1)", sqltypes.NewVarChar("c")},
+		{"SUBSTRING_INDEX('a::b::c', '::', -2)", sqltypes.NewVarChar("b::c")},
+		{"SUBSTRING_INDEX('a::b::c', '::', -3)", sqltypes.NewVarChar("a::b::c")},
+		// a partial delimiter is not counted
+		{"SUBSTRING_INDEX('a:b::c', '::', 1)", sqltypes.NewVarChar("a:b")},
+		{"SUBSTRING_INDEX('a::b:c', '::', -1)", sqltypes.NewVarChar("b:c")},
+		{"SUBSTRING_INDEX('::a::', '::', 1)", sqltypes.NewVarChar("")},
+		{"SUBSTRING_INDEX('::a::', '::', -1)", sqltypes.NewVarChar("")},
+		{"SUBSTRING_INDEX('one and two and three', ' and ', 2)", sqltypes.NewVarChar("one and two")},
+		{"SUBSTRING_INDEX('one and two and three', ' and ', -2)", sqltypes.NewVarChar("two and three")},
+		{"SUBSTRING_INDEX('ñandú--ñu--ñandú', '--', -2)", sqltypes.NewVarChar("ñu--ñandú")},
+	})
+}
+
+func TestSubstringIndexCollation(t *testing.T) {
+	utf8mb4Bin := collations.Local().LookupByName("utf8mb4_bin").ID()
+
+	for _, expr := range []string{
+		"SUBSTRING_INDEX(_utf8mb4 'a.B.c' COLLATE utf8mb4_bin, '.', 2)",
+		"SUBSTRING_INDEX(_utf8mb4 'a.B.c' COLLATE utf8mb4_bin, '.', -2)",
+		"SUBSTRING_INDEX(_utf8mb4 'a.B.c' COLLATE utf8mb4_bin, '.', 0)",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			res, err := env.Evaluate(translateForEnv(t, expr))
+			require.NoError(t, err)
+			assert.Equal(t, utf8mb4Bin, res.Collation())
+		})
+	}
+}
+
+func TestField(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// a numeric argument makes every argument compare as a number
+		{"FIELD(1, '1', '01')", sqltypes.NewInt64(1)},
+		{"FIELD(1, '01', '1')", sqltypes.NewInt64(1)},
+		{"FIELD(1, 'a', '1.0')", sqltypes.NewInt64(2)},
+		{"FIELD('01', 'x', '1', 2)", sqltypes.NewInt64(2)},
+		{"FIELD(0, 'a', 'b')", sqltypes.NewInt64(1)},
+		{"FIELD(1.5, 1.50, 2)", sqltypes.NewInt64(1)},
+		{"FIELD(2, 1, 2.0, 3)", sqltypes.NewInt64(2)},
+		{"FIELD(18446744073709551615, -1, 18446744073709551615)", sqltypes.NewInt64(2)},
+		{"FIELD(1.5e0, '1.5', 1.5)", sqltypes.NewInt64(1)},
+		// only strings compare as strings, using their collation
+		{"FIELD('1', '01', '1')", sqltypes.NewInt64(2)},
+		{"FIELD('b', 'a', 'b', 'c')", sqltypes.NewInt64(2)},
+		{"FIELD('B', 'a', 'b', 'c')", sqltypes.NewInt64(2)},
+		{"FIELD('B', _binary 'b', _binary 'B')", sqltypes.NewInt64(2)},
+		{"FIELD('a', 'b', 'c')", sqltypes.NewInt64(0)},
+		// NULL is never found
+		{"FIELD(NULL, NULL, 'a')", sqltypes.NewInt64(0)},
+		{"FIELD('a', NULL, 'a')", sqltypes.NewInt64(2)},
+		{"FIELD(1, NULL, 1)", sqltypes.NewInt64(2)},
+	})
+}
+
+func TestLocate(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"LOCATE('bar', 'foobarbar')", sqltypes.NewInt64(4)},
+		{"LOCATE('xbar', 'foobar')", sqltypes.NewInt64(0)},
+		{"LOCATE('bar', 'foobarbar', 5)", sqltypes.NewInt64(7)},
+		{"LOCATE('bar', 'foobarbar', 8)", sqltypes.NewInt64(0)},
+		{"INSTR('foobarbar', 'bar')", sqltypes.NewInt64(4)},
+		{"INSTR('xbar', 'foobar')", sqltypes.NewInt64(0)},
+		{"POSITION('bar' IN 'foobarbar')", sqltypes.NewInt64(4)},
+		{"LOCATE(2, 123)", sqltypes.NewInt64(2)},
+
+		// the search uses the collation of the arguments
+		{"LOCATE('BAR', 'foobar')", sqltypes.NewInt64(4)},
+		{"LOCATE('BAR', 'foobar' COLLATE utf8mb4_bin)", sqltypes.NewInt64(0)},
+		{"INSTR('foobar' COLLATE utf8mb4_bin, 'bar')", sqltypes.NewInt64(4)},
+
+		// positions are counted in characters
+		{"LOCATE('ú', 'ñandú')", sqltypes.NewInt64(5)},
+		{"LOCATE('d', 'ñandú', 4)", sqltypes.NewInt64(4)},
+		{"INSTR('日本語テスト', 'テ')", sqltypes.NewInt64(4)},
+
+		// unless the arguments are binary, in which case they are bytes
+		{"LOCATE(_binary 'ú', _binary 'ñandú')", sqltypes.NewInt64(6)},
+		{"LOCATE(X'C3BA', _binary 'ñandú')", sqltypes.NewInt64(6)},
+		{"LOCATE(_binary 'd', _binary 'ñandú', 4)", sqltypes.NewInt64(5)},
+		{"LOCATE('ú', _binary 'ñandú')", sqltypes.NewInt64(6)},
+		{"LOCATE(_binary 'U', _binary 'ñandú')", sqltypes.NewInt64(0)},
+		{"INSTR(_binary '日本語テスト', _binary 'テ')", sqltypes.NewInt64(10)},
+		{"INSTR(_binary '日本語テスト', X'E38386')", sqltypes.NewInt64(10)},
+
+		{"LOCATE('', 'abc')", sqltypes.NewInt64(1)},
+		{"LOCATE('', 'abc', 3)", sqltypes.NewInt64(3)},
+		{"LOCATE('', 'abc', 4)", sqltypes.NewInt64(0)},
+		{"LOCATE('', '')", sqltypes.NewInt64(1)},
+		{"LOCATE('a', 'abc', 0)", sqltypes.NewInt64(0)},
+		{"LOCATE('a', 'abc', -1)", sqltypes.NewInt64(0)},
+		{"LOCATE(NULL, 'abc')", sqltypes.NULL},
+		{"LOCATE('a', NULL)", sqltypes.NULL},
+		{"LOCATE('a', 'abc', NULL)", sqltypes.NULL},
+		{"INSTR('abc', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestChar(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CHAR(65)", sqltypes.NewVarBinary("A")},
+		{"CHAR(77, 121, 83, 81, '76')", sqltypes.NewVarBinary("MySQL")},
+		{"CHAR(77.3, 77.5)", sqltypes.NewVarBinary("MN")},
+		{"CHAR(0)", sqltypes.NewVarBinary("\x00")},
+
+		// codes above 255 are encoded in big-endian order, without leading zeros
+		{"CHAR(256)", sqltypes.NewVarBinary("\x01\x00")},
+		{"CHAR(65535)", sqltypes.NewVarBinary("\xff\xff")},
+		{"CHAR(65536)", sqltypes.NewVarBinary("\x01\x00\x00")},
+		{"CHAR(16777216)", sqltypes.NewVarBinary("\x01\x00\x00\x00")},
+		{"CHAR(0x4D7953514C)", sqltypes.NewVarBinary("ySQL")},
+		{"CHAR(1, 256, 65536)", sqltypes.NewVarBinary("\x01\x01\x00\x01\x00\x00")},
+		{"CHAR(-1)", sqltypes.NewVarBinary("\xff\xff\xff\xff")},
+		{"CHAR(4294967296)", sqltypes.NewVarBinary("\x00")},
+
+		// NULL arguments are skipped
+		{"CHAR(65, NULL, 66)", sqltypes.NewVarBinary("AB")},
+		{"CHAR(NULL)", sqltypes.NewVarBinary("")},
+
+		{"CHAR(0xE282AC USING utf8mb4)", sqltypes.NewVarChar("€")},
+		{"CHAR(65, 66 USING latin1)", sqltypes.NewVarChar("AB")},
+		{"CHAR(255 USING utf8mb4)", sqltypes.NULL},
+	})
+}
+
+func TestReplace(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"REPLACE('www.mysql.com', 'w', 'Ww')", sqltypes.NewVarChar("WwWwWw.mysql.com")},
+		{"REPLACE('aaa', 'aa', 'b')", sqltypes.NewVarChar("ba")},
+		{"REPLACE('abc', '', 'x')", sqltypes.NewVarChar("abc")},
+		{"REPLACE('abc', 'b', '')", sqltypes.NewVarChar("ac")},
+		{"REPLACE('ABC', 'b', 'x')", sqltypes.NewVarChar("ABC")},
+		{"REPLACE('ñandú', 'ú', 'u')", sqltypes.NewVarChar("ñandu")},
+		{"REPLACE(123, 2, 9)", sqltypes.NewVarChar("193")},
+		{"REPLACE(_binary 'abc', 'b', 'x')", sqltypes.NewVarBinary("axc")},
+		{"REPLACE('abc', _binary 'b', 'x')", sqltypes.NewVarBinary("axc")},
+		{"REPLACE(NULL, 'b', 'x')", sqltypes.NULL},
+		{"REPLACE('abc', NULL, 'x')", sqltypes.NULL},
+		{"REPLACE('abc', 'b', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestReplaceCollation(t *testing.T) {
+	utf8mb4Bin := collations.Local().LookupByName("utf8mb4_bin").ID()
+
+	for _, expr := range []string{
+		"REPLACE('abc' COLLATE utf8mb4_bin, 'b', 'x')",
+		"REPLACE('abc', 'b' COLLATE utf8mb4_bin, 'x')",
+		"REPLACE('abc', 'b', 'x' COLLATE utf8mb4_bin)",
+		"REPLACE('abc' COLLATE utf8mb4_bin, 'b' COLLATE utf8mb4_bin, 'x' COLLATE utf8mb4_bin)",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			res, err := env.Evaluate(translateForEnv(t, expr))
+			require.NoError(t, err)
+			assert.Equal(t, utf8mb4Bin, res.Collation())
+		})
+	}
+
+	testEvaluateErrors(t, []evaluateErrorCase{
+		{
+			"REPLACE('abc' COLLATE utf8mb4_bin, 'b' COLLATE utf8mb4_general_ci, 'x')",
+			"Illegal mix of collations (utf8mb4_bin,EXPLICIT) and (utf8mb4_general_ci,EXPLICIT)",
+		},
+		{
+			"REPLACE('abc' COLLATE utf8mb4_bin, 'b', 'x' COLLATE utf8mb4_general_ci)",
+			"Illegal mix of collations (utf8mb4_bin,EXPLICIT) and (utf8mb4_general_ci,EXPLICIT)",
+		},
+		{
+			"REPLACE('abc', 'b' COLLATE utf8mb4_bin, 'x' COLLATE utf8mb4_general_ci)",
+			"Illegal mix of collations (utf8mb4_bin,EXPLICIT) and (utf8mb4_general_ci,EXPLICIT)",
+		},
+	})
+}
+
+func TestWeightStringBinaryPadding(t *testing.T) {
+	binary := func(s string) sqltypes.Value {
+		return sqltypes.MakeTrusted(sqltypes.VarBinary, []byte(s))
+	}
+	testEvaluateCases(t, []evaluateCase{
+		{"WEIGHT_STRING('ab' AS BINARY(4))", binary("ab\x00\x00")},
+		{"WEIGHT_STRING('abcd' AS BINARY(4))", binary("abcd")},
+		{"WEIGHT_STRING('abcdef' AS BINARY(4))", binary("abcd")},
+		{"WEIGHT_STRING(_latin1 'foobar' AS BINARY(3))", binary("foo")},
+		{"WEIGHT_STRING('' AS BINARY(2))", binary("\x00\x00")},
+		{"WEIGHT_STRING(NULL AS BINARY(4))", sqltypes.NULL},
+	})
+}
+
+func TestFormat(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"FORMAT(1234567.891, 2)", sqltypes.NewVarChar("1,234,567.89")},
+		{"FORMAT(1234.5, 0)", sqltypes.NewVarChar("1,235")},
+		{"FORMAT(123, 2)", sqltypes.NewVarChar("123.00")},
+		{"FORMAT(1234, 2)", sqltypes.NewVarChar("1,234.00")},
+		{"FORMAT(0.5, 3)", sqltypes.NewVarChar("0.500")},
+		{"FORMAT(1234.5678, -1)", sqltypes.NewVarChar("1,235")},
+		{"FORMAT(1e6, 1)", sqltypes.NewVarChar("1,000,000.0")},
+		{"FORMAT('12345.678', 1)", sqltypes.NewVarChar("12,345.7")},
+		{"FORMAT(18446744073709551615, 0)", sqltypes.NewVarChar("18,446,744,073,709,551,615")},
+
+		// the sign goes in front of the grouped digits
+		{"FORMAT(-1234567.891, 2)", sqltypes.NewVarChar("-1,234,567.89")},
+		{"FORMAT(-1, 2)", sqltypes.NewVarChar("-1.00")},
+		{"FORMAT(-12, 0)", sqltypes.NewVarChar("-12")},
+		{"FORMAT(-123, 0)", sqltypes.NewVarChar("-123")},
+		{"FORMAT(-1234, 0)", sqltypes.NewVarChar("-1,234")},
+		{"FORMAT(-123456, 1)", sqltypes.NewVarChar("-123,456.0")},
+		{"FORMAT(-1234567, 0)", sqltypes.NewVarChar("-1,234,567")},
+		{"FORMAT(-999999.996, 2)", sqltypes.NewVarChar("-1,000,000.00")},
+		{"FORMAT(-9223372036854775808, 0)", sqltypes.NewVarChar("-9,223,372,036,854,775,808")},
+		{"FORMAT(-1234.5e0, 0)", sqltypes.NewVarChar("-1,234")},
+		{"FORMAT(-98765.4321e0, 3)", sqltypes.NewVarChar("-98,765.432")},
+		{"FORMAT('-1234567.891', 2)", sqltypes.NewVarChar("-1,234,567.89")},
+
+		{"FORMAT(NULL, 2)", sqltypes.NULL},
+		{"FORMAT(1234, NULL)", sqltypes.NULL},
+	})
+}
@@ -0,0 +1,1652 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"vitess.io/vitess/go/hack"
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/mysql/collations/charset"
+	"vitess.io/vitess/go/sqltypes"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/datetime"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/decimal"
+)
+
+// builtinDateFormat implements DATE_FORMAT. Like in MySQL 8.0, the zero date
+// '0000-00-00' is a valid input, and its components are formatted as zeroes,
+// but formatting the names of its days or months yields NULL.
+type builtinDateFormat struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinDateFormat)(nil)
+
+func (call *builtinDateFormat) eval(env *ExpressionEnv) (eval, error) {
+	date, format, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if date == nil || format == nil {
+		return nil, nil
+	}
+
+	dt, ok := evalToDateTime(env, date)
+	if !ok {
+		return nil, nil
+	}
+
+	// the names of the locale are in UTF-8, so the result is formatted in
+	// UTF-8 and then converted to the connection's charset
+	f, err := evalToVarchar(format, collations.CollationUtf8mb4ID, true)
+	if err != nil {
+		return nil, err
+	}
+	text, ok := datetime.Strftime(nil, f.bytes, dt, env.currentLocale())
+	if !ok {
+		return nil, nil
+	}
+	return evalLocaleText(env, text)
+}
+
+func (call *builtinDateFormat) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	call.Arguments[0].typeof(env)
+	call.Arguments[1].typeof(env)
+	return sqltypes.VarChar, flagNullable
+}
+
+// builtinTimeFormat implements TIME_FORMAT. The argument is converted into a
+// TIME, and the result is NULL if it cannot be, or if the format has any of
+// the specifiers for the parts of a date.
+type builtinTimeFormat struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinTimeFormat)(nil)
+
+func (call *builtinTimeFormat) eval(env *ExpressionEnv) (eval, error) {
+	arg, format, err := call.arg2(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil || format == nil {
+		return nil, nil
+	}
+
+	t, _, ok := evalToTime(arg)
+	if !ok {
+		return nil, nil
+	}
+
+	f, err := evalToVarchar(format, collations.CollationUtf8mb4ID, true)
+	if err != nil {
+		return nil, err
+	}
+	text, ok := datetime.StrftimeTime(nil, f.bytes, t)
+	if !ok {
+		return nil, nil
+	}
+	return evalLocaleText(env, text)
+}
+
+func (call *builtinTimeFormat) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	call.Arguments[0].typeof(env)
+	call.Arguments[1].typeof(env)
+	return sqltypes.VarChar, flagNullable
+}
+
+type builtinSecToTime struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSecToTime)(nil)
+
+// maxTimeSeconds is the number of seconds in '838:59:59', the largest
+// value that can be stored in a MySQL TIME.
+const maxTimeSeconds = datetime.MaxHours*3600 + 59*60 + 59
+
+// secondsToTime returns the TIME for the given (possibly negative) number of
+// seconds, rounded to prec fractional digits and clamped to the TIME range.
+func secondsToTime(dec decimal.Decimal, prec int32) datetime.Time {
+	dec = dec.Round(prec)
+	neg := dec.Sign() < 0
+	if neg {
+		dec = dec.Neg()
+	}
+
+	sec, ok := dec.Int64()
+	if !ok || sec > maxTimeSeconds {
+		return datetime.NewTime(neg, datetime.MaxHours, 59, 59, 0)
+	}
+	nsec, _ := dec.Sub(decimal.NewFromInt(sec)).Mul(decimal.New(1, 9)).Int64()
+	if sec == maxTimeSeconds && nsec > 0 {
+		nsec = 0
+	}
+	return datetime.NewTime(neg, int(sec/3600), int(sec/60%60), int(sec%60), int(nsec))
+}
+
+func (call *builtinSecToTime) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	var (
+		dec  decimal.Decimal
+		prec int32
+	)
+	switch arg := evalToNumeric(arg).(type) {
+	case *evalInt64:
+		dec = decimal.NewFromInt(arg.i)
+	case *evalUint64:
+		dec = decimal.NewFromUint(arg.u)
+	case *evalFloat:
+		dec = decimal.NewFromFloatMySQL(arg.f)
+		prec = datetime.DefaultPrecision
+	case *evalDecimal:
+		dec = arg.dec
+		prec = arg.length
+		if prec > datetime.DefaultPrecision {
+			prec = datetime.DefaultPrecision
+		}
+	}
+
+	t := secondsToTime(dec, prec)
+	return newEvalRaw(sqltypes.Time, t.Format(uint8(prec)), collationNumeric), nil
+}
+
+func (call *builtinSecToTime) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Time, f
+}
+
+type builtinTimeToSec struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinTimeToSec)(nil)
+
+// secondsPrecision returns the number of fractional digits in the result of
+// the functions that return a number of seconds, like TIME_TO_SEC and
+// UNIX_TIMESTAMP, and whether it's a DECIMAL instead of an integer. Like in
+// MySQL, the type of the result doesn't depend on the row: only constant
+// arguments use the precision of their value, while for any other argument
+// it's given by its type, and text and floats always have 6 digits.
+func secondsPrecision(arg Expr, tt sqltypes.Type, valuePrec int) (int, bool) {
+	switch {
+	case sqltypes.IsIntegral(tt) || tt == sqltypes.Date:
+		return 0, false
+	case arg.constant():
+		return valuePrec, valuePrec > 0
+	case sqltypes.IsText(tt) || sqltypes.IsBinary(tt) || sqltypes.IsFloat(tt):
+		return datetime.DefaultPrecision, true
+	default:
+		return valuePrec, true
+	}
+}
+
+// secondsDecimal returns the given number of seconds as a DECIMAL with prec
+// fractional digits, taken from the nanoseconds.
+func secondsDecimal(sec int64, nsec int, prec int, neg bool) eval {
+	frac := int64(nsec)
+	for i := prec; i < 9; i++ {
+		frac /= 10
+	}
+	dec := decimal.NewFromInt(sec).Add(decimal.New(frac, -int32(prec)))
+	if neg {
+		dec = dec.Neg()
+	}
+	return newEvalDecimalWithPrec(dec, int32(prec))
+}
+
+func (call *builtinTimeToSec) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil {
+		return nil, err
+	}
+	if arg == nil {
+		return nil, nil
+	}
+
+	t, prec, ok := evalToTime(arg)
+	if !ok {
+		return nil, nil
+	}
+
+	sec := int64(t.Hour())*3600 + int64(t.Minute())*60 + int64(t.Second())
+	prec, dec := secondsPrecision(call.Arguments[0], arg.SQLType(), prec)
+	if !dec {
+		if t.Neg() {
+			sec = -sec
+		}
+		return newEvalInt64(sec), nil
+	}
+	return secondsDecimal(sec, t.Nanosecond(), prec, t.Neg()), nil
+}
+
+func (call *builtinTimeToSec) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	var prec int
+	if call.Arguments[0].constant() {
+		if arg, err := call.Arguments[0].eval(env); err == nil && arg != nil {
+			_, prec, _ = evalToTime(arg)
+		}
+	}
+	if _, dec := secondsPrecision(call.Arguments[0], tt, prec); dec {
+		return sqltypes.Decimal, f | flagNullable
+	}
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinNow struct {
+	CallExpr
+	prec uint8
+}
+
+var _ Expr = (*builtinNow)(nil)
+
+// truncateFsp truncates the nanoseconds of the given time to prec fractional
+// second digits, like MySQL does with the current time.
+func truncateFsp(t time.Time, prec uint8) time.Time {
+	unit := time.Second
+	for i := uint8(0); i < prec; i++ {
+		unit /= 10
+	}
+	return t.Add(-(time.Duration(t.Nanosecond()) % unit))
+}
+
+func (call *builtinNow) eval(env *ExpressionEnv) (eval, error) {
+	now := truncateFsp(env.time(), call.prec)
+	return newEvalRaw(sqltypes.Datetime, datetime.NewDateTimeFromStd(now).Format(call.prec), collationNumeric), nil
+}
+
+func (call *builtinNow) typeof(_ *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	return sqltypes.Datetime, 0
+}
+
+type builtinCurdate struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinCurdate)(nil)
+
+func (call *builtinCurdate) eval(env *ExpressionEnv) (eval, error) {
+	now := datetime.NewDateTimeFromStd(env.time())
+	return newEvalRaw(sqltypes.Date, now.Date.Format(), collationNumeric), nil
+}
+
+func (call *builtinCurdate) typeof(_ *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	return sqltypes.Date, 0
+}
+
+type builtinCurtime struct {
+	CallExpr
+	prec uint8
+}
+
+var _ Expr = (*builtinCurtime)(nil)
+
+func (call *builtinCurtime) eval(env *ExpressionEnv) (eval, error) {
+	now := datetime.NewDateTimeFromStd(truncateFsp(env.time(), call.prec))
+	return newEvalRaw(sqltypes.Time, now.Time.Format(call.prec), collationNumeric), nil
+}
+
+func (call *builtinCurtime) typeof(_ *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	return sqltypes.Time, 0
+}
+
+type builtinSysdate struct {
+	CallExpr
+	prec uint8
+}
+
+var _ Expr = (*builtinSysdate)(nil)
+
+// SYSDATE returns the time at which it's evaluated rather than the time of
+// the statement, so it can return different values within the same statement
+func (call *builtinSysdate) eval(env *ExpressionEnv) (eval, error) {
+	now := truncateFsp(env.wallClock().In(env.currentTimezone()), call.prec)
+	return newEvalRaw(sqltypes.Datetime, datetime.NewDateTimeFromStd(now).Format(call.prec), collationNumeric), nil
+}
+
+func (call *builtinSysdate) typeof(_ *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	return sqltypes.Datetime, 0
+}
+
+// evalDateArg evaluates the only argument of a function that extracts a
+// component of a date. The boolean result is false if the argument is NULL
+// or it is not a valid date.
+func evalDateArg(env *ExpressionEnv, call *CallExpr) (datetime.DateTime, bool, error) {
+	arg, err := call.arg1(env)
+	if err != nil || arg == nil {
+		return datetime.DateTime{}, false, err
+	}
+	dt, ok := evalToDateTime(env, arg)
+	return dt, ok, nil
+}
+
+// evalCalendarDateArg is like evalDateArg, but it also returns false for
+// dates with zero components, which are not part of the calendar.
+func evalCalendarDateArg(env *ExpressionEnv, call *CallExpr) (datetime.Date, bool, error) {
+	dt, ok, err := evalDateArg(env, call)
+	if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+		return datetime.Date{}, false, err
+	}
+	return dt.Date, true, nil
+}
+
+type builtinYear struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinYear)(nil)
+
+func (call *builtinYear) eval(env *ExpressionEnv) (eval, error) {
+	dt, ok, err := evalDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(dt.Date.Year())), nil
+}
+
+func (call *builtinYear) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinMonth struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinMonth)(nil)
+
+func (call *builtinMonth) eval(env *ExpressionEnv) (eval, error) {
+	dt, ok, err := evalDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(dt.Date.Month())), nil
+}
+
+func (call *builtinMonth) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinDay struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinDay)(nil)
+
+func (call *builtinDay) eval(env *ExpressionEnv) (eval, error) {
+	dt, ok, err := evalDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(dt.Date.Day())), nil
+}
+
+func (call *builtinDay) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinDayOfWeek struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinDayOfWeek)(nil)
+
+func (call *builtinDayOfWeek) eval(env *ExpressionEnv) (eval, error) {
+	d, ok, err := evalCalendarDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	// 1 = Sunday, ..., 7 = Saturday
+	return newEvalInt64(int64(d.Weekday()) + 1), nil
+}
+
+func (call *builtinDayOfWeek) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinWeekDay struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinWeekDay)(nil)
+
+func (call *builtinWeekDay) eval(env *ExpressionEnv) (eval, error) {
+	d, ok, err := evalCalendarDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	// 0 = Monday, ..., 6 = Sunday
+	return newEvalInt64((int64(d.Weekday()) + 6) % 7), nil
+}
+
+func (call *builtinWeekDay) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinDayOfYear struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinDayOfYear)(nil)
+
+func (call *builtinDayOfYear) eval(env *ExpressionEnv) (eval, error) {
+	d, ok, err := evalCalendarDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(d.Yearday())), nil
+}
+
+func (call *builtinDayOfYear) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+// evalLocaleText returns the given UTF-8 text, which can contain the names of
+// days or months in the current locale, as a string in the connection's charset.
+func evalLocaleText(env *ExpressionEnv, text []byte) (eval, error) {
+	col := env.collation()
+	b, err := charset.Convert(nil, col.Collation.Get().Charset(), text, charset.Charset_utf8mb4{})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range text {
+		if c >= utf8.RuneSelf {
+			col.Repertoire = collations.RepertoireUnicode
+			break
+		}
+	}
+	return newEvalText(b, col), nil
+}
+
+type builtinDayName struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinDayName)(nil)
+
+func (call *builtinDayName) eval(env *ExpressionEnv) (eval, error) {
+	d, ok, err := evalCalendarDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return evalLocaleText(env, []byte(env.currentLocale().DayName(d.Weekday())))
+}
+
+func (call *builtinDayName) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f | flagNullable
+}
+
+type builtinMonthName struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinMonthName)(nil)
+
+func (call *builtinMonthName) eval(env *ExpressionEnv) (eval, error) {
+	// like in MySQL, only the month of the date needs to be non-zero
+	dt, ok, err := evalDateArg(env, &call.CallExpr)
+	if !ok || dt.Date.Month() == 0 {
+		return nil, err
+	}
+	return evalLocaleText(env, []byte(env.currentLocale().MonthName(dt.Date.Month())))
+}
+
+func (call *builtinMonthName) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.VarChar, f | flagNullable
+}
+
+type builtinQuarter struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinQuarter)(nil)
+
+func (call *builtinQuarter) eval(env *ExpressionEnv) (eval, error) {
+	dt, ok, err := evalDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	// dates with a zero month, like the zero date, are in quarter 0
+	return newEvalInt64(int64(dt.Date.Month()+2) / 3), nil
+}
+
+func (call *builtinQuarter) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinLastDay struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinLastDay)(nil)
+
+func (call *builtinLastDay) eval(env *ExpressionEnv) (eval, error) {
+	// like in MySQL, the day of the date can be zero, but its month cannot
+	dt, ok, err := evalDateArg(env, &call.CallExpr)
+	if !ok || dt.Date.Month() == 0 {
+		return nil, err
+	}
+	year, month := dt.Date.Year(), dt.Date.Month()
+	last := datetime.NewDate(year, month, datetime.DaysIn(month, year))
+	return newEvalRaw(sqltypes.Date, last.Format(), collationNumeric), nil
+}
+
+func (call *builtinLastDay) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Date, f | flagNullable
+}
+
+// defaultWeekFormat is the mode used by WEEK when none is given. vtgate does
+// not track the session's default_week_format, so this is MySQL's default.
+const defaultWeekFormat = 0
+
+type builtinWeek struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinWeek)(nil)
+
+func (call *builtinWeek) eval(env *ExpressionEnv) (eval, error) {
+	d, ok, err := evalCalendarDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+
+	// like in MySQL, a NULL mode is the same as mode 0
+	mode := int64(defaultWeekFormat)
+	if len(call.Arguments) > 1 {
+		m, err := call.Arguments[1].eval(env)
+		if err != nil {
+			return nil, err
+		}
+		mode = 0
+		if m != nil {
+			mode = evalToNumeric(m).toInt64().i
+		}
+	}
+	return newEvalInt64(int64(d.Week(int(mode)))), nil
+}
+
+func (call *builtinWeek) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinWeekOfYear struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinWeekOfYear)(nil)
+
+func (call *builtinWeekOfYear) eval(env *ExpressionEnv) (eval, error) {
+	d, ok, err := evalCalendarDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(d.Week(3))), nil
+}
+
+func (call *builtinWeekOfYear) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+// evalTimeArg evaluates the only argument of a function that extracts a
+// component of a time. The boolean result is false if the argument is NULL
+// or it is not a valid time.
+func evalTimeArg(env *ExpressionEnv, call *CallExpr) (datetime.Time, bool, error) {
+	arg, err := call.arg1(env)
+	if err != nil || arg == nil {
+		return datetime.Time{}, false, err
+	}
+	t, _, ok := evalToTime(arg)
+	return t, ok, nil
+}
+
+type builtinHour struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinHour)(nil)
+
+func (call *builtinHour) eval(env *ExpressionEnv) (eval, error) {
+	t, ok, err := evalTimeArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(t.Hour())), nil
+}
+
+func (call *builtinHour) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinMinute struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinMinute)(nil)
+
+func (call *builtinMinute) eval(env *ExpressionEnv) (eval, error) {
+	t, ok, err := evalTimeArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(t.Minute())), nil
+}
+
+func (call *builtinMinute) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinSecond struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinSecond)(nil)
+
+func (call *builtinSecond) eval(env *ExpressionEnv) (eval, error) {
+	t, ok, err := evalTimeArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(t.Second())), nil
+}
+
+func (call *builtinSecond) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinMicrosecond struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinMicrosecond)(nil)
+
+func (call *builtinMicrosecond) eval(env *ExpressionEnv) (eval, error) {
+	t, ok, err := evalTimeArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(t.Nanosecond() / 1000)), nil
+}
+
+func (call *builtinMicrosecond) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+// builtinDateMath implements DATE_ADD and DATE_SUB, together with their
+// ADDDATE and SUBDATE aliases and the arithmetic operators with an INTERVAL.
+type builtinDateMath struct {
+	CallExpr
+	unit datetime.IntervalType
+	sub  bool
+}
+
+var _ Expr = (*builtinDateMath)(nil)
+
+// dateMathKeepsTime returns whether adding an interval of the given type to a
+// TIME results in a TIME. Like in MySQL, all the other units result in a
+// DATETIME on the current date.
+func dateMathKeepsTime(unit datetime.IntervalType) bool {
+	return (unit >= datetime.IntervalHour && unit <= datetime.IntervalMicrosecond) || unit >= datetime.IntervalHourMinute
+}
+
+// evalToInterval converts the value of an INTERVAL expression into an interval
+// of the given type. It also returns the number of fractional second digits
+// that the interval adds to the result of the date arithmetic.
+func evalToInterval(e eval, unit datetime.IntervalType) (datetime.Interval, int, bool) {
+	switch {
+	case unit.IsCompound():
+		itv, ok := datetime.ParseInterval(string(e.ToRawBytes()), unit)
+		if unit.HasMicrosecond() {
+			return itv, datetime.DefaultPrecision, ok
+		}
+		return itv, 0, ok
+
+	case unit == datetime.IntervalSecond:
+		var prec int
+		switch num := evalToNumeric(e).(type) {
+		case *evalInt64, *evalUint64:
+			return datetime.NewInterval(unit, evalToIntervalInt(num)), 0, true
+		case *evalDecimal:
+			prec = int(num.length)
+		default:
+			prec = datetime.DefaultPrecision
+		}
+		if prec > datetime.DefaultPrecision {
+			prec = datetime.DefaultPrecision
+		}
+
+		dec := evalToNumeric(e).toDecimal(0, 0).dec
+		neg := dec.Sign() < 0
+		if neg {
+			dec = dec.Neg()
+		}
+		sec := dec.Truncate(0)
+		usec, _ := dec.Sub(sec).Mul(decimal.NewFromInt(1000000)).Int64()
+		s, _ := sec.Int64()
+		return datetime.NewIntervalSecond(neg, s, usec), prec, true
+
+	case unit == datetime.IntervalMicrosecond:
+		return datetime.NewInterval(unit, evalToIntervalInt(evalToNumeric(e))), datetime.DefaultPrecision, true
+
+	default:
+		return datetime.NewInterval(unit, evalToIntervalInt(evalToNumeric(e))), 0, true
+	}
+}
+
+// evalToIntervalInt returns the number of units of a simple interval. Unsigned
+// values that overflow an int64 are clamped instead of wrapping around.
+func evalToIntervalInt(num evalNumeric) int64 {
+	if u, ok := num.(*evalUint64); ok && u.u > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return num.toInt64().i
+}
+
+func (call *builtinDateMath) eval(env *ExpressionEnv) (eval, error) {
+	date, value, err := call.arg2(env)
+	if err != nil || date == nil || value == nil {
+		return nil, err
+	}
+
+	itv, prec, ok := evalToInterval(value, call.unit)
+	if !ok {
+		return nil, nil
+	}
+	if call.sub {
+		itv = itv.Negate()
+	}
+
+	var tt sqltypes.Type
+	if b, ok := date.(*evalBytes); ok {
+		tt = b.SQLType()
+	}
+
+	switch tt {
+	case sqltypes.Date, sqltypes.Datetime, sqltypes.Timestamp:
+		dt, dtPrec, _, ok := datetime.ParseDateOrDateTime(date.(*evalBytes).string())
+		if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+			return nil, nil
+		}
+		if dt, ok = dt.AddInterval(itv); !ok {
+			return nil, nil
+		}
+		if tt == sqltypes.Date && !call.unit.HasTimePart() {
+			return newEvalRaw(sqltypes.Date, dt.Date.Format(), collationNumeric), nil
+		}
+		if dtPrec > prec {
+			prec = dtPrec
+		}
+		return newEvalRaw(sqltypes.Datetime, dt.Format(uint8(prec)), collationNumeric), nil
+
+	case sqltypes.Time:
+		t, tPrec, ok := datetime.ParseTime(date.(*evalBytes).string())
+		if !ok {
+			return nil, nil
+		}
+		if tPrec > prec {
+			prec = tPrec
+		}
+		if dateMathKeepsTime(call.unit) {
+			if t, ok = t.AddInterval(itv); !ok {
+				return nil, nil
+			}
+			return newEvalRaw(sqltypes.Time, t.Format(uint8(prec)), collationNumeric), nil
+		}
+		today := datetime.NewDateTimeFromStd(env.time()).Date
+		dt := datetime.NewDateTimeFromStd(today.ToStdTime(time.UTC).Add(t.ToDuration()))
+		if dt, ok = dt.AddInterval(itv); !ok {
+			return nil, nil
+		}
+		return newEvalRaw(sqltypes.Datetime, dt.Format(uint8(prec)), collationNumeric), nil
+
+	default:
+		// strings and numbers result in a string, which is a date if the
+		// argument was a date and the interval has no time part
+		var dt datetime.DateTime
+		var hasTime bool
+		switch date := date.(type) {
+		case *evalBytes:
+			dt, _, hasTime, ok = datetime.ParseDateOrDateTime(date.string())
+		case evalNumeric:
+			i, _, _ := splitNumericTemporal(date)
+			dt, ok = evalToDateTime(env, date)
+			hasTime = i > 99991231
+		default:
+			ok = false
+		}
+		if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+			return nil, nil
+		}
+		if dt, ok = dt.AddInterval(itv); !ok {
+			return nil, nil
+		}
+
+		var buf []byte
+		switch {
+		case !hasTime && !call.unit.HasTimePart():
+			buf = dt.Date.Format()
+		case dt.Time.Nanosecond() != 0:
+			buf = dt.Format(datetime.DefaultPrecision)
+		default:
+			buf = dt.Format(0)
+		}
+		return newEvalText(buf, env.collation()), nil
+	}
+}
+
+func (call *builtinDateMath) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	tt, f := call.Arguments[0].typeof(env)
+	call.Arguments[1].typeof(env)
+
+	switch {
+	case tt == sqltypes.Date && !call.unit.HasTimePart():
+		return sqltypes.Date, f | flagNullable
+	case tt == sqltypes.Time && dateMathKeepsTime(call.unit):
+		return sqltypes.Time, f | flagNullable
+	case tt == sqltypes.Date || tt == sqltypes.Datetime || tt == sqltypes.Timestamp || tt == sqltypes.Time:
+		return sqltypes.Datetime, f | flagNullable
+	default:
+		return sqltypes.VarChar, f | flagNullable
+	}
+}
+
+type builtinDateDiff struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinDateDiff)(nil)
+
+func (call *builtinDateDiff) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil || arg1 == nil || arg2 == nil {
+		return nil, err
+	}
+
+	// only the date parts of the arguments are compared
+	var dates [2]datetime.Date
+	for i, arg := range []eval{arg1, arg2} {
+		dt, ok := evalToDateTime(env, arg)
+		if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+			return nil, nil
+		}
+		dates[i] = dt.Date
+	}
+	return newEvalInt64(int64(dates[0].DayNumber() - dates[1].DayNumber())), nil
+}
+
+func (call *builtinDateDiff) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.Int64, f1 | f2 | flagNullable
+}
+
+type builtinToDays struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinToDays)(nil)
+
+func (call *builtinToDays) eval(env *ExpressionEnv) (eval, error) {
+	d, ok, err := evalCalendarDateArg(env, &call.CallExpr)
+	if !ok {
+		return nil, err
+	}
+	return newEvalInt64(int64(d.DayNumber())), nil
+}
+
+func (call *builtinToDays) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+type builtinFromDays struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinFromDays)(nil)
+
+// FROM_DAYS is the inverse of TO_DAYS, but like in MySQL, the day numbers
+// in the year 0 and below all return the zero date, since MySQL doesn't
+// use the proleptic Gregorian calendar for them. The days past '9999-12-31'
+// in the year 10000 are out of range, and return NULL.
+func (call *builtinFromDays) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil || arg == nil {
+		return nil, err
+	}
+
+	daynr := evalToNumeric(arg).toInt64().i
+	d := datetime.DateFromDayNumber(int(daynr))
+	if d.Year() > 9999 {
+		return nil, nil
+	}
+	return newEvalRaw(sqltypes.Date, d.Format(), collationNumeric), nil
+}
+
+func (call *builtinFromDays) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Date, f | flagNullable
+}
+
+type builtinToSeconds struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinToSeconds)(nil)
+
+func (call *builtinToSeconds) eval(env *ExpressionEnv) (eval, error) {
+	dt, ok, err := evalDateArg(env, &call.CallExpr)
+	if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+		return nil, err
+	}
+	// fractional seconds are truncated
+	sec := int64(dt.Date.DayNumber())*24*3600 + int64(dt.Time.Hour())*3600 + int64(dt.Time.Minute())*60 + int64(dt.Time.Second())
+	return newEvalInt64(sec), nil
+}
+
+func (call *builtinToSeconds) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
+
+// builtinTimestampDiff implements TIMESTAMPDIFF, which returns the number of
+// whole units between two datetimes. Partial units are truncated.
+type builtinTimestampDiff struct {
+	CallExpr
+	unit datetime.IntervalType
+}
+
+var _ Expr = (*builtinTimestampDiff)(nil)
+
+func (call *builtinTimestampDiff) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil || arg1 == nil || arg2 == nil {
+		return nil, err
+	}
+
+	var dts [2]datetime.DateTime
+	for i, arg := range []eval{arg1, arg2} {
+		dt, _, ok := evalToDateTimeToday(env, arg)
+		if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+			return nil, nil
+		}
+		dts[i] = dt
+	}
+
+	// like in MySQL, the result is the difference from the first argument to
+	// the second, computed from the earliest of them to the latest
+	beg, end := dts[0], dts[1]
+	neg := datetimeMicroseconds(end) < datetimeMicroseconds(beg)
+	if neg {
+		beg, end = end, beg
+	}
+
+	var diff int64
+	switch call.unit {
+	case datetime.IntervalYear, datetime.IntervalQuarter, datetime.IntervalMonth:
+		months := int64(end.Date.Year()-beg.Date.Year())*12 + int64(end.Date.Month()-beg.Date.Month())
+		// a month is only complete if the end is not earlier in the month
+		// than the beginning
+		if end.Date.Day() < beg.Date.Day() ||
+			end.Date.Day() == beg.Date.Day() && timeOfDayMicroseconds(end.Time) < timeOfDayMicroseconds(beg.Time) {
+			months--
+		}
+		switch call.unit {
+		case datetime.IntervalYear:
+			diff = months / 12
+		case datetime.IntervalQuarter:
+			diff = months / 3
+		default:
+			diff = months
+		}
+	default:
+		usec := datetimeMicroseconds(end) - datetimeMicroseconds(beg)
+		switch call.unit {
+		case datetime.IntervalWeek:
+			diff = usec / (7 * 24 * 3600 * 1000000)
+		case datetime.IntervalDay:
+			diff = usec / (24 * 3600 * 1000000)
+		case datetime.IntervalHour:
+			diff = usec / (3600 * 1000000)
+		case datetime.IntervalMinute:
+			diff = usec / (60 * 1000000)
+		case datetime.IntervalSecond:
+			diff = usec / 1000000
+		default:
+			diff = usec
+		}
+	}
+
+	if neg {
+		diff = -diff
+	}
+	return newEvalInt64(diff), nil
+}
+
+func (call *builtinTimestampDiff) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.Int64, f1 | f2 | flagNullable
+}
+
+// timeOfDayMicroseconds returns the number of microseconds since midnight
+// of the given time of day.
+func timeOfDayMicroseconds(t datetime.Time) int64 {
+	return (int64(t.Hour())*3600+int64(t.Minute())*60+int64(t.Second()))*1000000 + int64(t.Nanosecond()/1000)
+}
+
+// datetimeMicroseconds returns the number of microseconds between year 0 and
+// the given datetime, which fits in an int64 for any supported date.
+func datetimeMicroseconds(dt datetime.DateTime) int64 {
+	return int64(dt.Date.DayNumber())*24*3600*1000000 + timeOfDayMicroseconds(dt.Time)
+}
+
+type builtinTimeDiff struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinTimeDiff)(nil)
+
+// timeDiffArg converts an argument of TIMEDIFF into a TIME, a DATE or a
+// DATETIME, returning the type it was converted into. Like in MySQL, when
+// any of the arguments has a date type, strings and numbers are converted
+// into dates; otherwise they are converted into times, unless they are long
+// enough to be a full datetime.
+func timeDiffArg(env *ExpressionEnv, e eval, withDate bool) (datetime.DateTime, sqltypes.Type, int, bool) {
+	switch e := e.(type) {
+	case *evalBytes:
+		s := hack.String(e.bytes)
+		switch tt := e.SQLType(); {
+		case tt == sqltypes.Time:
+			t, prec, ok := datetime.ParseTime(s)
+			return datetime.NewDateTime(datetime.Date{}, t), sqltypes.Time, prec, ok
+		case tt == sqltypes.Date:
+			dt, prec, _, ok := datetime.ParseDateOrDateTime(s)
+			return dt, sqltypes.Date, prec, ok
+		case sqltypes.IsDate(tt):
+			dt, prec, _, ok := datetime.ParseDateOrDateTime(s)
+			return dt, sqltypes.Datetime, prec, ok
+		}
+
+		s = strings.TrimSpace(s)
+		dt, prec, hasTime, ok := datetime.ParseDateOrDateTime(s)
+		switch {
+		case withDate && ok && !hasTime && !strings.Contains(s, ":"):
+			// values like '10:00:00' are times, even if they can be
+			// parsed leniently as a date
+			return dt, sqltypes.Date, prec, true
+		case ok && hasTime && (withDate || len(s) >= 12):
+			return dt, sqltypes.Datetime, prec, true
+		case withDate:
+			return dt, sqltypes.Null, 0, false
+		}
+		t, prec, ok := datetime.ParseTime(s)
+		return datetime.NewDateTime(datetime.Date{}, t), sqltypes.Time, prec, ok
+
+	case evalNumeric:
+		i, _, ok := splitNumericTemporal(e)
+		if !ok {
+			return datetime.DateTime{}, sqltypes.Null, 0, false
+		}
+		t, prec, ok := evalToTime(e)
+		if !withDate && i > -10000000000 && i < 10000000000 {
+			return datetime.NewDateTime(datetime.Date{}, t), sqltypes.Time, prec, ok
+		}
+		dt, ok := evalToDateTime(env, e)
+		if withDate && i <= 99991231 {
+			return dt, sqltypes.Date, 0, ok
+		}
+		return dt, sqltypes.Datetime, prec, ok
+
+	default:
+		return datetime.DateTime{}, sqltypes.Null, 0, false
+	}
+}
+
+func (call *builtinTimeDiff) eval(env *ExpressionEnv) (eval, error) {
+	arg1, arg2, err := call.arg2(env)
+	if err != nil || arg1 == nil || arg2 == nil {
+		return nil, err
+	}
+
+	withDate := sqltypes.IsDate(arg1.SQLType()) && arg1.SQLType() != sqltypes.Time ||
+		sqltypes.IsDate(arg2.SQLType()) && arg2.SQLType() != sqltypes.Time
+
+	dt1, tt1, prec1, ok := timeDiffArg(env, arg1, withDate)
+	if !ok {
+		return nil, nil
+	}
+	dt2, tt2, prec2, ok := timeDiffArg(env, arg2, withDate)
+	if !ok {
+		return nil, nil
+	}
+	// the difference between a time and a date is not defined
+	if tt1 != tt2 {
+		return nil, nil
+	}
+
+	seconds := func(dt datetime.DateTime) (int64, int64) {
+		dur := dt.Time.ToDuration()
+		return int64(dt.Date.DayNumber())*24*3600 + int64(dur/time.Second), int64(dur % time.Second)
+	}
+	sec1, nsec1 := seconds(dt1)
+	sec2, nsec2 := seconds(dt2)
+
+	diff := decimal.NewFromInt(sec1 - sec2).Add(decimal.New(nsec1-nsec2, -9))
+	prec := maxprec(int32(prec1), int32(prec2))
+	t := secondsToTime(diff, prec)
+	return newEvalRaw(sqltypes.Time, t.Format(uint8(prec)), collationNumeric), nil
+}
+
+func (call *builtinTimeDiff) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	return sqltypes.Time, f1 | f2 | flagNullable
+}
+
+type builtinStrToDate struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinStrToDate)(nil)
+
+// strToDateType returns the type of the values that STR_TO_DATE parses with
+// the given format, and their number of fractional second digits.
+func strToDateType(format string) (sqltypes.Type, uint8) {
+	date, time, frac := datetime.StrptimeParts(format)
+	var prec uint8
+	if frac {
+		prec = datetime.DefaultPrecision
+	}
+	switch {
+	case date && !time:
+		return sqltypes.Date, 0
+	case time && !date:
+		return sqltypes.Time, prec
+	default:
+		return sqltypes.Datetime, prec
+	}
+}
+
+func (call *builtinStrToDate) eval(env *ExpressionEnv) (eval, error) {
+	str, format, err := call.arg2(env)
+	if err != nil || str == nil || format == nil {
+		return nil, err
+	}
+
+	s, err := evalToVarchar(str, env.DefaultCollation, true)
+	if err != nil {
+		return nil, err
+	}
+	f, err := evalToVarchar(format, env.DefaultCollation, true)
+	if err != nil {
+		return nil, err
+	}
+
+	dt, ok := datetime.Strptime(hack.String(s.bytes), hack.String(f.bytes))
+	if !ok {
+		return nil, nil
+	}
+
+	tt, prec := strToDateType(hack.String(f.bytes))
+	if tt == sqltypes.Time {
+		return newEvalRaw(sqltypes.Time, dt.Time.Format(prec), collationNumeric), nil
+	}
+	// like in MySQL with its default SQL mode, dates with zero components
+	// are not valid results
+	if dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+		return nil, nil
+	}
+	if tt == sqltypes.Date {
+		return newEvalRaw(sqltypes.Date, dt.Date.Format(), collationNumeric), nil
+	}
+	return newEvalRaw(sqltypes.Datetime, dt.Format(prec), collationNumeric), nil
+}
+
+func (call *builtinStrToDate) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	tt := sqltypes.Datetime
+	if lit, ok := call.Arguments[1].(*Literal); ok && lit.inner != nil {
+		tt, _ = strToDateType(hack.String(lit.inner.ToRawBytes()))
+	}
+	return tt, f1 | f2 | flagNullable
+}
+
+type builtinFromUnixtime struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinFromUnixtime)(nil)
+
+// maxUnixtime is the largest timestamp accepted by FROM_UNIXTIME, which is
+// '3001-01-18 23:59:59' UTC like in MySQL.
+const maxUnixtime = 32536771199
+
+func (call *builtinFromUnixtime) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.Arguments[0].eval(env)
+	if err != nil || arg == nil {
+		return nil, err
+	}
+
+	var (
+		dec  decimal.Decimal
+		prec int32
+	)
+	switch arg := evalToNumeric(arg).(type) {
+	case *evalInt64:
+		dec = decimal.NewFromInt(arg.i)
+	case *evalUint64:
+		dec = decimal.NewFromUint(arg.u)
+	case *evalFloat:
+		dec = decimal.NewFromFloatMySQL(arg.f)
+		prec = datetime.DefaultPrecision
+	case *evalDecimal:
+		dec = arg.dec
+		prec = arg.length
+		if prec > datetime.DefaultPrecision {
+			prec = datetime.DefaultPrecision
+		}
+	}
+
+	if dec.Sign() < 0 {
+		return nil, nil
+	}
+	sec, ok := dec.Truncate(0).Int64()
+	if !ok || sec > maxUnixtime {
+		return nil, nil
+	}
+	// like in MySQL, digits past the microseconds are truncated
+	usec, _ := dec.Sub(decimal.NewFromInt(sec)).Mul(decimal.NewFromInt(1000000)).Int64()
+
+	t := time.Unix(sec, usec*1000).In(env.currentTimezone())
+	dt := datetime.NewDateTimeFromStd(t)
+
+	if len(call.Arguments) == 1 {
+		return newEvalRaw(sqltypes.Datetime, dt.Format(uint8(prec)), collationNumeric), nil
+	}
+
+	format, err := call.Arguments[1].eval(env)
+	if err != nil || format == nil {
+		return nil, err
+	}
+	f, err := evalToVarchar(format, collations.CollationUtf8mb4ID, true)
+	if err != nil {
+		return nil, err
+	}
+	text, ok := datetime.Strftime(nil, f.bytes, dt, env.currentLocale())
+	if !ok {
+		return nil, nil
+	}
+	return evalLocaleText(env, text)
+}
+
+func (call *builtinFromUnixtime) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	if len(call.Arguments) == 1 {
+		return sqltypes.Datetime, f | flagNullable
+	}
+	call.Arguments[1].typeof(env)
+	return sqltypes.VarChar, flagNullable
+}
+
+type builtinUnixTimestamp struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinUnixTimestamp)(nil)
+
+func (call *builtinUnixTimestamp) eval(env *ExpressionEnv) (eval, error) {
+	if len(call.Arguments) == 0 {
+		return newEvalInt64(env.time().Unix()), nil
+	}
+
+	arg, err := call.arg1(env)
+	if err != nil || arg == nil {
+		return nil, err
+	}
+
+	// like in MySQL, values that are not valid dates, or that are out of the
+	// range of timestamps, result in 0 instead of NULL
+	var sec int64
+	var nsec int
+	dt, prec, ok := evalToDateTimeToday(env, arg)
+	if ok && dt.Date.Month() != 0 && dt.Date.Day() != 0 {
+		t := dt.ToStdTime(env.currentTimezone())
+		if t.Unix() >= 0 && t.Unix() <= maxUnixtime {
+			sec, nsec = t.Unix(), t.Nanosecond()
+		}
+	}
+
+	prec, dec := secondsPrecision(call.Arguments[0], arg.SQLType(), prec)
+	if !dec {
+		return newEvalInt64(sec), nil
+	}
+	return secondsDecimal(sec, nsec, prec, false), nil
+}
+
+func (call *builtinUnixTimestamp) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	if len(call.Arguments) == 0 {
+		return sqltypes.Int64, 0
+	}
+	tt, f := call.Arguments[0].typeof(env)
+	var prec int
+	if call.Arguments[0].constant() {
+		if arg, err := call.Arguments[0].eval(env); err == nil && arg != nil {
+			_, prec, _ = evalToDateTimeToday(env, arg)
+		}
+	}
+	if _, dec := secondsPrecision(call.Arguments[0], tt, prec); dec {
+		return sqltypes.Decimal, f
+	}
+	return sqltypes.Int64, f
+}
+
+type builtinMakeDate struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinMakeDate)(nil)
+
+func (call *builtinMakeDate) eval(env *ExpressionEnv) (eval, error) {
+	year, yearday, err := call.arg2(env)
+	if err != nil || year == nil || yearday == nil {
+		return nil, err
+	}
+
+	d, ok := datetime.MakeDate(evalToNumeric(year).toInt64().i, evalToNumeric(yearday).toInt64().i)
+	if !ok {
+		return nil, nil
+	}
+	return newEvalRaw(sqltypes.Date, d.Format(), collationNumeric), nil
+}
+
+func (call *builtinMakeDate) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	call.Arguments[0].typeof(env)
+	call.Arguments[1].typeof(env)
+	return sqltypes.Date, flagNullable
+}
+
+type builtinMakeTime struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinMakeTime)(nil)
+
+func (call *builtinMakeTime) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if arg == nil {
+			return nil, nil
+		}
+	}
+
+	var hour decimal.Decimal
+	switch h := evalToNumeric(args[0]).(type) {
+	case *evalUint64:
+		hour = decimal.NewFromUint(h.u)
+	default:
+		hour = decimal.NewFromInt(h.toInt64().i)
+	}
+	minute := evalToNumeric(args[1]).toInt64().i
+
+	var (
+		sec  decimal.Decimal
+		prec int32
+	)
+	switch s := evalToNumeric(args[2]).(type) {
+	case *evalInt64:
+		sec = decimal.NewFromInt(s.i)
+	case *evalUint64:
+		sec = decimal.NewFromUint(s.u)
+	case *evalFloat:
+		sec = decimal.NewFromFloatMySQL(s.f)
+		prec = datetime.DefaultPrecision
+	case *evalDecimal:
+		sec = s.dec
+		prec = s.length
+		if prec > datetime.DefaultPrecision {
+			prec = datetime.DefaultPrecision
+		}
+	}
+
+	if minute < 0 || minute > 59 || sec.Sign() < 0 || sec.Cmp(decimal.NewFromInt(60)) >= 0 {
+		return nil, nil
+	}
+
+	// the sign of the hour applies to the whole time, and hours beyond the
+	// TIME range are clamped
+	neg := hour.Sign() < 0
+	if neg {
+		hour = hour.Neg()
+	}
+	total := hour.Mul(decimal.NewFromInt(3600)).Add(decimal.NewFromInt(minute * 60)).Add(sec)
+	if neg {
+		total = total.Neg()
+	}
+	t := secondsToTime(total, prec)
+	return newEvalRaw(sqltypes.Time, t.Format(uint8(prec)), collationNumeric), nil
+}
+
+func (call *builtinMakeTime) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	for _, arg := range call.Arguments {
+		arg.typeof(env)
+	}
+	return sqltypes.Time, flagNullable
+}
+
+type builtinConvertTz struct {
+	CallExpr
+}
+
+var _ Expr = (*builtinConvertTz)(nil)
+
+func (call *builtinConvertTz) eval(env *ExpressionEnv) (eval, error) {
+	args, err := call.args(env)
+	if err != nil {
+		return nil, err
+	}
+	if args[0] == nil || args[1] == nil || args[2] == nil {
+		return nil, nil
+	}
+
+	dt, prec, ok := evalToDateTimeToday(env, args[0])
+	if !ok || dt.Date.Month() == 0 || dt.Date.Day() == 0 {
+		return nil, nil
+	}
+
+	var zones [2]*time.Location
+	for i, arg := range args[1:] {
+		tz, err := evalToVarchar(arg, collations.CollationUtf8mb4ID, true)
+		if err != nil {
+			return nil, err
+		}
+		if zones[i], ok = datetime.ParseTimeZone(tz.string()); !ok {
+			return nil, nil
+		}
+	}
+
+	// like in MySQL, datetimes outside the range of timestamps are returned
+	// without any conversion
+	t := dt.ToStdTime(zones[0])
+	if t.Unix() >= 0 && t.Unix() <= maxUnixtime {
+		dt = datetime.NewDateTimeFromStd(t.In(zones[1]))
+	}
+	return newEvalRaw(sqltypes.Datetime, dt.Format(uint8(prec)), collationNumeric), nil
+}
+
+func (call *builtinConvertTz) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f1 := call.Arguments[0].typeof(env)
+	_, f2 := call.Arguments[1].typeof(env)
+	_, f3 := call.Arguments[2].typeof(env)
+	return sqltypes.Datetime, f1 | f2 | f3 | flagNullable
+}
+
+// builtinExtract implements EXTRACT. Compound units return their components
+// concatenated as a single number, like 202301 for YEAR_MONTH.
+type builtinExtract struct {
+	CallExpr
+	unit datetime.IntervalType
+}
+
+var _ Expr = (*builtinExtract)(nil)
+
+// maxTimeInt64 is the largest number that MySQL interprets as a TIME in the
+// hhmmss form; larger numbers are interpreted as datetimes instead.
+const maxTimeInt64 = 8385959
+
+// evalToExtractTime converts the argument of EXTRACT into a time of day for
+// the units that have a time component. Like in MySQL, the day of the month
+// is also returned for values that have a date, while TIME values have no
+// day, but can have more than 24 hours.
+func evalToExtractTime(env *ExpressionEnv, e eval) (int, datetime.Time, bool) {
+	switch e := e.(type) {
+	case *evalBytes:
+		if e.SQLType() == sqltypes.Time {
+			t, _, ok := datetime.ParseTime(e.string())
+			return 0, t, ok
+		}
+		s := strings.TrimSpace(e.string())
+		if dt, _, hasTime, ok := datetime.ParseDateOrDateTime(s); ok && (hasTime || !strings.Contains(s, ":")) {
+			return dt.Date.Day(), dt.Time, true
+		}
+		t, _, ok := datetime.ParseTime(s)
+		return 0, t, ok
+	case evalNumeric:
+		if i, _, _ := splitNumericTemporal(e); i > maxTimeInt64 {
+			dt, ok := evalToDateTime(env, e)
+			return dt.Date.Day(), dt.Time, ok
+		}
+		t, _, ok := evalToTime(e)
+		return 0, t, ok
+	default:
+		return 0, datetime.Time{}, false
+	}
+}
+
+func (call *builtinExtract) eval(env *ExpressionEnv) (eval, error) {
+	arg, err := call.arg1(env)
+	if err != nil || arg == nil {
+		return nil, err
+	}
+
+	switch call.unit {
+	case datetime.IntervalYear, datetime.IntervalYearMonth, datetime.IntervalQuarter,
+		datetime.IntervalMonth, datetime.IntervalWeek, datetime.IntervalDay:
+		dt, ok := evalToDateTime(env, arg)
+		if !ok {
+			return nil, nil
+		}
+		d := dt.Date
+		switch call.unit {
+		case datetime.IntervalYear:
+			return newEvalInt64(int64(d.Year())), nil
+		case datetime.IntervalYearMonth:
+			return newEvalInt64(int64(d.Year())*100 + int64(d.Month())), nil
+		case datetime.IntervalQuarter:
+			return newEvalInt64(int64(d.Month()+2) / 3), nil
+		case datetime.IntervalMonth:
+			return newEvalInt64(int64(d.Month())), nil
+		case datetime.IntervalWeek:
+			return newEvalInt64(int64(d.Week(defaultWeekFormat))), nil
+		default:
+			return newEvalInt64(int64(d.Day())), nil
+		}
+	}
+
+	day, t, ok := evalToExtractTime(env, arg)
+	if !ok {
+		return nil, nil
+	}
+
+	var (
+		dd   = int64(day)
+		hh   = int64(t.Hour())
+		mm   = int64(t.Minute())
+		ss   = int64(t.Second())
+		usec = int64(t.Nanosecond() / 1000)
+		n    int64
+	)
+	switch call.unit {
+	case datetime.IntervalDayHour:
+		n = dd*100 + hh
+	case datetime.IntervalDayMinute:
+		n = dd*10000 + hh*100 + mm
+	case datetime.IntervalDaySecond:
+		n = dd*1000000 + hh*10000 + mm*100 + ss
+	case datetime.IntervalHour:
+		n = hh
+	case datetime.IntervalHourMinute:
+		n = hh*100 + mm
+	case datetime.IntervalHourSecond:
+		n = hh*10000 + mm*100 + ss
+	case datetime.IntervalMinute:
+		n = mm
+	case datetime.IntervalMinuteSecond:
+		n = mm*100 + ss
+	case datetime.IntervalSecond:
+		n = ss
+	case datetime.IntervalMicrosecond:
+		n = usec
+	case datetime.IntervalDayMicrosecond:
+		n = (dd*1000000+hh*10000+mm*100+ss)*1000000 + usec
+	case datetime.IntervalHourMicrosecond:
+		n = (hh*10000+mm*100+ss)*1000000 + usec
+	case datetime.IntervalMinuteMicrosecond:
+		n = (mm*100+ss)*1000000 + usec
+	case datetime.IntervalSecondMicrosecond:
+		n = ss*1000000 + usec
+	}
+	if t.Neg() {
+		n = -n
+	}
+	return newEvalInt64(n), nil
+}
+
+func (call *builtinExtract) typeof(env *ExpressionEnv) (sqltypes.Type, typeFlag) {
+	_, f := call.Arguments[0].typeof(env)
+	return sqltypes.Int64, f | flagNullable
+}
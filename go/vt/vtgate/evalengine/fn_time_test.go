@@ -0,0 +1,1513 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evalengine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"vitess.io/vitess/go/mysql/collations"
+	"vitess.io/vitess/go/sqltypes"
+	querypb "vitess.io/vitess/go/vt/proto/query"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/datetime"
+)
+
+func TestDateFormat(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"DATE_FORMAT('2023-01-01 00:00:00', '%h %I %l %p')", sqltypes.NewVarChar("12 12 12 AM")},
+		{"DATE_FORMAT('2023-01-01 00:00:00', '%r')", sqltypes.NewVarChar("12:00:00 AM")},
+		{"DATE_FORMAT('2023-01-01 00:30:00', '%r')", sqltypes.NewVarChar("12:30:00 AM")},
+		{"DATE_FORMAT('2023-01-01 12:00:00', '%h %I %l %p')", sqltypes.NewVarChar("12 12 12 PM")},
+		{"DATE_FORMAT('2023-01-01 12:00:00', '%r')", sqltypes.NewVarChar("12:00:00 PM")},
+		{"DATE_FORMAT('2023-01-01 11:59:59', '%r')", sqltypes.NewVarChar("11:59:59 AM")},
+		{"DATE_FORMAT('2023-06-15 15:30:45', '%h %I %l %p')", sqltypes.NewVarChar("03 03 3 PM")},
+		{"DATE_FORMAT('2023-06-15 15:30:45', '%r')", sqltypes.NewVarChar("03:30:45 PM")},
+		{"DATE_FORMAT('2023-06-15 09:05:01', '%l:%i %p')", sqltypes.NewVarChar("9:05 AM")},
+		{"DATE_FORMAT('2023-06-15 15:30:45.123456', '%Y-%m-%d %T.%f')", sqltypes.NewVarChar("2023-06-15 15:30:45.123456")},
+		{"DATE_FORMAT(TIMESTAMP '2023-06-15 15:30:45', '%H %k')", sqltypes.NewVarChar("15 15")},
+		{"DATE_FORMAT(20230615, '%y %c %e')", sqltypes.NewVarChar("23 6 15")},
+		{"DATE_FORMAT('2023-06-15', '%q')", sqltypes.NewVarChar("q")},
+		{"DATE_FORMAT('not a date', '%Y')", sqltypes.NULL},
+		{"DATE_FORMAT(NULL, '%Y')", sqltypes.NULL},
+		{"DATE_FORMAT('2023-06-15', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestDateFormatZeroDate(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"DATE_FORMAT('0000-00-00', '%Y')", sqltypes.NewVarChar("0000")},
+		{"DATE_FORMAT('0000-00-00', '%Y-%m-%d')", sqltypes.NewVarChar("0000-00-00")},
+		{"DATE_FORMAT('0000-00-00', '%y %c %e')", sqltypes.NewVarChar("00 0 0")},
+		{"DATE_FORMAT('0000-00-00', '%H:%i:%s.%f')", sqltypes.NewVarChar("00:00:00.000000")},
+		{"DATE_FORMAT('0000-00-00', '%h %I %l %p')", sqltypes.NewVarChar("12 12 12 AM")},
+		{"DATE_FORMAT('0000-00-00', '%r|%T')", sqltypes.NewVarChar("12:00:00 AM|00:00:00")},
+		{"DATE_FORMAT('0000-00-00 00:00:00', '%Y-%m-%d %T')", sqltypes.NewVarChar("0000-00-00 00:00:00")},
+		{"DATE_FORMAT(0, '%Y-%m-%d')", sqltypes.NewVarChar("0000-00-00")},
+		{"DATE_FORMAT('2023-00-00', '%Y-%m-%d')", sqltypes.NewVarChar("2023-00-00")},
+		{"DATE_FORMAT('0000-00-00 25:00:00', '%Y')", sqltypes.NULL},
+		{"DATE_FORMAT('0000-00-00', '%D %j')", sqltypes.NewVarChar("0th 000")},
+		// the names of the days and months of a zero date are NULL
+		{"DATE_FORMAT('0000-00-00', '%W')", sqltypes.NULL},
+		{"DATE_FORMAT('0000-00-00', '%a')", sqltypes.NULL},
+		{"DATE_FORMAT('0000-00-00', '%w')", sqltypes.NULL},
+		{"DATE_FORMAT('0000-00-00', '%M')", sqltypes.NULL},
+		{"DATE_FORMAT('0000-00-00', '%b')", sqltypes.NULL},
+		{"DATE_FORMAT('2023-00-15', '%M')", sqltypes.NULL},
+		{"DATE_FORMAT('0000-01-00', '%M %b')", sqltypes.NewVarChar("January Jan")},
+	})
+}
+
+func TestDateFormatSpecifiers(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"DATE_FORMAT('2023-06-15', '%W %a %w')", sqltypes.NewVarChar("Thursday Thu 4")},
+		{"DATE_FORMAT('2023-06-18', '%W %a %w')", sqltypes.NewVarChar("Sunday Sun 0")},
+		{"DATE_FORMAT('2023-06-15', '%M %b')", sqltypes.NewVarChar("June Jun")},
+		{"DATE_FORMAT('2023-06-15', '%j')", sqltypes.NewVarChar("166")},
+		{"DATE_FORMAT('2023-01-01', '%j')", sqltypes.NewVarChar("001")},
+		{"DATE_FORMAT('2024-12-31', '%j')", sqltypes.NewVarChar("366")},
+		{"DATE_FORMAT('2023-06-15', '%U %u %V %v %X %x')", sqltypes.NewVarChar("24 24 24 24 2023 2023")},
+		{"DATE_FORMAT('2023-01-01', '%U %u %V %v %X %x')", sqltypes.NewVarChar("01 00 01 52 2023 2022")},
+		{"DATE_FORMAT('2024-12-30', '%v %x')", sqltypes.NewVarChar("01 2025")},
+		{"DATE_FORMAT('2023-06-01', '%D')", sqltypes.NewVarChar("1st")},
+		{"DATE_FORMAT('2023-06-02', '%D')", sqltypes.NewVarChar("2nd")},
+		{"DATE_FORMAT('2023-06-03', '%D')", sqltypes.NewVarChar("3rd")},
+		{"DATE_FORMAT('2023-06-04', '%D')", sqltypes.NewVarChar("4th")},
+		{"DATE_FORMAT('2023-06-11', '%D %D')", sqltypes.NewVarChar("11th 11th")},
+		{"DATE_FORMAT('2023-06-12', '%D')", sqltypes.NewVarChar("12th")},
+		{"DATE_FORMAT('2023-06-13', '%D')", sqltypes.NewVarChar("13th")},
+		{"DATE_FORMAT('2023-06-21', '%D')", sqltypes.NewVarChar("21st")},
+		{"DATE_FORMAT('2023-06-22', '%D')", sqltypes.NewVarChar("22nd")},
+		{"DATE_FORMAT('2023-06-23', '%D')", sqltypes.NewVarChar("23rd")},
+		{"DATE_FORMAT('2023-05-31', '%D')", sqltypes.NewVarChar("31st")},
+		{"DATE_FORMAT('2023-06-15 15:30:45', '%W, %M %D %Y %r')", sqltypes.NewVarChar("Thursday, June 15th 2023 03:30:45 PM")},
+		{"DATE_FORMAT('2023-06-15', '100%% %z %')", sqltypes.NewVarChar("100% z %")},
+	})
+}
+
+func TestDateFormatPercent(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"DATE_FORMAT('2023-06-15', '%%%Y')", sqltypes.NewVarChar("%2023")},
+		{"DATE_FORMAT('2023-06-15', '%Y%%')", sqltypes.NewVarChar("2023%")},
+		{"DATE_FORMAT('2023-06-15', '%%%%')", sqltypes.NewVarChar("%%")},
+		// like in MySQL, '%%' consumes the percent sign, so the 'Y' after it
+		// is just a literal character and not a specifier
+		{"DATE_FORMAT('2023-06-15', '%%Y')", sqltypes.NewVarChar("%Y")},
+		{"DATE_FORMAT('2023-06-15 10:20:30', '%H%%%i')", sqltypes.NewVarChar("10%20")},
+	})
+
+	env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+	env.Tz = time.UTC
+	env.SetTime(time.Date(2023, 6, 15, 10, 20, 30, 0, time.UTC))
+
+	res, err := env.Evaluate(translateForEnv(t, "DATE_FORMAT(NOW(), '%%%Y')"))
+	require.NoError(t, err)
+	require.Equal(t, sqltypes.NewVarChar("%2023"), res.Value())
+}
+
+func TestDateFormatLocale(t *testing.T) {
+	cases := []struct {
+		locale   string
+		expected string
+	}{
+		{"", "Wednesday Wed 15 March Mar"},
+		{"en_US", "Wednesday Wed 15 March Mar"},
+		{"es_ES", "miércoles mié 15 marzo mar"},
+		{"de_DE", "Mittwoch Mi 15 März Mär"},
+		{"xx_XX", "Wednesday Wed 15 March Mar"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.locale, func(t *testing.T) {
+			expr := translateForEnv(t, "DATE_FORMAT('2023-03-15 10:00:00', '%W %a %e %M %b')")
+			require.False(t, expr.constant())
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.LcTimeNames = tc.locale
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.NewVarChar(tc.expected), res.Value())
+		})
+	}
+
+	t.Run("connection charset", func(t *testing.T) {
+		env := EnvWithBindVars(nil, collations.Local().LookupByName("latin1_swedish_ci").ID())
+		env.LcTimeNames = "de_DE"
+
+		res, err := env.Evaluate(translateForEnv(t, "DATE_FORMAT('2023-03-15', '%b %Y')"))
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewVarChar("M\xe4r 2023"), res.Value())
+	})
+}
+
+func TestTemporalArithmetic(t *testing.T) {
+	// Subtracting two dates with the `-` operator does not count the days
+	// between them like DATEDIFF does: MySQL converts both dates to their
+	// YYYYMMDD numeric form and subtracts those instead.
+	testEvaluateCases(t, []evaluateCase{
+		// DATEDIFF would return 1 for each of these
+		{"DATE '2023-03-01' - DATE '2023-02-28'", sqltypes.NewInt64(73)},
+		{"DATE '2024-01-01' - DATE '2023-12-31'", sqltypes.NewInt64(8870)},
+		{"DATE '2023-02-01' - DATE '2023-01-31'", sqltypes.NewInt64(70)},
+		// within a month both agree
+		{"DATE '2023-01-10' - DATE '2023-01-05'", sqltypes.NewInt64(5)},
+		{"DATE '2023-01-01' - DATE '2023-01-02'", sqltypes.NewInt64(-1)},
+		{"DATE '2023-01-01' + 0", sqltypes.NewInt64(20230101)},
+		{"TIMESTAMP '2023-01-01 00:01:00' - TIMESTAMP '2023-01-01 00:00:59'", sqltypes.NewInt64(41)},
+		{"TIMESTAMP '2023-01-01 10:00:00.5' - 0", sqltypes.NewDecimal("20230101100000.5")},
+		{"TIME '10:00:00' - TIME '09:59:59'", sqltypes.NewInt64(4041)},
+		{"DATE '2023-01-01' - NULL", sqltypes.NULL},
+	})
+}
+
+func TestTemporalArithmeticTypeOf(t *testing.T) {
+	testColumnTypeOf(t, "column0 + 0", []sqltypes.Value{
+		datetimeValue("2023-01-01 10:00:00"),
+		datetimeValue("2023-01-01 10:00:00.5"),
+		timeValue("10:00:00"),
+		timeValue("10:00:00.25"),
+		sqltypes.MakeTrusted(sqltypes.Date, []byte("2023-01-01")),
+	}, []sqltypes.Value{
+		sqltypes.NewInt64(20230101100000),
+		sqltypes.NewDecimal("20230101100000.5"),
+		sqltypes.NewInt64(100000),
+		sqltypes.NewDecimal("100000.25"),
+		sqltypes.NewInt64(20230101),
+	})
+
+	// casts have the precision they are given
+	testColumnTypeOf(t, "CAST(column0 AS TIME(1)) - 0", []sqltypes.Value{
+		sqltypes.NewVarChar("10:00:00"),
+		sqltypes.NewVarChar("10:00:00.5"),
+		sqltypes.NewVarChar("10:00:00.25"),
+	}, []sqltypes.Value{
+		sqltypes.NewDecimal("100000.0"),
+		sqltypes.NewDecimal("100000.5"),
+		sqltypes.NewDecimal("100000.3"),
+	})
+	testColumnTypeOf(t, "-CAST(column0 AS DATETIME)", []sqltypes.Value{
+		sqltypes.NewVarChar("2023-01-01 10:00:00"),
+		sqltypes.NewVarChar("2023-01-01 10:00:00.5"),
+	}, []sqltypes.Value{
+		sqltypes.NewInt64(-20230101100000),
+		sqltypes.NewInt64(-20230101100001),
+	})
+
+	// the precision of any other computed time is only known once it is
+	// evaluated, so it is always a decimal
+	testColumnTypeOf(t, "COALESCE(column0) + 0", []sqltypes.Value{
+		datetimeValue("2023-01-01 10:00:00"),
+		datetimeValue("2023-01-01 10:00:00.5"),
+	}, []sqltypes.Value{
+		sqltypes.NewDecimal("20230101100000"),
+		sqltypes.NewDecimal("20230101100000.5"),
+	})
+
+	for _, tc := range []struct {
+		expr string
+		typ  sqltypes.Type
+	}{
+		{"TIMESTAMP '2023-01-01 00:01:00' - TIMESTAMP '2023-01-01 00:00:59'", sqltypes.Int64},
+		{"TIMESTAMP '2023-01-01 10:00:00.5' - 0", sqltypes.Decimal},
+		{"TIME '10:00:00' - TIME '09:59:59'", sqltypes.Int64},
+		{"DATE '2023-01-01' + 0", sqltypes.Int64},
+		{"-DATE '2023-01-01'", sqltypes.Int64},
+		{"-TIMESTAMP '2023-01-01 10:00:00'", sqltypes.Int64},
+		{"-TIMESTAMP '2023-01-01 10:00:00.5'", sqltypes.Decimal},
+	} {
+		expr := translateForEnv(t, tc.expr)
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		typ, err := env.TypeOf(expr)
+		require.NoError(t, err)
+		require.Equal(t, tc.typ, typ, tc.expr)
+
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, tc.typ, res.Value().Type(), tc.expr)
+	}
+
+	// bind variables have the precision of their value
+	for _, tc := range []struct {
+		expr  string
+		value sqltypes.Value
+		typ   sqltypes.Type
+	}{
+		{":a + 0", datetimeValue("2023-01-01 10:00:00"), sqltypes.Int64},
+		{":a + 0", datetimeValue("2023-01-01 10:00:00.5"), sqltypes.Decimal},
+		{":a - 1", timeValue("10:00:00"), sqltypes.Int64},
+		{"-:a", sqltypes.MakeTrusted(sqltypes.Date, []byte("2023-01-01")), sqltypes.Int64},
+		{"-:a", datetimeValue("2023-01-01 10:00:00"), sqltypes.Int64},
+		{"-:a", timeValue("10:00:00.25"), sqltypes.Decimal},
+	} {
+		expr := translateForEnv(t, tc.expr)
+		env := EnvWithBindVars(map[string]*querypb.BindVariable{
+			"a": sqltypes.ValueBindVariable(tc.value),
+		}, collations.CollationUtf8mb4ID)
+		typ, err := env.TypeOf(expr)
+		require.NoError(t, err)
+		require.Equal(t, tc.typ, typ, tc.expr)
+
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, tc.typ, res.Value().Type(), tc.expr)
+	}
+}
+
+func TestSecondsFractions(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"SEC_TO_TIME(3661.5)", sqltypes.MakeTrusted(sqltypes.Time, []byte("01:01:01.5"))},
+		{"SEC_TO_TIME(3661.25)", sqltypes.MakeTrusted(sqltypes.Time, []byte("01:01:01.25"))},
+		{"SEC_TO_TIME(-3661.5)", sqltypes.MakeTrusted(sqltypes.Time, []byte("-01:01:01.5"))},
+		{"SEC_TO_TIME(0.1234567)", sqltypes.MakeTrusted(sqltypes.Time, []byte("00:00:00.123457"))},
+		{"SEC_TO_TIME(3661.5e0)", sqltypes.MakeTrusted(sqltypes.Time, []byte("01:01:01.500000"))},
+		{"SEC_TO_TIME(3661)", sqltypes.MakeTrusted(sqltypes.Time, []byte("01:01:01"))},
+		{"TIME_TO_SEC('01:01:01.5')", sqltypes.NewDecimal("3661.5")},
+		{"TIME_TO_SEC('-01:01:01.250')", sqltypes.NewDecimal("-3661.250")},
+		{"TIME_TO_SEC(TIME '01:01:01.5')", sqltypes.NewDecimal("3661.5")},
+		{"TIME_TO_SEC(10101.5)", sqltypes.NewDecimal("3661.5")},
+		{"TIME_TO_SEC('01:01:01')", sqltypes.NewInt64(3661)},
+		{"TIME_TO_SEC(SEC_TO_TIME(3661.5))", sqltypes.NewDecimal("3661.5")},
+		{"TIME_TO_SEC(SEC_TO_TIME(-0.75))", sqltypes.NewDecimal("-0.75")},
+		{"SEC_TO_TIME(TIME_TO_SEC('12:34:56.789'))", sqltypes.MakeTrusted(sqltypes.Time, []byte("12:34:56.789"))},
+		{"SEC_TO_TIME(NULL)", sqltypes.NULL},
+		{"TIME_TO_SEC(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestTimeToSecTypeOf(t *testing.T) {
+	// the type of a column decides the type of the result, so values without
+	// fractional seconds still result in a decimal
+	testColumnTypeOf(t, "TIME_TO_SEC(column0)", []sqltypes.Value{
+		sqltypes.NewVarChar("01:01:01"),
+		sqltypes.NewVarChar("01:01:01.5"),
+		timeValue("01:01:01"),
+		timeValue("-01:01:01.50"),
+		sqltypes.MakeTrusted(sqltypes.Date, []byte("2020-01-01")),
+		sqltypes.NewInt64(10101),
+		sqltypes.NewDecimal("10101.5"),
+	}, []sqltypes.Value{
+		sqltypes.NewDecimal("3661.000000"),
+		sqltypes.NewDecimal("3661.500000"),
+		sqltypes.NewDecimal("3661"),
+		sqltypes.NewDecimal("-3661.50"),
+		sqltypes.NewInt64(0),
+		sqltypes.NewInt64(3661),
+		sqltypes.NewDecimal("3661.5"),
+	})
+}
+
+func TestSecToTimeRange(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// values over 24 hours are kept, up to the TIME range
+		{"SEC_TO_TIME(86400)", timeValue("24:00:00")},
+		{"SEC_TO_TIME(90061)", timeValue("25:01:01")},
+		{"SEC_TO_TIME(3020399)", timeValue("838:59:59")},
+		{"SEC_TO_TIME(3020400)", timeValue("838:59:59")},
+		{"SEC_TO_TIME(3020399.5)", timeValue("838:59:59.0")},
+		{"SEC_TO_TIME(18446744073709551615)", timeValue("838:59:59")},
+		{"SEC_TO_TIME('90061')", timeValue("25:01:01.000000")},
+
+		// negative seconds result in a negative TIME
+		{"SEC_TO_TIME(-1)", timeValue("-00:00:01")},
+		{"SEC_TO_TIME(-90061)", timeValue("-25:01:01")},
+		{"SEC_TO_TIME(-3020400)", timeValue("-838:59:59")},
+
+		{"TIME_TO_SEC('25:01:01')", sqltypes.NewInt64(90061)},
+		{"TIME_TO_SEC('-25:01:01')", sqltypes.NewInt64(-90061)},
+		{"TIME_TO_SEC('838:59:59')", sqltypes.NewInt64(3020399)},
+		{"TIME_TO_SEC('-00:00:01')", sqltypes.NewInt64(-1)},
+		{"TIME_TO_SEC('2023-06-15 10:00:00')", sqltypes.NewInt64(36000)},
+
+		// round trips
+		{"TIME_TO_SEC(SEC_TO_TIME(90061))", sqltypes.NewInt64(90061)},
+		{"TIME_TO_SEC(SEC_TO_TIME(-90061.25))", sqltypes.NewDecimal("-90061.25")},
+		{"TIME_TO_SEC(SEC_TO_TIME(3020400))", sqltypes.NewInt64(3020399)},
+		{"SEC_TO_TIME(TIME_TO_SEC('-100:00:00.5'))", timeValue("-100:00:00.5")},
+	})
+}
+
+func TestNow(t *testing.T) {
+	now := time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC)
+
+	cases := []struct {
+		expression string
+		tz         *time.Location
+		expected   string
+	}{
+		{"NOW()", time.UTC, "2023-06-15 10:20:30"},
+		{"NOW(1)", time.UTC, "2023-06-15 10:20:30.1"},
+		{"NOW(3)", time.UTC, "2023-06-15 10:20:30.123"},
+		{"NOW(6)", time.UTC, "2023-06-15 10:20:30.123456"},
+		{"CURRENT_TIMESTAMP", time.UTC, "2023-06-15 10:20:30"},
+		{"CURRENT_TIMESTAMP(2)", time.UTC, "2023-06-15 10:20:30.12"},
+		{"LOCALTIME()", time.UTC, "2023-06-15 10:20:30"},
+		{"LOCALTIMESTAMP(4)", time.UTC, "2023-06-15 10:20:30.1234"},
+		{"NOW()", time.FixedZone("", 5*3600+30*60), "2023-06-15 15:50:30"},
+		{"NOW(6)", time.FixedZone("", -14*3600), "2023-06-14 20:20:30.123456"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr := translateForEnv(t, tc.expression)
+			require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Tz = tc.tz
+			env.SetTime(now)
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.MakeTrusted(sqltypes.Datetime, []byte(tc.expected)), res.Value())
+		})
+	}
+
+	t.Run("pinned to the statement", func(t *testing.T) {
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		res, err := env.Evaluate(translateForEnv(t, "NOW(6) = NOW(6) AND NOW(6) = CURRENT_TIMESTAMP(6)"))
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewInt64(1), res.Value())
+
+		expr := translateForEnv(t, "NOW(6)")
+		first, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+		second, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, first.Value(), second.Value())
+	})
+}
+
+func TestCastTime(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CAST(CAST('-01:00:00' AS TIME) AS CHAR)", sqltypes.NewVarChar("-01:00:00")},
+		{"CAST(CAST('-838:59:59' AS TIME) AS CHAR)", sqltypes.NewVarChar("-838:59:59")},
+		{"CAST(CAST(-10203 AS TIME) AS CHAR)", sqltypes.NewVarChar("-01:02:03")},
+		{"CAST(TIME '-01:00:00' AS CHAR)", sqltypes.NewVarChar("-01:00:00")},
+		{"CAST('-01:00:00' AS TIME)", sqltypes.MakeTrusted(sqltypes.Time, []byte("-01:00:00"))},
+		{"CAST('12:34:56.5' AS TIME)", sqltypes.MakeTrusted(sqltypes.Time, []byte("12:34:57"))},
+		{"CAST('12:34:59.5' AS TIME)", sqltypes.MakeTrusted(sqltypes.Time, []byte("12:35:00"))},
+		{"CAST('12:34:56.123456' AS TIME(3))", sqltypes.MakeTrusted(sqltypes.Time, []byte("12:34:56.123"))},
+		{"CAST('2023-06-15 12:34:56' AS TIME)", sqltypes.MakeTrusted(sqltypes.Time, []byte("12:34:56"))},
+		{"CAST('not a time' AS TIME)", sqltypes.NULL},
+		{"CAST(NULL AS TIME)", sqltypes.NULL},
+	})
+}
+
+func TestCastTimeRange(t *testing.T) {
+	// values beyond the TIME range are clamped to its boundaries, which have
+	// no fractional seconds
+	testEvaluateCases(t, []evaluateCase{
+		{"CAST('838:59:59' AS TIME)", timeValue("838:59:59")},
+		{"CAST('839:00:00' AS TIME)", timeValue("838:59:59")},
+		{"CAST('-838:59:59' AS TIME)", timeValue("-838:59:59")},
+		{"CAST('-839:00:00' AS TIME)", timeValue("-838:59:59")},
+		{"CAST('34 23:00:00' AS TIME)", timeValue("838:59:59")},
+		{"CAST('838:59:59.5' AS TIME(1))", timeValue("838:59:59.0")},
+		{"CAST('-838:59:59.000001' AS TIME(6))", timeValue("-838:59:59.000000")},
+		{"CAST('838:59:58.5' AS TIME(1))", timeValue("838:59:58.5")},
+		{"CAST(8390000 AS TIME)", timeValue("838:59:59")},
+		{"CAST(-8390000 AS TIME)", timeValue("-838:59:59")},
+		{"CAST(8385959.5 AS TIME(1))", timeValue("838:59:59.0")},
+		{"CAST(8395959.5 AS TIME(1))", timeValue("838:59:59.0")},
+		{"CAST(-8385959.5e0 AS TIME(1))", timeValue("-838:59:59.0")},
+		{"CAST(8385958.5 AS TIME(1))", timeValue("838:59:58.5")},
+	})
+}
+
+func TestCurdateCurtime(t *testing.T) {
+	now := time.Date(2023, 6, 15, 23, 20, 30, 123456789, time.UTC)
+
+	cases := []struct {
+		expression string
+		tz         *time.Location
+		expected   sqltypes.Value
+	}{
+		{"CURDATE()", time.UTC, sqltypes.MakeTrusted(sqltypes.Date, []byte("2023-06-15"))},
+		{"CURRENT_DATE", time.UTC, sqltypes.MakeTrusted(sqltypes.Date, []byte("2023-06-15"))},
+		{"CURRENT_DATE()", time.FixedZone("", 2*3600), sqltypes.MakeTrusted(sqltypes.Date, []byte("2023-06-16"))},
+		{"CURTIME()", time.UTC, sqltypes.MakeTrusted(sqltypes.Time, []byte("23:20:30"))},
+		{"CURTIME(0)", time.UTC, sqltypes.MakeTrusted(sqltypes.Time, []byte("23:20:30"))},
+		{"CURTIME(2)", time.UTC, sqltypes.MakeTrusted(sqltypes.Time, []byte("23:20:30.12"))},
+		{"CURTIME(6)", time.UTC, sqltypes.MakeTrusted(sqltypes.Time, []byte("23:20:30.123456"))},
+		{"CURRENT_TIME", time.UTC, sqltypes.MakeTrusted(sqltypes.Time, []byte("23:20:30"))},
+		{"CURRENT_TIME(3)", time.UTC, sqltypes.MakeTrusted(sqltypes.Time, []byte("23:20:30.123"))},
+		{"CURTIME(3)", time.FixedZone("", -3600), sqltypes.MakeTrusted(sqltypes.Time, []byte("22:20:30.123"))},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr := translateForEnv(t, tc.expression)
+			require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Tz = tc.tz
+			env.SetTime(now)
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res.Value())
+		})
+	}
+
+	t.Run("same statement time as NOW", func(t *testing.T) {
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		res, err := env.Evaluate(translateForEnv(t, "CURDATE() = DATE_FORMAT(NOW(), '%Y-%m-%d') AND CURTIME(6) = DATE_FORMAT(NOW(6), '%H:%i:%s.%f')"))
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewInt64(1), res.Value())
+	})
+}
+
+func TestSysdate(t *testing.T) {
+	now := time.Date(2023, 6, 15, 10, 20, 30, 123456789, time.UTC)
+
+	cases := []struct {
+		expression string
+		tz         *time.Location
+		expected   string
+	}{
+		{"SYSDATE()", time.UTC, "2023-06-15 10:20:30"},
+		{"SYSDATE(0)", time.UTC, "2023-06-15 10:20:30"},
+		{"SYSDATE(3)", time.UTC, "2023-06-15 10:20:30.123"},
+		{"SYSDATE(6)", time.UTC, "2023-06-15 10:20:30.123456"},
+		{"SYSDATE(6)", time.FixedZone("", 5*3600+30*60), "2023-06-15 15:50:30.123456"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr := translateForEnv(t, tc.expression)
+			require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Tz = tc.tz
+			env.clock = func() time.Time { return now }
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.MakeTrusted(sqltypes.Datetime, []byte(tc.expected)), res.Value())
+		})
+	}
+
+	t.Run("not pinned to the statement", func(t *testing.T) {
+		cases := []struct {
+			expression string
+			expected   sqltypes.Value
+		}{
+			{"TIMESTAMPDIFF(MICROSECOND, SYSDATE(6), SYSDATE(6))", sqltypes.NewInt64(1500000)},
+			{"TIMESTAMPDIFF(MICROSECOND, NOW(6), NOW(6))", sqltypes.NewInt64(0)},
+			{"TIMESTAMPDIFF(MICROSECOND, NOW(6), SYSDATE(6))", sqltypes.NewInt64(1500000)},
+			{"SYSDATE(6) = SYSDATE(6)", sqltypes.NewInt64(0)},
+			{"NOW(6) = NOW(6)", sqltypes.NewInt64(1)},
+		}
+
+		for _, tc := range cases {
+			// the clock moves forward every time that it's read
+			clock := now
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Tz = time.UTC
+			env.clock = func() time.Time {
+				t := clock
+				clock = clock.Add(1500 * time.Millisecond)
+				return t
+			}
+
+			res, err := env.Evaluate(translateForEnv(t, tc.expression))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res.Value(), "%s", tc.expression)
+		}
+	})
+
+	t.Run("statement time", func(t *testing.T) {
+		// SetTime pins NOW(), but SYSDATE() still returns the actual time
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		env.Tz = time.UTC
+		env.SetTime(now.Add(-time.Hour))
+		env.clock = func() time.Time { return now }
+
+		res, err := env.Evaluate(translateForEnv(t, "TIMESTAMPDIFF(SECOND, NOW(), SYSDATE())"))
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewInt64(3600), res.Value())
+	})
+}
+
+func TestDateComponents(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"YEAR('2023-06-15')", sqltypes.NewInt64(2023)},
+		{"MONTH('2023-06-15')", sqltypes.NewInt64(6)},
+		{"DAY('2023-06-15')", sqltypes.NewInt64(15)},
+		{"DAYOFMONTH('2023-06-15')", sqltypes.NewInt64(15)},
+		{"YEAR('23-06-15 10:20:30')", sqltypes.NewInt64(2023)},
+		{"MONTH('2023/06/15 10:20:30.5')", sqltypes.NewInt64(6)},
+		{"DAY(20230615)", sqltypes.NewInt64(15)},
+		{"YEAR(20230615102030)", sqltypes.NewInt64(2023)},
+		{"MONTH(DATE '2023-06-15')", sqltypes.NewInt64(6)},
+		{"DAY(TIMESTAMP '2023-06-15 01:02:03')", sqltypes.NewInt64(15)},
+		{"YEAR('0000-00-00')", sqltypes.NewInt64(0)},
+		{"MONTH('0000-00-00')", sqltypes.NewInt64(0)},
+		{"DAY('0000-00-00')", sqltypes.NewInt64(0)},
+		{"MONTH('2023-00-15')", sqltypes.NewInt64(0)},
+		{"YEAR('not a date')", sqltypes.NULL},
+		{"MONTH('2023-13-01')", sqltypes.NULL},
+		{"DAY('2023-02-30')", sqltypes.NULL},
+		{"DAY('2023-06-15 25:00:00')", sqltypes.NULL},
+		{"YEAR(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestDayNumbering(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// 2023-06-15 is a Thursday
+		{"DAYOFWEEK('2023-06-15')", sqltypes.NewInt64(5)},
+		{"WEEKDAY('2023-06-15')", sqltypes.NewInt64(3)},
+		// 2023-06-18 is a Sunday, the first day for DAYOFWEEK and the last for WEEKDAY
+		{"DAYOFWEEK('2023-06-18')", sqltypes.NewInt64(1)},
+		{"WEEKDAY('2023-06-18')", sqltypes.NewInt64(6)},
+		// 2023-06-19 is a Monday
+		{"DAYOFWEEK('2023-06-19 10:00:00')", sqltypes.NewInt64(2)},
+		{"WEEKDAY(20230619)", sqltypes.NewInt64(0)},
+		{"DAYOFYEAR('2023-01-01')", sqltypes.NewInt64(1)},
+		{"DAYOFYEAR(DATE '2023-12-31')", sqltypes.NewInt64(365)},
+		{"DAYOFYEAR('2024-12-31')", sqltypes.NewInt64(366)},
+		{"DAYOFYEAR('2024-02-29')", sqltypes.NewInt64(60)},
+		{"DAYOFWEEK('0000-00-00')", sqltypes.NULL},
+		{"WEEKDAY('2023-00-15')", sqltypes.NULL},
+		{"DAYOFYEAR('2023-06-00')", sqltypes.NULL},
+		{"DAYOFYEAR('2023-02-30')", sqltypes.NULL},
+		{"DAYOFWEEK('not a date')", sqltypes.NULL},
+		{"WEEKDAY(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestTimeComponents(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"HOUR('10:20:30')", sqltypes.NewInt64(10)},
+		{"MINUTE('10:20:30')", sqltypes.NewInt64(20)},
+		{"SECOND('10:20:30')", sqltypes.NewInt64(30)},
+		{"MICROSECOND('10:20:30')", sqltypes.NewInt64(0)},
+		// TIME values can have more than 24 hours
+		{"HOUR('838:59:59')", sqltypes.NewInt64(838)},
+		{"HOUR('100:00:00')", sqltypes.NewInt64(100)},
+		{"HOUR('-100:00:00')", sqltypes.NewInt64(100)},
+		{"HOUR('2 10:00:00')", sqltypes.NewInt64(58)},
+		{"HOUR('900:00:00')", sqltypes.NewInt64(838)},
+		{"MINUTE('838:59:59')", sqltypes.NewInt64(59)},
+		// fractional seconds
+		{"MICROSECOND('10:20:30.123456')", sqltypes.NewInt64(123456)},
+		{"MICROSECOND('10:20:30.5')", sqltypes.NewInt64(500000)},
+		{"SECOND('10:20:30.999999')", sqltypes.NewInt64(30)},
+		{"MICROSECOND(TIME '10:20:30.25')", sqltypes.NewInt64(250000)},
+		{"MICROSECOND(102030.5)", sqltypes.NewInt64(500000)},
+		// datetimes
+		{"HOUR('2023-06-15 15:30:45')", sqltypes.NewInt64(15)},
+		{"MINUTE(TIMESTAMP '2023-06-15 15:30:45')", sqltypes.NewInt64(30)},
+		{"MICROSECOND('2023-06-15 15:30:45.000123')", sqltypes.NewInt64(123)},
+		{"SECOND(20230615153045)", sqltypes.NewInt64(45)},
+		{"HOUR(DATE '2023-06-15')", sqltypes.NewInt64(0)},
+		{"MINUTE('10:61:00')", sqltypes.NULL},
+		{"HOUR('not a time')", sqltypes.NULL},
+		{"SECOND(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestWeek(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"WEEK('2008-02-20')", sqltypes.NewInt64(7)},
+		{"WEEK('2008-02-20', 0)", sqltypes.NewInt64(7)},
+		{"WEEK('2008-02-20', 1)", sqltypes.NewInt64(8)},
+		{"WEEK('2008-12-31', 1)", sqltypes.NewInt64(53)},
+		// around the year boundary the mode decides both the first day of
+		// the week and whether the week belongs to the previous year
+		{"WEEK('2000-01-01', 0)", sqltypes.NewInt64(0)},
+		{"WEEK('2000-01-01', 2)", sqltypes.NewInt64(52)},
+		{"WEEK('2023-01-01', 0)", sqltypes.NewInt64(1)},
+		{"WEEK('2023-01-01', 1)", sqltypes.NewInt64(0)},
+		{"WEEK('2023-01-01', 3)", sqltypes.NewInt64(52)},
+		{"WEEK('2023-01-01', 7)", sqltypes.NewInt64(52)},
+		{"WEEK('2024-12-30', 0)", sqltypes.NewInt64(52)},
+		{"WEEK('2024-12-30', 1)", sqltypes.NewInt64(53)},
+		{"WEEK('2024-12-30', 3)", sqltypes.NewInt64(1)},
+		{"WEEK('2024-12-30', 5)", sqltypes.NewInt64(53)},
+		{"WEEK('2024-12-30', 6)", sqltypes.NewInt64(1)},
+		{"WEEK('2021-01-03', 3)", sqltypes.NewInt64(53)},
+		{"WEEK('2021-01-03', 7)", sqltypes.NewInt64(52)},
+		{"WEEK('2027-01-01', 3)", sqltypes.NewInt64(53)},
+		{"WEEK('2027-01-01', 6)", sqltypes.NewInt64(52)},
+		// only the three lowest bits of the mode are used
+		{"WEEK('2008-02-20', 9)", sqltypes.NewInt64(8)},
+		{"WEEK('2008-02-20', NULL)", sqltypes.NewInt64(7)},
+		{"WEEK(20081231, 1)", sqltypes.NewInt64(53)},
+		{"WEEK(TIMESTAMP '2008-12-31 23:59:59', 3)", sqltypes.NewInt64(1)},
+		{"WEEKOFYEAR('2008-02-20')", sqltypes.NewInt64(8)},
+		{"WEEKOFYEAR('2008-12-31')", sqltypes.NewInt64(1)},
+		{"WEEKOFYEAR('2021-01-03')", sqltypes.NewInt64(53)},
+		{"WEEKOFYEAR(DATE '2023-01-01')", sqltypes.NewInt64(52)},
+		{"WEEK('0000-00-00')", sqltypes.NULL},
+		{"WEEK('2023-00-15', 1)", sqltypes.NULL},
+		{"WEEK('2023-02-30')", sqltypes.NULL},
+		{"WEEKOFYEAR('not a date')", sqltypes.NULL},
+		{"WEEK(NULL, 1)", sqltypes.NULL},
+		{"WEEKOFYEAR(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestQuarter(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"QUARTER('2023-01-01')", sqltypes.NewInt64(1)},
+		{"QUARTER('2023-03-31')", sqltypes.NewInt64(1)},
+		{"QUARTER('2023-04-01')", sqltypes.NewInt64(2)},
+		{"QUARTER('2023-06-30')", sqltypes.NewInt64(2)},
+		{"QUARTER('2023-07-01')", sqltypes.NewInt64(3)},
+		{"QUARTER('2023-09-30')", sqltypes.NewInt64(3)},
+		{"QUARTER('2023-10-01')", sqltypes.NewInt64(4)},
+		{"QUARTER('2023-12-31 23:59:59')", sqltypes.NewInt64(4)},
+		{"QUARTER(DATE '2023-05-15')", sqltypes.NewInt64(2)},
+		{"QUARTER(TIMESTAMP '2023-08-15 10:00:00')", sqltypes.NewInt64(3)},
+		{"QUARTER(20231115)", sqltypes.NewInt64(4)},
+		{"QUARTER('0000-00-00')", sqltypes.NewInt64(0)},
+		{"QUARTER('2023-00-00')", sqltypes.NewInt64(0)},
+		{"QUARTER('2023-13-01')", sqltypes.NULL},
+		{"QUARTER('not a date')", sqltypes.NULL},
+		{"QUARTER(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestDayMonthName(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"DAYNAME('2023-06-15')", sqltypes.NewVarChar("Thursday")},
+		{"DAYNAME('2023-06-18 10:00:00')", sqltypes.NewVarChar("Sunday")},
+		{"DAYNAME(DATE '2024-02-29')", sqltypes.NewVarChar("Thursday")},
+		{"MONTHNAME('2023-06-15')", sqltypes.NewVarChar("June")},
+		{"MONTHNAME(20231201)", sqltypes.NewVarChar("December")},
+		// only the month needs to be non-zero
+		{"MONTHNAME('2023-05-00')", sqltypes.NewVarChar("May")},
+		{"DAYNAME('2023-05-00')", sqltypes.NULL},
+		{"MONTHNAME('2023-00-15')", sqltypes.NULL},
+		{"DAYNAME('0000-00-00')", sqltypes.NULL},
+		{"MONTHNAME('0000-00-00')", sqltypes.NULL},
+		{"DAYNAME('2023-02-30')", sqltypes.NULL},
+		{"MONTHNAME('not a date')", sqltypes.NULL},
+		{"DAYNAME(NULL)", sqltypes.NULL},
+		{"MONTHNAME(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestDayMonthNameLocale(t *testing.T) {
+	cases := []struct {
+		expression string
+		locale     string
+		expected   string
+	}{
+		{"DAYNAME('2023-06-15')", "", "Thursday"},
+		{"DAYNAME('2023-06-15')", "en_US", "Thursday"},
+		{"DAYNAME('2023-06-15')", "es_ES", "jueves"},
+		{"DAYNAME('2023-06-14')", "es_ES", "miércoles"},
+		{"DAYNAME('2023-06-15')", "de_DE", "Donnerstag"},
+		{"MONTHNAME('2023-03-15')", "", "March"},
+		{"MONTHNAME('2023-03-15')", "es_ES", "marzo"},
+		{"MONTHNAME('2023-03-15')", "de_DE", "März"},
+		// unsupported locales fall back to en_US
+		{"MONTHNAME('2023-03-15')", "xx_XX", "March"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression+"/"+tc.locale, func(t *testing.T) {
+			expr := translateForEnv(t, tc.expression)
+			require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.LcTimeNames = tc.locale
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, sqltypes.NewVarChar(tc.expected), res.Value())
+		})
+	}
+
+	t.Run("connection charset", func(t *testing.T) {
+		env := EnvWithBindVars(nil, collations.Local().LookupByName("latin1_swedish_ci").ID())
+		env.LcTimeNames = "de_DE"
+
+		res, err := env.Evaluate(translateForEnv(t, "MONTHNAME('2023-03-15')"))
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewVarChar("M\xe4rz"), res.Value())
+	})
+}
+
+func TestLastDay(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"LAST_DAY('2024-02-10')", dateValue("2024-02-29")},
+		{"LAST_DAY('2023-02-10')", dateValue("2023-02-28")},
+		{"LAST_DAY('2000-02-01')", dateValue("2000-02-29")},
+		{"LAST_DAY('1900-02-01')", dateValue("1900-02-28")},
+		{"LAST_DAY('2023-04-15')", dateValue("2023-04-30")},
+		{"LAST_DAY('2023-11-30')", dateValue("2023-11-30")},
+		{"LAST_DAY('2023-01-01')", dateValue("2023-01-31")},
+		{"LAST_DAY('2023-12-31 23:59:59')", dateValue("2023-12-31")},
+		{"LAST_DAY(TIMESTAMP '2023-06-15 10:00:00')", dateValue("2023-06-30")},
+		{"LAST_DAY(DATE '2023-07-04')", dateValue("2023-07-31")},
+		{"LAST_DAY(20230815)", dateValue("2023-08-31")},
+		{"LAST_DAY('2023-09-00')", dateValue("2023-09-30")},
+		{"LAST_DAY('2023-00-15')", sqltypes.NULL},
+		{"LAST_DAY('0000-00-00')", sqltypes.NULL},
+		{"LAST_DAY('2023-02-30')", sqltypes.NULL},
+		{"LAST_DAY('not a date')", sqltypes.NULL},
+		{"LAST_DAY(NULL)", sqltypes.NULL},
+	})
+}
+
+func TestDateMath(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"DATE_ADD('2023-01-01', INTERVAL 1 DAY)", sqltypes.NewVarChar("2023-01-02")},
+		{"DATE_ADD('2023-01-01', INTERVAL 1 day)", sqltypes.NewVarChar("2023-01-02")},
+		{"DATE_SUB('2023-01-01', INTERVAL 1 DAY)", sqltypes.NewVarChar("2022-12-31")},
+		{"DATE_ADD('2023-01-01', INTERVAL -1 DAY)", sqltypes.NewVarChar("2022-12-31")},
+		{"DATE_SUB('2023-01-01', INTERVAL 1 WEEK)", sqltypes.NewVarChar("2022-12-25")},
+		{"DATE_ADD('2023-01-01', INTERVAL 2 QUARTER)", sqltypes.NewVarChar("2023-07-01")},
+		{"'2023-01-01' + INTERVAL 1 DAY", sqltypes.NewVarChar("2023-01-02")},
+		{"INTERVAL 1 DAY + '2023-01-01'", sqltypes.NewVarChar("2023-01-02")},
+		{"'2023-01-01' - INTERVAL 1 DAY", sqltypes.NewVarChar("2022-12-31")},
+		{"ADDDATE('2023-01-01', 5)", sqltypes.NewVarChar("2023-01-06")},
+		{"SUBDATE('2023-01-01', 5)", sqltypes.NewVarChar("2022-12-27")},
+		{"ADDDATE('2023-01-01', INTERVAL 1 MONTH)", sqltypes.NewVarChar("2023-02-01")},
+		{"DATE_ADD(20230101, INTERVAL 1 DAY)", sqltypes.NewVarChar("2023-01-02")},
+		{"DATE_ADD(20230101100000, INTERVAL 1 DAY)", sqltypes.NewVarChar("2023-01-02 10:00:00")},
+
+		// adding months or years clamps to the end of the month
+		{"DATE_ADD('2023-01-31', INTERVAL 1 MONTH)", sqltypes.NewVarChar("2023-02-28")},
+		{"DATE_ADD('2024-01-31', INTERVAL 1 MONTH)", sqltypes.NewVarChar("2024-02-29")},
+		{"DATE_ADD(DATE '2023-01-31', INTERVAL 1 MONTH)", dateValue("2023-02-28")},
+		{"DATE_SUB('2023-03-31', INTERVAL 1 MONTH)", sqltypes.NewVarChar("2023-02-28")},
+		{"DATE_ADD('2023-08-31', INTERVAL 1 QUARTER)", sqltypes.NewVarChar("2023-11-30")},
+		{"DATE_ADD('2024-02-29', INTERVAL 1 YEAR)", sqltypes.NewVarChar("2025-02-28")},
+		{"DATE_ADD('2024-02-29', INTERVAL 4 YEAR)", sqltypes.NewVarChar("2028-02-29")},
+		{"DATE_ADD('2023-01-31 10:00:00', INTERVAL 1 MONTH)", sqltypes.NewVarChar("2023-02-28 10:00:00")},
+
+		// compound units are parsed from their string form
+		{"DATE_ADD('2023-01-31', INTERVAL '1-2' YEAR_MONTH)", sqltypes.NewVarChar("2024-03-31")},
+		{"DATE_SUB('2023-01-31', INTERVAL '1-2' YEAR_MONTH)", sqltypes.NewVarChar("2021-11-30")},
+		{"DATE_ADD('2023-12-31 23:59:59', INTERVAL '1 1:1:1' DAY_SECOND)", sqltypes.NewVarChar("2024-01-02 01:01:00")},
+		{"DATE_ADD('2023-01-01 00:00:00', INTERVAL '1:1' DAY_SECOND)", sqltypes.NewVarChar("2023-01-01 00:01:01")},
+		{"DATE_SUB('2023-01-01 00:00:00', INTERVAL '1 1' DAY_HOUR)", sqltypes.NewVarChar("2022-12-30 23:00:00")},
+		{"DATE_ADD('2023-01-01', INTERVAL '-1 10' DAY_HOUR)", sqltypes.NewVarChar("2022-12-30 14:00:00")},
+		{"DATE_ADD('2023-01-01', INTERVAL '1:30' HOUR_MINUTE)", sqltypes.NewVarChar("2023-01-01 01:30:00")},
+		{"DATE_ADD('2023-01-01', INTERVAL 1.30 HOUR_MINUTE)", sqltypes.NewVarChar("2023-01-01 01:30:00")},
+		{"DATE_ADD('2023-01-01', INTERVAL '2:3:4' HOUR_SECOND)", sqltypes.NewVarChar("2023-01-01 02:03:04")},
+		{"DATE_ADD('2023-01-01', INTERVAL '5:6' MINUTE_SECOND)", sqltypes.NewVarChar("2023-01-01 00:05:06")},
+		{"DATE_ADD('2023-01-01', INTERVAL '1.5' SECOND_MICROSECOND)", sqltypes.NewVarChar("2023-01-01 00:00:01.500000")},
+		{"DATE_ADD('2023-01-01', INTERVAL '1.000001' SECOND_MICROSECOND)", sqltypes.NewVarChar("2023-01-01 00:00:01.000001")},
+		{"DATE_ADD('2023-01-01', INTERVAL '1 2:3:4.5' DAY_MICROSECOND)", sqltypes.NewVarChar("2023-01-02 02:03:04.500000")},
+		{"DATE_ADD('2023-01-01', INTERVAL '1:2.25' MINUTE_MICROSECOND)", sqltypes.NewVarChar("2023-01-01 00:01:02.250000")},
+		{"DATE_ADD('2023-01-01', INTERVAL '1 2 3' DAY_HOUR)", sqltypes.NULL},
+
+		// adding time units to a DATE promotes it to a DATETIME
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL 1 DAY)", dateValue("2023-01-02")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL '1-1' YEAR_MONTH)", dateValue("2024-02-01")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL 1 HOUR)", datetimeValue("2023-01-01 01:00:00")},
+		{"DATE_SUB(DATE '2023-01-01', INTERVAL 1 SECOND)", datetimeValue("2022-12-31 23:59:59")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL '1 1' DAY_HOUR)", datetimeValue("2023-01-02 01:00:00")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL 1 MICROSECOND)", datetimeValue("2023-01-01 00:00:00.000001")},
+		{"DATE_ADD('2023-01-01', INTERVAL 1 HOUR)", sqltypes.NewVarChar("2023-01-01 01:00:00")},
+
+		{"DATE_ADD(TIMESTAMP '2023-01-01 10:00:00', INTERVAL 1 DAY)", datetimeValue("2023-01-02 10:00:00")},
+		{"DATE_ADD(TIMESTAMP '2023-01-01 10:00:00', INTERVAL 1.5 SECOND)", datetimeValue("2023-01-01 10:00:01.5")},
+		{"DATE_SUB(TIMESTAMP '2023-01-01 10:00:00', INTERVAL 1.5 SECOND)", datetimeValue("2023-01-01 09:59:58.5")},
+		{"DATE_ADD(TIMESTAMP '2023-01-01 10:00:00.12', INTERVAL 1 DAY)", datetimeValue("2023-01-02 10:00:00.12")},
+		{"DATE_ADD('2023-01-01 10:00:00.123', INTERVAL 1 DAY)", sqltypes.NewVarChar("2023-01-02 10:00:00.123000")},
+
+		{"DATE_ADD(TIME '10:00:00', INTERVAL 1 HOUR)", timeValue("11:00:00")},
+		{"DATE_ADD(TIME '23:00:00', INTERVAL 2 HOUR)", timeValue("25:00:00")},
+		{"DATE_SUB(TIME '01:00:00', INTERVAL 2 HOUR)", timeValue("-01:00:00")},
+		{"DATE_ADD(TIME '10:00:00', INTERVAL '1:30' MINUTE_SECOND)", timeValue("10:01:30")},
+		{"DATE_ADD(TIME '10:00:00', INTERVAL 1000 HOUR)", sqltypes.NULL},
+
+		// results out of range and invalid dates are NULL
+		{"DATE_ADD('9999-12-31', INTERVAL 1 DAY)", sqltypes.NULL},
+		{"DATE_ADD('9999-12-31 23:59:59', INTERVAL 1 SECOND)", sqltypes.NULL},
+		{"DATE_ADD('2023-01-01', INTERVAL 10000 YEAR)", sqltypes.NULL},
+		{"DATE_SUB('2023-01-01', INTERVAL 2024 YEAR)", sqltypes.NULL},
+		{"DATE_ADD('9999-12-01', INTERVAL 1 MONTH)", sqltypes.NULL},
+		{"DATE_ADD('2023-01-01', INTERVAL 9223372036854775807 SECOND)", sqltypes.NULL},
+		{"DATE_ADD('2023-01-01', INTERVAL 18446744073709551615 DAY)", sqltypes.NULL},
+		{"DATE_ADD('2023-02-30', INTERVAL 1 DAY)", sqltypes.NULL},
+		{"DATE_ADD('0000-00-00', INTERVAL 1 DAY)", sqltypes.NULL},
+		{"DATE_ADD('2023-00-01', INTERVAL 1 DAY)", sqltypes.NULL},
+		{"DATE_ADD('not a date', INTERVAL 1 DAY)", sqltypes.NULL},
+		{"DATE_ADD('2023-01-01', INTERVAL NULL DAY)", sqltypes.NULL},
+		{"DATE_ADD(NULL, INTERVAL 1 DAY)", sqltypes.NULL},
+	})
+}
+
+func TestDateMathTimeToDatetime(t *testing.T) {
+	expr := translateForEnv(t, "DATE_ADD(TIME '10:00:00', INTERVAL 1 DAY)")
+	require.False(t, expr.constant())
+
+	env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+	env.Tz = time.UTC
+	env.SetTime(time.Date(2023, 6, 15, 23, 0, 0, 0, time.UTC))
+
+	res, err := env.Evaluate(expr)
+	require.NoError(t, err)
+	require.Equal(t, sqltypes.MakeTrusted(sqltypes.Datetime, []byte("2023-06-16 10:00:00")), res.Value())
+}
+
+func TestDatePartsOfTime(t *testing.T) {
+	// TIME values are on the current date, so they have the same date parts
+	// as the datetime of that date
+	cases := []struct {
+		expression string
+		expected   string
+	}{
+		{"YEAR(TIME '10:20:30')", "YEAR(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"MONTH(TIME '10:20:30')", "MONTH(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"DAY(TIME '10:20:30')", "DAY(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"QUARTER(TIME '10:20:30')", "QUARTER(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"EXTRACT(YEAR_MONTH FROM TIME '10:20:30')", "EXTRACT(YEAR_MONTH FROM TIMESTAMP '2023-06-15 10:20:30')"},
+		{"DAYOFWEEK(TIME '10:20:30')", "DAYOFWEEK(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"WEEKDAY(TIME '10:20:30')", "WEEKDAY(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"DAYOFYEAR(TIME '10:20:30')", "DAYOFYEAR(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"DAYNAME(TIME '10:20:30')", "DAYNAME(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"MONTHNAME(TIME '10:20:30')", "MONTHNAME(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"DATE_FORMAT(TIME '10:20:30', '%Y-%m-%d %T')", "'2023-06-15 10:20:30'"},
+		{"WEEK(TIME '10:20:30')", "WEEK(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"WEEKOFYEAR(TIME '10:20:30')", "WEEKOFYEAR(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"TO_DAYS(TIME '10:20:30')", "TO_DAYS(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"TO_SECONDS(TIME '10:20:30')", "TO_SECONDS(TIMESTAMP '2023-06-15 10:20:30')"},
+		{"DATEDIFF(TIME '10:20:30', DATE '2023-06-01')", "14"},
+		{"LAST_DAY(TIME '10:20:30')", "DATE '2023-06-30'"},
+		// negative times and times of more than a day fall on other days
+		{"DAY(TIME '-01:00:00')", "14"},
+		{"DAY(CAST('25:00:00' AS TIME))", "16"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr := translateForEnv(t, tc.expression)
+			require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Tz = time.UTC
+			env.SetTime(time.Date(2023, 6, 15, 23, 0, 0, 0, time.UTC))
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			expected, err := env.Evaluate(translateForEnv(t, tc.expected))
+			require.NoError(t, err)
+			require.Equal(t, expected.Value(), res.Value())
+		})
+	}
+}
+
+func TestDateMathFractionalSecond(t *testing.T) {
+	// a DATE becomes a DATETIME with as many fractional digits as the interval
+	// has, up to 6; intervals that are not decimals always use 6 digits
+	testEvaluateCases(t, []evaluateCase{
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL 0.5 SECOND)", datetimeValue("2023-01-01 00:00:00.5")},
+		{"DATE_SUB(DATE '2023-01-01', INTERVAL 0.5 SECOND)", datetimeValue("2022-12-31 23:59:59.5")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL 1.250 SECOND)", datetimeValue("2023-01-01 00:00:01.250")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL 0.1234567 SECOND)", datetimeValue("2023-01-01 00:00:00.123456")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL '0.5' SECOND)", datetimeValue("2023-01-01 00:00:00.500000")},
+		{"DATE_ADD(DATE '2023-01-01', INTERVAL 0.5e0 SECOND)", datetimeValue("2023-01-01 00:00:00.500000")},
+		{"DATE '2023-01-01' + INTERVAL 0.5 SECOND", datetimeValue("2023-01-01 00:00:00.5")},
+		{"DATE_ADD('2023-01-01', INTERVAL 0.5 SECOND)", sqltypes.NewVarChar("2023-01-01 00:00:00.500000")},
+	})
+}
+
+func TestDateDiff(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"DATEDIFF('2023-01-10', '2023-01-01')", sqltypes.NewInt64(9)},
+		{"DATEDIFF('2023-01-01', '2023-01-10')", sqltypes.NewInt64(-9)},
+		{"DATEDIFF('2023-01-01', '2023-01-01')", sqltypes.NewInt64(0)},
+		{"DATEDIFF('2024-03-01', '2024-02-01')", sqltypes.NewInt64(29)},
+		{"DATEDIFF('2023-03-01', '2023-02-01')", sqltypes.NewInt64(28)},
+		{"DATEDIFF('2023-01-01', '2022-01-01')", sqltypes.NewInt64(365)},
+		{"DATEDIFF('0001-01-01', '0000-01-01')", sqltypes.NewInt64(365)},
+		{"DATEDIFF('9999-12-31', '0000-01-01')", sqltypes.NewInt64(3652423)},
+		// the time parts of the arguments are ignored
+		{"DATEDIFF('2023-01-10 23:59:59', '2023-01-01 00:00:00')", sqltypes.NewInt64(9)},
+		{"DATEDIFF('2023-01-10 00:00:00', '2023-01-01 23:59:59')", sqltypes.NewInt64(9)},
+		{"DATEDIFF(TIMESTAMP '2023-01-01 01:00:00', '2022-12-31 23:00:00')", sqltypes.NewInt64(1)},
+		{"DATEDIFF('2023-01-02 23:00:00', '2023-01-01 01:00:00')", sqltypes.NewInt64(1)},
+		{"DATEDIFF('2023-01-02 00:00:00.000001', '2023-01-01 23:59:59.999999')", sqltypes.NewInt64(1)},
+		{"DATEDIFF('2023-01-01 23:59:59', '2023-01-01 00:00:00')", sqltypes.NewInt64(0)},
+		{"DATEDIFF('2023-01-01 00:00:00', '2023-01-02 23:59:59')", sqltypes.NewInt64(-1)},
+		// strings and numbers are coerced to dates
+		{"DATEDIFF(DATE '2023-01-10', '2023-01-01')", sqltypes.NewInt64(9)},
+		{"DATEDIFF(20230110, '2023/1/1')", sqltypes.NewInt64(9)},
+		{"DATEDIFF('23-01-10', 20230101120000)", sqltypes.NewInt64(9)},
+		{"DATEDIFF('2023-02-30', '2023-01-01')", sqltypes.NULL},
+		{"DATEDIFF('2023-01-01', '0000-00-00')", sqltypes.NULL},
+		{"DATEDIFF('2023-00-01', '2023-01-01')", sqltypes.NULL},
+		{"DATEDIFF('not a date', '2023-01-01')", sqltypes.NULL},
+		{"DATEDIFF(NULL, '2023-01-01')", sqltypes.NULL},
+		{"DATEDIFF('2023-01-01', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestTimeDiff(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"TIMEDIFF('10:00:00', '08:30:00')", timeValue("01:30:00")},
+		{"TIMEDIFF('08:30:00', '10:00:00')", timeValue("-01:30:00")},
+		{"TIMEDIFF('-01:00:00', '01:00:00')", timeValue("-02:00:00")},
+		{"TIMEDIFF('-01:00:00', '-03:00:00')", timeValue("02:00:00")},
+		{"TIMEDIFF(TIME '10:00:00', TIME '10:00:00')", timeValue("00:00:00")},
+		{"TIMEDIFF('2023-01-02 10:00:00', '2023-01-01 08:00:00')", timeValue("26:00:00")},
+		{"TIMEDIFF('2023-01-01 08:00:00', '2023-01-02 10:00:00')", timeValue("-26:00:00")},
+		{"TIMEDIFF(TIMESTAMP '2023-03-01 00:00:00', '2023-02-28 23:59:59')", timeValue("00:00:01")},
+		{"TIMEDIFF(DATE '2023-01-02', DATE '2023-01-01')", timeValue("24:00:00")},
+		{"TIMEDIFF(DATE '2023-01-02', '2023-01-01')", timeValue("24:00:00")},
+		{"TIMEDIFF(100000, 83000)", timeValue("01:30:00")},
+		{"TIMEDIFF(20230102100000, '2023-01-01 10:00:00')", timeValue("24:00:00")},
+		// fractional seconds are kept, with the largest precision of both arguments
+		{"TIMEDIFF('10:00:00.5', '10:00:00')", timeValue("00:00:00.5")},
+		{"TIMEDIFF('10:00:00', '10:00:00.25')", timeValue("-00:00:00.25")},
+		{"TIMEDIFF('2000-01-01 00:00:00', '2000-01-01 00:00:00.000001')", timeValue("-00:00:00.000001")},
+		{"TIMEDIFF(TIMESTAMP '2023-01-01 00:00:01.123', TIMESTAMP '2023-01-01 00:00:00')", timeValue("00:00:01.123")},
+		{"TIMEDIFF(100000.5, 83000)", timeValue("01:30:00.5")},
+		// differences beyond the TIME range are clamped to it
+		{"TIMEDIFF('2023-01-01 00:00:00', '2000-01-01 00:00:00')", timeValue("838:59:59")},
+		{"TIMEDIFF('2000-01-01 00:00:00', '2023-01-01 00:00:00.5')", timeValue("-838:59:59.0")},
+		{"TIMEDIFF('838:59:59', '-838:59:59')", timeValue("838:59:59")},
+		// a time and a date cannot be compared
+		{"TIMEDIFF('2023-01-01 10:00:00', '10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF('10:00:00', '2023-01-01 10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF(TIME '10:00:00', TIMESTAMP '2023-01-01 10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF(DATE '2023-01-01', TIME '10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF(CAST('2023-01-05' AS DATE), '10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF(CAST('2023-01-05' AS DATE), TIME '10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF(DATE '2023-01-02', TIMESTAMP '2023-01-01 10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF(TIMESTAMP '2023-01-01 10:00:00', '2023-01-01')", sqltypes.NULL},
+		{"TIMEDIFF('foo', '10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF(NULL, '10:00:00')", sqltypes.NULL},
+		{"TIMEDIFF('10:00:00', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestStrToDate(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// formats with only date specifiers return a DATE
+		{"STR_TO_DATE('2023-06-15', '%Y-%m-%d')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('15/6/2023', '%d/%c/%Y')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('  2023-06-15', '%Y-%m-%d')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('June 15, 2023', '%M %d, %Y')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('jul 5 23', '%M %e %y')", dateValue("2023-07-05")},
+		{"STR_TO_DATE('Jun 5 99', '%b %e %y')", dateValue("1999-06-05")},
+		{"STR_TO_DATE('15th June 2023', '%D %M %Y')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('15.06.2023', '%d%.%m%.%Y')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('2023166', '%Y%j')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('202324 Thursday', '%X%V %W')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('2023 24 4', '%x %v %w')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('2023 24 Thu', '%Y %U %a')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('2023-06-15 garbage', '%Y-%m-%d')", dateValue("2023-06-15")},
+
+		// formats with date and time specifiers return a DATETIME
+		{"STR_TO_DATE('2023-06-15 03:30:45 PM', '%Y-%m-%d %h:%i:%s %p')", datetimeValue("2023-06-15 15:30:45")},
+		{"STR_TO_DATE('2023-06-15 12:00:00 AM', '%Y-%m-%d %h:%i:%s %p')", datetimeValue("2023-06-15 00:00:00")},
+		{"STR_TO_DATE('2023-06-15 12:30:00 pm', '%Y-%m-%d %I:%i:%S %p')", datetimeValue("2023-06-15 12:30:00")},
+		{"STR_TO_DATE('06/15/2023 9:05 AM', '%m/%d/%Y %l:%i %p')", datetimeValue("2023-06-15 09:05:00")},
+		{"STR_TO_DATE('2023-06-15 15:30:45', '%Y-%m-%d %T')", datetimeValue("2023-06-15 15:30:45")},
+		{"STR_TO_DATE('2023-06-15 15:30:45.5', '%Y-%m-%d %H:%i:%s.%f')", datetimeValue("2023-06-15 15:30:45.500000")},
+
+		// formats with only time specifiers return a TIME
+		{"STR_TO_DATE('03:30:45 PM', '%r')", timeValue("15:30:45")},
+		{"STR_TO_DATE('15:30:45', '%T')", timeValue("15:30:45")},
+		{"STR_TO_DATE('15:30:45.123', '%H:%i:%s.%f')", timeValue("15:30:45.123000")},
+		{"STR_TO_DATE('9', '%s')", timeValue("00:00:09")},
+
+		// values that do not match the format, or invalid dates, are NULL
+		{"STR_TO_DATE('2023/06/15', '%Y-%m-%d')", sqltypes.NULL},
+		{"STR_TO_DATE('foo', '%Y-%m-%d')", sqltypes.NULL},
+		{"STR_TO_DATE('Ju 15 2023', '%M %d %Y')", sqltypes.NULL},
+		{"STR_TO_DATE('2023-02-30', '%Y-%m-%d')", sqltypes.NULL},
+		{"STR_TO_DATE('2023-13-01', '%Y-%m-%d')", sqltypes.NULL},
+		{"STR_TO_DATE('2023-06', '%Y-%m-%d')", sqltypes.NULL},
+		{"STR_TO_DATE('00/00/0000', '%m/%d/%Y')", sqltypes.NULL},
+		{"STR_TO_DATE('2023-06-15 13:00:00 PM', '%Y-%m-%d %h:%i:%s %p')", sqltypes.NULL},
+		{"STR_TO_DATE('2023-06-15 10:00:00 PM', '%Y-%m-%d %H:%i:%s %p')", sqltypes.NULL},
+		{"STR_TO_DATE('25:00:00', '%H:%i:%s')", sqltypes.NULL},
+		{"STR_TO_DATE('2023 24 Thursday', '%X %U %W')", sqltypes.NULL},
+		{"STR_TO_DATE('2023-06-15', '%Y-%m-%d %Q')", dateValue("2023-06-15")},
+		{"STR_TO_DATE('2023-06-15 x', '%Y-%m-%d %Q')", sqltypes.NULL},
+		{"STR_TO_DATE('abc', 'abc')", sqltypes.NULL},
+		{"STR_TO_DATE(NULL, '%Y-%m-%d')", sqltypes.NULL},
+		{"STR_TO_DATE('2023-06-15', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestFromUnixtime(t *testing.T) {
+	utc := time.UTC
+	ist := time.FixedZone("", 5*3600+30*60)
+	pst := time.FixedZone("", -8*3600)
+
+	cases := []struct {
+		expression string
+		tz         *time.Location
+		expected   sqltypes.Value
+	}{
+		{"FROM_UNIXTIME(0)", utc, datetimeValue("1970-01-01 00:00:00")},
+		{"FROM_UNIXTIME(1686843045)", utc, datetimeValue("2023-06-15 15:30:45")},
+		{"FROM_UNIXTIME('1686843045')", utc, datetimeValue("2023-06-15 15:30:45.000000")},
+		{"FROM_UNIXTIME(32536771199)", utc, datetimeValue("3001-01-18 23:59:59")},
+
+		// fractional timestamps have fractional seconds
+		{"FROM_UNIXTIME(1686843045.5)", utc, datetimeValue("2023-06-15 15:30:45.5")},
+		{"FROM_UNIXTIME(1686843045.123)", utc, datetimeValue("2023-06-15 15:30:45.123")},
+		{"FROM_UNIXTIME(1686843045.12345678)", utc, datetimeValue("2023-06-15 15:30:45.123456")},
+		{"FROM_UNIXTIME(1686843045.25e0)", utc, datetimeValue("2023-06-15 15:30:45.250000")},
+
+		// the result is in the session's time zone
+		{"FROM_UNIXTIME(1686843045)", ist, datetimeValue("2023-06-15 21:00:45")},
+		{"FROM_UNIXTIME(1686843045)", pst, datetimeValue("2023-06-15 07:30:45")},
+		{"FROM_UNIXTIME(0)", pst, datetimeValue("1969-12-31 16:00:00")},
+
+		// with a format, the result is formatted like DATE_FORMAT
+		{"FROM_UNIXTIME(1686843045, '%Y-%m-%d %H:%i:%s')", utc, sqltypes.NewVarChar("2023-06-15 15:30:45")},
+		{"FROM_UNIXTIME(1686843045, '%W %M %D, %Y %r')", pst, sqltypes.NewVarChar("Thursday June 15th, 2023 07:30:45 AM")},
+		{"FROM_UNIXTIME(1686843045.5, '%s.%f')", utc, sqltypes.NewVarChar("45.500000")},
+		{"FROM_UNIXTIME(1686843045, NULL)", utc, sqltypes.NULL},
+
+		// negative and out of range timestamps are NULL
+		{"FROM_UNIXTIME(-1)", utc, sqltypes.NULL},
+		{"FROM_UNIXTIME(-0.5)", utc, sqltypes.NULL},
+		{"FROM_UNIXTIME(32536771200)", utc, sqltypes.NULL},
+		{"FROM_UNIXTIME(18446744073709551615)", utc, sqltypes.NULL},
+		{"FROM_UNIXTIME(-1, '%Y')", utc, sqltypes.NULL},
+		{"FROM_UNIXTIME(NULL)", utc, sqltypes.NULL},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr := translateForEnv(t, tc.expression)
+			require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Tz = tc.tz
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res.Value())
+		})
+	}
+}
+
+func TestUnixTimestamp(t *testing.T) {
+	now := time.Date(2023, 6, 15, 15, 30, 45, 900000000, time.UTC)
+	utc := time.UTC
+	ist := time.FixedZone("", 5*3600+30*60)
+	pst := time.FixedZone("", -8*3600)
+
+	cases := []struct {
+		expression string
+		tz         *time.Location
+		expected   sqltypes.Value
+	}{
+		{"UNIX_TIMESTAMP()", utc, sqltypes.NewInt64(1686843045)},
+		{"UNIX_TIMESTAMP()", pst, sqltypes.NewInt64(1686843045)},
+
+		{"UNIX_TIMESTAMP('2023-06-15 15:30:45')", utc, sqltypes.NewInt64(1686843045)},
+		{"UNIX_TIMESTAMP(DATE '2023-06-15')", utc, sqltypes.NewInt64(1686787200)},
+		{"UNIX_TIMESTAMP(20230615153045)", utc, sqltypes.NewInt64(1686843045)},
+		{"UNIX_TIMESTAMP(TIME '10:00:00')", utc, sqltypes.NewInt64(1686823200)},
+
+		// values with fractional seconds result in a decimal
+		{"UNIX_TIMESTAMP('2023-06-15 15:30:45.5')", utc, sqltypes.NewDecimal("1686843045.5")},
+		{"UNIX_TIMESTAMP('2023-06-15 15:30:45.123456')", utc, sqltypes.NewDecimal("1686843045.123456")},
+		{"UNIX_TIMESTAMP(TIMESTAMP '2023-06-15 15:30:45.25')", utc, sqltypes.NewDecimal("1686843045.25")},
+		{"UNIX_TIMESTAMP(20230615153045.5)", utc, sqltypes.NewDecimal("1686843045.5")},
+
+		// the argument is in the session's time zone
+		{"UNIX_TIMESTAMP('2023-06-15 15:30:45')", ist, sqltypes.NewInt64(1686823245)},
+		{"UNIX_TIMESTAMP('2023-06-15 15:30:45')", pst, sqltypes.NewInt64(1686871845)},
+		{"UNIX_TIMESTAMP('2023-06-15 15:30:45.5')", pst, sqltypes.NewDecimal("1686871845.5")},
+		{"UNIX_TIMESTAMP('1969-12-31 16:00:00')", pst, sqltypes.NewInt64(0)},
+		{"UNIX_TIMESTAMP('1970-01-01 00:00:00')", ist, sqltypes.NewInt64(0)},
+
+		// invalid and out of range values are 0
+		{"UNIX_TIMESTAMP('1969-12-31 23:59:59')", utc, sqltypes.NewInt64(0)},
+		{"UNIX_TIMESTAMP('1969-12-31 23:59:59.5')", utc, sqltypes.NewDecimal("0.0")},
+		{"UNIX_TIMESTAMP('3001-01-18 23:59:59')", utc, sqltypes.NewInt64(32536771199)},
+		{"UNIX_TIMESTAMP('3001-01-19 00:00:00')", utc, sqltypes.NewInt64(0)},
+		{"UNIX_TIMESTAMP('0000-00-00 00:00:00')", utc, sqltypes.NewInt64(0)},
+		{"UNIX_TIMESTAMP('2023-00-15')", utc, sqltypes.NewInt64(0)},
+		{"UNIX_TIMESTAMP('foo')", utc, sqltypes.NewInt64(0)},
+		{"UNIX_TIMESTAMP(NULL)", utc, sqltypes.NULL},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr := translateForEnv(t, tc.expression)
+			require.False(t, expr.constant(), "%s must not be folded into a constant", tc.expression)
+
+			env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+			env.Tz = tc.tz
+			env.SetTime(now)
+
+			res, err := env.Evaluate(expr)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, res.Value())
+		})
+	}
+}
+
+func TestUnixTimestampTypeOf(t *testing.T) {
+	// the type of a column decides the type of the result, so values without
+	// fractional seconds still result in a decimal
+	testColumnTypeOf(t, "UNIX_TIMESTAMP(column0)", []sqltypes.Value{
+		sqltypes.NewVarChar("2020-01-01 00:00:00"),
+		sqltypes.NewVarChar("2020-01-01 00:00:00.5"),
+		datetimeValue("2020-01-01 00:00:00"),
+		datetimeValue("2020-01-01 00:00:00.50"),
+		sqltypes.MakeTrusted(sqltypes.Date, []byte("2020-01-01")),
+		sqltypes.NewInt64(20200101000000),
+		sqltypes.NewDecimal("20200101000000.5"),
+		sqltypes.NewFloat64(20200101000000),
+	}, []sqltypes.Value{
+		sqltypes.NewDecimal("1577836800.000000"),
+		sqltypes.NewDecimal("1577836800.500000"),
+		sqltypes.NewDecimal("1577836800"),
+		sqltypes.NewDecimal("1577836800.50"),
+		sqltypes.NewInt64(1577836800),
+		sqltypes.NewInt64(1577836800),
+		sqltypes.NewDecimal("1577836800.5"),
+		sqltypes.NewDecimal("1577836800.000000"),
+	})
+}
+
+func TestMakeDate(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"MAKEDATE(2023, 1)", dateValue("2023-01-01")},
+		{"MAKEDATE(2023, 32)", dateValue("2023-02-01")},
+		{"MAKEDATE(2023, 365)", dateValue("2023-12-31")},
+		{"MAKEDATE(2024, 366)", dateValue("2024-12-31")},
+		{"MAKEDATE('2023', '60')", dateValue("2023-03-01")},
+
+		// days past the end of the year roll into the following years
+		{"MAKEDATE(2023, 366)", dateValue("2024-01-01")},
+		{"MAKEDATE(2023, 730)", dateValue("2024-12-30")},
+		{"MAKEDATE(2023, 1000)", dateValue("2025-09-26")},
+
+		// two-digit years
+		{"MAKEDATE(23, 1)", dateValue("2023-01-01")},
+		{"MAKEDATE(70, 1)", dateValue("1970-01-01")},
+		{"MAKEDATE(0, 1)", dateValue("2000-01-01")},
+
+		{"MAKEDATE(9999, 365)", dateValue("9999-12-31")},
+		{"MAKEDATE(9999, 366)", sqltypes.NULL},
+		{"MAKEDATE(10000, 1)", sqltypes.NULL},
+		{"MAKEDATE(-1, 1)", sqltypes.NULL},
+		{"MAKEDATE(2023, 0)", sqltypes.NULL},
+		{"MAKEDATE(2023, -1)", sqltypes.NULL},
+		{"MAKEDATE(NULL, 1)", sqltypes.NULL},
+		{"MAKEDATE(2023, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestMakeTime(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"MAKETIME(12, 15, 30)", timeValue("12:15:30")},
+		{"MAKETIME(0, 0, 0)", timeValue("00:00:00")},
+		{"MAKETIME(-1, 30, 0)", timeValue("-01:30:00")},
+
+		// hours beyond 23 are allowed, up to the TIME range
+		{"MAKETIME(100, 0, 0)", timeValue("100:00:00")},
+		{"MAKETIME(838, 59, 59)", timeValue("838:59:59")},
+		{"MAKETIME(839, 0, 0)", timeValue("838:59:59")},
+		{"MAKETIME(-900, 0, 0)", timeValue("-838:59:59")},
+		{"MAKETIME(18446744073709551615, 0, 0)", timeValue("838:59:59")},
+
+		// fractional seconds are kept
+		{"MAKETIME(12, 15, 30.5)", timeValue("12:15:30.5")},
+		{"MAKETIME(12, 15, 30.250)", timeValue("12:15:30.250")},
+		{"MAKETIME(12, 15, 30.1234567)", timeValue("12:15:30.123457")},
+		{"MAKETIME(12, 15, '30.5')", timeValue("12:15:30.500000")},
+		{"MAKETIME(838, 59, 59.5)", timeValue("838:59:59.0")},
+
+		{"MAKETIME(12, 60, 0)", sqltypes.NULL},
+		{"MAKETIME(12, -1, 0)", sqltypes.NULL},
+		{"MAKETIME(12, 0, 60)", sqltypes.NULL},
+		{"MAKETIME(12, 0, 59.9999999)", timeValue("12:01:00.000000")},
+		{"MAKETIME(12, 0, -1)", sqltypes.NULL},
+		{"MAKETIME(NULL, 0, 0)", sqltypes.NULL},
+		{"MAKETIME(0, NULL, 0)", sqltypes.NULL},
+		{"MAKETIME(0, 0, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestCastTemporal(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CAST('2023-01-01' AS DATE)", dateValue("2023-01-01")},
+		{"CAST('2023-01-01 12:34:56' AS DATE)", dateValue("2023-01-01")},
+		{"CAST(20230101 AS DATE)", dateValue("2023-01-01")},
+		{"CAST(TIMESTAMP '2023-01-01 12:34:56' AS DATE)", dateValue("2023-01-01")},
+		{"CAST('not a date' AS DATE)", sqltypes.NULL},
+		{"CAST(NULL AS DATE)", sqltypes.NULL},
+
+		{"CAST('2023-01-01' AS DATETIME)", datetimeValue("2023-01-01 00:00:00")},
+		{"CAST('2023-01-01 12:34:56.789' AS DATETIME)", datetimeValue("2023-01-01 12:34:57")},
+		{"CAST('2023-01-01 12:34:56.789' AS DATETIME(2))", datetimeValue("2023-01-01 12:34:56.79")},
+		{"CAST('2023-01-01 23:59:59.5' AS DATETIME)", datetimeValue("2023-01-02 00:00:00")},
+		{"CAST(DATE '2023-01-01' AS DATETIME(3))", datetimeValue("2023-01-01 00:00:00.000")},
+		{"CAST('not a date' AS DATETIME)", sqltypes.NULL},
+	})
+}
+
+func TestTimeFormat(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"TIME_FORMAT('10:20:30', '%H')", sqltypes.NewVarChar("10")},
+		{"TIME_FORMAT('10:20:30', '%H:%i:%s')", sqltypes.NewVarChar("10:20:30")},
+		{"TIME_FORMAT('10:20:30.25', '%T.%f')", sqltypes.NewVarChar("10:20:30.250000")},
+		{"TIME_FORMAT(102030, '%h %p')", sqltypes.NewVarChar("10 AM")},
+		{"TIME_FORMAT(CAST('22:00:00' AS TIME), '%r')", sqltypes.NewVarChar("10:00:00 PM")},
+		{"TIME_FORMAT('2023-01-01 10:20:30', '%T')", sqltypes.NewVarChar("10:20:30")},
+
+		// arguments that are not a valid TIME result in NULL
+		{"TIME_FORMAT('2023-01-01', '%H')", sqltypes.NULL},
+		{"TIME_FORMAT('not a time', '%H')", sqltypes.NULL},
+		{"TIME_FORMAT('', '%H')", sqltypes.NULL},
+		{"TIME_FORMAT(NULL, '%H')", sqltypes.NULL},
+		{"TIME_FORMAT('10:20:30', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestTimeFormatSpecifiers(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// hours are not limited to a day
+		{"TIME_FORMAT('838:59:59', '%H')", sqltypes.NewVarChar("838")},
+		{"TIME_FORMAT('838:59:59', '%H:%i:%s')", sqltypes.NewVarChar("838:59:59")},
+		{"TIME_FORMAT('838:59:59', '%T')", sqltypes.NewVarChar("838:59:59")},
+		{"TIME_FORMAT('100:00:00', '%k %h %I %l %p')", sqltypes.NewVarChar("100 04 04 4 AM")},
+		{"TIME_FORMAT('-10:20:30', '%H:%i:%s')", sqltypes.NewVarChar("-10:20:30")},
+		{"TIME_FORMAT('-838:59:59', '%H')", sqltypes.NewVarChar("-838")},
+		{"TIME_FORMAT('00:00:00', '%r')", sqltypes.NewVarChar("12:00:00 AM")},
+		{"TIME_FORMAT('12:30:00', '%r')", sqltypes.NewVarChar("12:30:00 PM")},
+		{"TIME_FORMAT('10:20:30.123456', '%f')", sqltypes.NewVarChar("123456")},
+		{"TIME_FORMAT('10:20:30', '%S %s')", sqltypes.NewVarChar("30 30")},
+		{"TIME_FORMAT('10:20:30', 'at %H%%')", sqltypes.NewVarChar("at 10%")},
+		{"TIME_FORMAT('10:20:30', '%q')", sqltypes.NewVarChar("q")},
+
+		// the specifiers for the parts of a date result in NULL
+		{"TIME_FORMAT('10:20:30', '%Y')", sqltypes.NULL},
+		{"TIME_FORMAT('10:20:30', '%H %d')", sqltypes.NULL},
+		{"TIME_FORMAT('2023-01-01 10:20:30', '%M')", sqltypes.NULL},
+		{"TIME_FORMAT('838:59:59', '%W')", sqltypes.NULL},
+	})
+}
+
+func TestTimestampAdd(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"TIMESTAMPADD(MICROSECOND, 1, TIMESTAMP '2023-01-01 00:00:00')", datetimeValue("2023-01-01 00:00:00.000001")},
+		{"TIMESTAMPADD(MICROSECOND, -1, TIMESTAMP '2023-01-01 00:00:00')", datetimeValue("2022-12-31 23:59:59.999999")},
+		{"TIMESTAMPADD(MICROSECOND, 1500000, TIMESTAMP '2023-01-01 00:00:00.25')", datetimeValue("2023-01-01 00:00:01.750000")},
+		{"TIMESTAMPADD(MICROSECOND, 1, '2023-01-01')", sqltypes.NewVarChar("2023-01-01 00:00:00.000001")},
+		{"TIMESTAMPADD(SECOND, 30, TIMESTAMP '2023-01-01 23:59:45')", datetimeValue("2023-01-02 00:00:15")},
+		{"TIMESTAMPADD(MINUTE, 1, '2023-01-01 10:20:30')", sqltypes.NewVarChar("2023-01-01 10:21:30")},
+		{"TIMESTAMPADD(hour, -2, TIMESTAMP '2023-01-01 01:00:00')", datetimeValue("2022-12-31 23:00:00")},
+		{"TIMESTAMPADD(DAY, 1, DATE '2023-01-31')", dateValue("2023-02-01")},
+		{"TIMESTAMPADD(WEEK, 1, DATE '2023-01-31')", dateValue("2023-02-07")},
+		{"TIMESTAMPADD(MONTH, 1, DATE '2023-01-31')", dateValue("2023-02-28")},
+		{"TIMESTAMPADD(QUARTER, 1, DATE '2023-01-31')", dateValue("2023-04-30")},
+		{"TIMESTAMPADD(YEAR, 1, DATE '2024-02-29')", dateValue("2025-02-28")},
+		{"TIMESTAMPADD(DAY, 1, 'not a date')", sqltypes.NULL},
+		{"TIMESTAMPADD(DAY, NULL, DATE '2023-01-01')", sqltypes.NULL},
+		{"TIMESTAMPADD(DAY, 1, NULL)", sqltypes.NULL},
+	})
+}
+
+func TestToDaysFromDays(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"TO_DAYS('2007-10-07')", sqltypes.NewInt64(733321)},
+		{"TO_DAYS(950501)", sqltypes.NewInt64(728779)},
+		{"TO_DAYS('2007-10-07 23:59:59')", sqltypes.NewInt64(733321)},
+		{"TO_DAYS('0000-01-01')", sqltypes.NewInt64(1)},
+		{"TO_DAYS('0001-01-01')", sqltypes.NewInt64(366)},
+		{"TO_DAYS('9999-12-31')", sqltypes.NewInt64(3652424)},
+		{"TO_DAYS('0000-00-00')", sqltypes.NULL},
+		{"TO_DAYS('2023-00-15')", sqltypes.NULL},
+		{"TO_DAYS('not a date')", sqltypes.NULL},
+		{"TO_DAYS(NULL)", sqltypes.NULL},
+
+		{"FROM_DAYS(730669)", dateValue("2000-07-03")},
+		{"FROM_DAYS(733321)", dateValue("2007-10-07")},
+		{"FROM_DAYS(366)", dateValue("0001-01-01")},
+		{"FROM_DAYS(3652424)", dateValue("9999-12-31")},
+		{"FROM_DAYS('730669')", dateValue("2000-07-03")},
+		// like in MySQL, the days in the year 0 are not converted
+		{"FROM_DAYS(1)", dateValue("0000-00-00")},
+		{"FROM_DAYS(365)", dateValue("0000-00-00")},
+		{"FROM_DAYS(0)", dateValue("0000-00-00")},
+		{"FROM_DAYS(-1)", dateValue("0000-00-00")},
+		{"FROM_DAYS(3652425)", sqltypes.NULL},
+		{"FROM_DAYS(3652500)", dateValue("0000-00-00")},
+		{"FROM_DAYS(NULL)", sqltypes.NULL},
+
+		{"FROM_DAYS(TO_DAYS('2023-06-15'))", dateValue("2023-06-15")},
+		{"FROM_DAYS(TO_DAYS('2024-02-29 10:20:30'))", dateValue("2024-02-29")},
+		{"FROM_DAYS(TO_DAYS('0001-01-01'))", dateValue("0001-01-01")},
+		{"FROM_DAYS(TO_DAYS('0000-12-31'))", dateValue("0000-00-00")},
+		{"TO_DAYS(FROM_DAYS(738000))", sqltypes.NewInt64(738000)},
+
+		{"TO_SECONDS(950501)", sqltypes.NewInt64(62966505600)},
+		{"TO_SECONDS('2009-11-29')", sqltypes.NewInt64(63426672000)},
+		{"TO_SECONDS('2009-11-29 13:43:32')", sqltypes.NewInt64(63426721412)},
+		{"TO_SECONDS('2009-11-29 13:43:32.999999')", sqltypes.NewInt64(63426721412)},
+		{"TO_SECONDS('0000-01-01')", sqltypes.NewInt64(86400)},
+		{"TO_SECONDS('0000-00-00 10:00:00')", sqltypes.NULL},
+		{"TO_SECONDS(NULL)", sqltypes.NULL},
+	})
+
+	for start := 366; start < datetime.MaxDayNumber; start += 997 {
+		expr := translateForEnv(t, fmt.Sprintf("TO_DAYS(FROM_DAYS(%d))", start))
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, sqltypes.NewInt64(int64(start)), res.Value(), "day number %d", start)
+	}
+}
+
+func TestTimestampDiff(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		// partial months are truncated
+		{"TIMESTAMPDIFF(MONTH, '2023-01-31', '2023-02-28')", sqltypes.NewInt64(0)},
+		{"TIMESTAMPDIFF(MONTH, '2023-01-15', '2023-02-15')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(MONTH, '2023-01-15 10:00:00', '2023-02-15 09:59:59')", sqltypes.NewInt64(0)},
+		{"TIMESTAMPDIFF(MONTH, '2023-01-15 10:00:00', '2023-02-15 10:00:00')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(MONTH, '2023-01-15 10:00:00', '2023-02-15 10:00:00.000001')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(MONTH, '2023-02-15', '2023-01-16')", sqltypes.NewInt64(0)},
+		{"TIMESTAMPDIFF(MONTH, '2023-02-15', '2023-01-15')", sqltypes.NewInt64(-1)},
+		{"TIMESTAMPDIFF(MONTH, '2020-01-01', '2023-06-30')", sqltypes.NewInt64(41)},
+		{"TIMESTAMPDIFF(QUARTER, '2023-01-01', '2023-06-30')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(QUARTER, '2023-01-01', '2023-07-01')", sqltypes.NewInt64(2)},
+		{"TIMESTAMPDIFF(YEAR, '2020-02-29', '2021-02-28')", sqltypes.NewInt64(0)},
+		{"TIMESTAMPDIFF(YEAR, '2020-02-29', '2021-03-01')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(YEAR, '2023-06-15', '2000-06-16')", sqltypes.NewInt64(-22)},
+
+		{"TIMESTAMPDIFF(WEEK, '2023-01-01', '2023-01-14')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(DAY, '2023-01-01 12:00:00', '2023-01-02 11:59:59')", sqltypes.NewInt64(0)},
+		{"TIMESTAMPDIFF(DAY, '2023-01-02 11:59:59', '2023-01-01 12:00:00')", sqltypes.NewInt64(0)},
+		{"TIMESTAMPDIFF(DAY, '2023-01-02', '2023-01-01')", sqltypes.NewInt64(-1)},
+		{"TIMESTAMPDIFF(HOUR, '2023-01-01 00:00:00', '2023-01-01 01:59:59')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(MINUTE, '2023-01-01 00:00:00', '2023-01-01 00:01:59.999999')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(SECOND, '2023-01-01 00:00:00.5', '2023-01-01 00:00:01')", sqltypes.NewInt64(0)},
+		{"TIMESTAMPDIFF(SECOND, '2023-01-01', '2023-01-02')", sqltypes.NewInt64(86400)},
+		{"TIMESTAMPDIFF(MICROSECOND, '2023-01-01 00:00:00', '2023-01-01 00:00:00.000001')", sqltypes.NewInt64(1)},
+		{"TIMESTAMPDIFF(MICROSECOND, '2023-01-01 00:00:01', '2023-01-01 00:00:00.25')", sqltypes.NewInt64(-750000)},
+		{"TIMESTAMPDIFF(MICROSECOND, '0001-01-01', '9999-12-31 23:59:59.999999')", sqltypes.NewInt64(315537897599999999)},
+		{"TIMESTAMPDIFF(DAY, DATE '2023-01-01', TIMESTAMP '2023-03-01 00:00:00')", sqltypes.NewInt64(59)},
+		{"TIMESTAMPDIFF(DAY, 20230101, 20230201)", sqltypes.NewInt64(31)},
+
+		{"TIMESTAMPDIFF(DAY, '0000-00-00', '2023-01-01')", sqltypes.NULL},
+		{"TIMESTAMPDIFF(DAY, 'not a date', '2023-01-01')", sqltypes.NULL},
+		{"TIMESTAMPDIFF(DAY, NULL, '2023-01-01')", sqltypes.NULL},
+		{"TIMESTAMPDIFF(DAY, '2023-01-01', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestConvertTz(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', '+10:00')", datetimeValue("2023-01-01 22:00:00")},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+05:30', '-03:00')", datetimeValue("2023-01-01 03:30:00")},
+		{"CONVERT_TZ('2023-01-01 00:00:00', '+00:00', '-13:59')", datetimeValue("2022-12-31 10:01:00")},
+		{"CONVERT_TZ('2023-01-01 00:00:00', '+00:00', '+14:00')", datetimeValue("2023-01-01 14:00:00")},
+		{"CONVERT_TZ('2023-01-01 12:00:00.123', '+00:00', '+01:00')", datetimeValue("2023-01-01 13:00:00.123")},
+		{"CONVERT_TZ(TIMESTAMP '2023-01-01 12:00:00', '+00:00', '+01:00')", datetimeValue("2023-01-01 13:00:00")},
+		{"CONVERT_TZ(DATE '2023-01-01', '+00:00', '+01:00')", datetimeValue("2023-01-01 01:00:00")},
+
+		// named zones, before and after the DST change in Europe on 2023-03-26
+		{"CONVERT_TZ('2023-03-26 00:30:00', 'UTC', 'Europe/Amsterdam')", datetimeValue("2023-03-26 01:30:00")},
+		{"CONVERT_TZ('2023-03-26 01:30:00', 'UTC', 'Europe/Amsterdam')", datetimeValue("2023-03-26 03:30:00")},
+		{"CONVERT_TZ('2023-03-26 03:30:00', 'Europe/Amsterdam', 'UTC')", datetimeValue("2023-03-26 01:30:00")},
+		{"CONVERT_TZ('2023-07-01 12:00:00', 'America/New_York', '+00:00')", datetimeValue("2023-07-01 16:00:00")},
+		{"CONVERT_TZ('2023-01-01 12:00:00', 'America/New_York', '+00:00')", datetimeValue("2023-01-01 17:00:00")},
+
+		// datetimes outside the range of timestamps are not converted
+		{"CONVERT_TZ('1969-12-31 23:00:00', '+00:00', '+01:00')", datetimeValue("1969-12-31 23:00:00")},
+		{"CONVERT_TZ('3001-01-19 00:00:00', '+00:00', '+01:00')", datetimeValue("3001-01-19 00:00:00")},
+
+		{"CONVERT_TZ('2023-01-01 12:00:00', 'Not/AZone', '+00:00')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', 'Not/AZone')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', '+14:01')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', '-14:00')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', '+01:60')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', '01:00')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', '')", sqltypes.NULL},
+		{"CONVERT_TZ('not a date', '+00:00', '+01:00')", sqltypes.NULL},
+		{"CONVERT_TZ('0000-00-00 00:00:00', '+00:00', '+01:00')", sqltypes.NULL},
+		{"CONVERT_TZ(NULL, '+00:00', '+01:00')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', NULL, '+01:00')", sqltypes.NULL},
+		{"CONVERT_TZ('2023-01-01 12:00:00', '+00:00', NULL)", sqltypes.NULL},
+	})
+}
+
+func TestExtract(t *testing.T) {
+	testEvaluateCases(t, []evaluateCase{
+		{"EXTRACT(YEAR FROM '2023-06-15 10:20:30')", sqltypes.NewInt64(2023)},
+		{"EXTRACT(QUARTER FROM '2023-06-15')", sqltypes.NewInt64(2)},
+		{"EXTRACT(MONTH FROM DATE '2023-06-15')", sqltypes.NewInt64(6)},
+		{"EXTRACT(DAY FROM 20230615)", sqltypes.NewInt64(15)},
+		{"EXTRACT(HOUR FROM '2023-06-15 10:20:30')", sqltypes.NewInt64(10)},
+		{"EXTRACT(MINUTE FROM '10:20:30')", sqltypes.NewInt64(20)},
+		{"EXTRACT(SECOND FROM TIMESTAMP '2023-06-15 10:20:30')", sqltypes.NewInt64(30)},
+		{"EXTRACT(MICROSECOND FROM '10:20:30.25')", sqltypes.NewInt64(250000)},
+
+		// compound units concatenate their components
+		{"EXTRACT(YEAR_MONTH FROM '2023-06-15 10:20:30')", sqltypes.NewInt64(202306)},
+		{"EXTRACT(YEAR_MONTH FROM DATE '0001-01-01')", sqltypes.NewInt64(101)},
+		{"EXTRACT(DAY_HOUR FROM '2023-06-15 10:20:30')", sqltypes.NewInt64(1510)},
+		{"EXTRACT(DAY_MINUTE FROM '2019-07-02 01:02:03')", sqltypes.NewInt64(20102)},
+		{"EXTRACT(DAY_SECOND FROM '2023-06-15 10:20:30')", sqltypes.NewInt64(15102030)},
+		{"EXTRACT(DAY_MICROSECOND FROM '2023-06-15 10:20:30.123456')", sqltypes.NewInt64(15102030123456)},
+		{"EXTRACT(DAY_MICROSECOND FROM TIMESTAMP '2023-06-05 01:02:03.5')", sqltypes.NewInt64(5010203500000)},
+		{"EXTRACT(DAY_HOUR FROM '2023-06-15')", sqltypes.NewInt64(1500)},
+		{"EXTRACT(DAY_HOUR FROM 20230615102030)", sqltypes.NewInt64(1510)},
+		{"EXTRACT(HOUR_MINUTE FROM '10:20:30')", sqltypes.NewInt64(1020)},
+		{"EXTRACT(HOUR_SECOND FROM 102030)", sqltypes.NewInt64(102030)},
+		{"EXTRACT(MINUTE_SECOND FROM '10:20:30')", sqltypes.NewInt64(2030)},
+		{"EXTRACT(HOUR_MICROSECOND FROM '10:20:30.000001')", sqltypes.NewInt64(102030000001)},
+		{"EXTRACT(MINUTE_MICROSECOND FROM '10:20:30.5')", sqltypes.NewInt64(2030500000)},
+		{"EXTRACT(SECOND_MICROSECOND FROM '10:20:30.5')", sqltypes.NewInt64(30500000)},
+
+		// TIME values have no day, and their sign applies to the result
+		{"EXTRACT(DAY_HOUR FROM CAST('100:20:30' AS TIME))", sqltypes.NewInt64(100)},
+		{"EXTRACT(HOUR FROM '-10:20:30')", sqltypes.NewInt64(-10)},
+		{"EXTRACT(HOUR_SECOND FROM '-10:20:30')", sqltypes.NewInt64(-102030)},
+
+		{"EXTRACT(YEAR FROM 'not a date')", sqltypes.NULL},
+		{"EXTRACT(YEAR_MONTH FROM '2023-13-01')", sqltypes.NULL},
+		{"EXTRACT(DAY_MICROSECOND FROM 'not a date')", sqltypes.NULL},
+		{"EXTRACT(HOUR FROM '10:60:00')", sqltypes.NULL},
+		{"EXTRACT(YEAR FROM NULL)", sqltypes.NULL},
+		{"EXTRACT(DAY_SECOND FROM NULL)", sqltypes.NULL},
+	})
+}
+
+func TestSetSystemVariable(t *testing.T) {
+	env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+	env.SetSystemVariable("time_zone", "'+01:00'")
+	env.SetSystemVariable("LC_TIME_NAMES", "'es_ES'")
+	env.SetSystemVariable("max_allowed_packet", "1024")
+	// unknown variables and values that aren't literals are ignored
+	env.SetSystemVariable("sql_mode", "'ANSI'")
+	env.SetSystemVariable("time_zone", "@@global.time_zone")
+
+	res, err := env.Evaluate(translateForEnv(t, "FROM_UNIXTIME(0)"))
+	require.NoError(t, err)
+	require.Equal(t, "1970-01-01 01:00:00", res.Value().ToString())
+
+	require.Equal(t, "es_ES", env.LcTimeNames)
+	require.Equal(t, int64(1024), env.MaxAllowedPacket)
+}
@@ -22,6 +22,7 @@ import (
 
 	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/vt/sqlparser"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/datetime"
 )
 
 func FormatExpr(expr Expr) string {
@@ -170,6 +171,86 @@ func (c *builtinWeightString) format(w *formatter, depth int) {
 	w.WriteByte(')')
 }
 
+func (c *builtinNow) format(w *formatter, depth int) {
+	formatFsp(w, c.Method, c.prec)
+}
+
+func (c *builtinCurtime) format(w *formatter, depth int) {
+	formatFsp(w, c.Method, c.prec)
+}
+
+func (c *builtinSysdate) format(w *formatter, depth int) {
+	formatFsp(w, c.Method, c.prec)
+}
+
+func (c *builtinDateMath) format(w *formatter, depth int) {
+	if c.Method == "TIMESTAMPADD" {
+		formatTimestampFunc(w, c.Method, c.unit, c.Arguments[1], c.Arguments[0], depth)
+		return
+	}
+	w.WriteString(c.Method)
+	w.WriteByte('(')
+	c.Arguments[0].format(w, depth+1)
+	w.WriteString(", INTERVAL ")
+	c.Arguments[1].format(w, depth+1)
+	w.WriteByte(' ')
+	w.WriteString(c.unit.String())
+	w.WriteByte(')')
+}
+
+func (c *builtinTimestampDiff) format(w *formatter, depth int) {
+	formatTimestampFunc(w, c.Method, c.unit, c.Arguments[0], c.Arguments[1], depth)
+}
+
+func (c *builtinExtract) format(w *formatter, depth int) {
+	w.WriteString("EXTRACT(")
+	w.WriteString(c.unit.String())
+	w.WriteString(" FROM ")
+	c.Arguments[0].format(w, depth+1)
+	w.WriteByte(')')
+}
+
+func formatTimestampFunc(w *formatter, method string, unit datetime.IntervalType, expr1, expr2 Expr, depth int) {
+	w.WriteString(method)
+	w.WriteByte('(')
+	w.WriteString(unit.String())
+	w.WriteString(", ")
+	expr1.format(w, depth+1)
+	w.WriteString(", ")
+	expr2.format(w, depth+1)
+	w.WriteByte(')')
+}
+
+func formatFsp(w *formatter, method string, prec uint8) {
+	w.WriteString(strings.ToUpper(method))
+	if prec > 0 {
+		fmt.Fprintf(w, "(%d)", prec)
+	} else {
+		w.WriteString("()")
+	}
+}
+
+func (c *builtinTrim) format(w *formatter, depth int) {
+	if c.Method != "TRIM" {
+		c.CallExpr.format(w, depth)
+		return
+	}
+	w.WriteString("TRIM(")
+	if c.trim != sqlparser.NoTrimType {
+		w.WriteString(strings.ToUpper(c.trim.ToString()))
+		w.WriteByte(' ')
+	}
+	if len(c.Arguments) > 1 {
+		c.Arguments[1].format(w, depth)
+		w.WriteByte(' ')
+	}
+	if c.trim != sqlparser.NoTrimType || len(c.Arguments) > 1 {
+		w.WriteString("FROM ")
+	}
+	c.Arguments[0].format(w, depth)
+	w.WriteByte(')')
+}
+
 func (n *NegateExpr) format(w *formatter, depth int) {
 	w.WriteByte('-')
 	n.Inner.format(w, depth)
@@ -0,0 +1,350 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"time"
+)
+
+// Date is a calendar date as understood by MySQL. Unlike time.Time, it can
+// represent the zero date ('0000-00-00') and dates with zero month or day
+// components, all of which MySQL accepts as valid values.
+type Date struct {
+	year  uint16
+	month uint8
+	day   uint8
+}
+
+// Time is a MySQL TIME value: a signed time of day or elapsed time, which
+// can hold more than 24 hours. The sign is stored in the high bit of hour.
+type Time struct {
+	hour       uint16
+	minute     uint8
+	second     uint8
+	nanosecond uint32
+}
+
+// DateTime is a MySQL DATETIME value, composed of a Date and a
+// (non-negative, less than 24h) Time.
+type DateTime struct {
+	Date Date
+	Time Time
+}
+
+const negMask = uint16(1 << 15)
+
+// DefaultPrecision is the maximum number of fractional second digits
+// supported by MySQL temporal types.
+const DefaultPrecision = 6
+
+func NewDate(year, month, day int) Date {
+	return Date{year: uint16(year), month: uint8(month), day: uint8(day)}
+}
+
+func NewTime(neg bool, hour, minute, second, nanosecond int) Time {
+	t := Time{hour: uint16(hour), minute: uint8(minute), second: uint8(second), nanosecond: uint32(nanosecond)}
+	if neg {
+		t.hour |= negMask
+	}
+	return t
+}
+
+func NewDateTime(date Date, time Time) DateTime {
+	return DateTime{Date: date, Time: time}
+}
+
+// NewDateTimeFromStd returns the DateTime for the wall clock of the given time.Time,
+// in the time.Time's location.
+func NewDateTimeFromStd(t time.Time) DateTime {
+	year, month, day := t.Date()
+	return DateTime{
+		Date: NewDate(year, int(month), day),
+		Time: NewTime(false, t.Hour(), t.Minute(), t.Second(), t.Nanosecond()),
+	}
+}
+
+func (d Date) Year() int {
+	return int(d.year)
+}
+
+func (d Date) Month() int {
+	return int(d.month)
+}
+
+func (d Date) Day() int {
+	return int(d.day)
+}
+
+// IsZero returns whether this is the zero date '0000-00-00'.
+func (d Date) IsZero() bool {
+	return d.year == 0 && d.month == 0 && d.day == 0
+}
+
+// Weekday returns the day of the week of this date. It is only meaningful
+// for dates with non-zero month and day components.
+func (d Date) Weekday() time.Weekday {
+	return time.Weekday(calcWeekday(d.DayNumber(), true))
+}
+
+// calcWeekday is a port of MySQL's calc_weekday. It returns the day of the
+// week of the given day number, starting at 0 for Sunday or Monday.
+func calcWeekday(daynr int, sundayFirst bool) int {
+	daynr += 5
+	if sundayFirst {
+		daynr++
+	}
+	return daynr % 7
+}
+
+// Yearday returns the day of the year of this date, in the range [1, 366].
+func (d Date) Yearday() int {
+	return d.ToStdTime(time.UTC).YearDay()
+}
+
+// DayNumber returns the number of days since year 0 of this date, as
+// computed by MySQL's calc_daynr. Unlike the Go calendar, MySQL does not
+// consider year 0 a leap year.
+func (d Date) DayNumber() int {
+	year, month, day := d.Year(), d.Month(), d.Day()
+	if year == 0 && month == 0 {
+		return 0
+	}
+
+	daynr := 365*year + 31*(month-1) + day
+	if month <= 2 {
+		year--
+	} else {
+		daynr -= (month*4 + 23) / 10
+	}
+	return daynr + year/4 - ((year/100+1)*3)/4
+}
+
+// MaxDayNumber is the day number of '9999-12-31', the last supported date.
+const MaxDayNumber = 3652424
+
+var daysInMonth = [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// DateFromDayNumber returns the date with the given number of days since
+// year 0, as computed by MySQL's get_date_from_daynr. It is the inverse of
+// DayNumber, and it returns the zero date for day numbers in the year 0 or
+// past the year 9999.
+func DateFromDayNumber(daynr int) Date {
+	if daynr <= 365 || daynr >= 3652500 {
+		return Date{}
+	}
+
+	year := daynr * 100 / 36525
+	dayOfYear := daynr - year*365 - (year-1)/4 + (((year-1)/100+1)*3)/4
+	for dayOfYear > daysInYear(year) {
+		dayOfYear -= daysInYear(year)
+		year++
+	}
+
+	var leapDay int
+	if daysInYear(year) == 366 && dayOfYear > 31+28 {
+		dayOfYear--
+		if dayOfYear == 31+28 {
+			leapDay = 1
+		}
+	}
+
+	month := 1
+	for dayOfYear > daysInMonth[month-1] {
+		dayOfYear -= daysInMonth[month-1]
+		month++
+	}
+	return NewDate(year, month, dayOfYear+leapDay)
+}
+
+// MakeDate returns the date that is the given day of the year, like MySQL's
+// MAKEDATE. Years below 100 are two-digit years, and days past the end of the
+// year roll over into the following years. The boolean result is false if the
+// year or the day are out of range.
+func MakeDate(year, yearday int64) (Date, bool) {
+	if year < 0 || year > 9999 || yearday <= 0 || yearday > MaxDayNumber {
+		return Date{}, false
+	}
+	daynr := NewDate(year2000(int(year)), 1, 1).DayNumber() + int(yearday) - 1
+	if daynr > MaxDayNumber {
+		return Date{}, false
+	}
+	return DateFromDayNumber(daynr), true
+}
+
+const (
+	weekMondayFirst = 1 << iota
+	weekYear
+	weekFirstWeekday
+)
+
+// weekMode converts the mode argument of MySQL's WEEK function into the
+// flags used by calcWeek.
+func weekMode(mode int) int {
+	behaviour := mode & 7
+	if behaviour&weekMondayFirst == 0 {
+		behaviour ^= weekFirstWeekday
+	}
+	return behaviour
+}
+
+// calcWeek is a port of MySQL's calc_week. It returns the year that the
+// week of this date belongs to, together with the week number.
+func (d Date) calcWeek(behaviour int) (int, int) {
+	mondayFirst := behaviour&weekMondayFirst != 0
+	weekYear := behaviour&weekYear != 0
+	firstWeekday := behaviour&weekFirstWeekday != 0
+
+	year := d.Year()
+	daynr := d.DayNumber()
+	firstDaynr := NewDate(year, 1, 1).DayNumber()
+	weekday := calcWeekday(firstDaynr, !mondayFirst)
+
+	if d.Month() == 1 && d.Day() <= 7-weekday {
+		if !weekYear && ((firstWeekday && weekday != 0) || (!firstWeekday && weekday >= 4)) {
+			return year, 0
+		}
+		weekYear = true
+		year--
+		days := daysInYear(year)
+		firstDaynr -= days
+		weekday = (weekday + 53*7 - days) % 7
+	}
+
+	var days int
+	if (firstWeekday && weekday != 0) || (!firstWeekday && weekday >= 4) {
+		days = daynr - (firstDaynr + (7 - weekday))
+	} else {
+		days = daynr - (firstDaynr - weekday)
+	}
+
+	if weekYear && days >= 52*7 {
+		weekday = (weekday + daysInYear(year)) % 7
+		if (!firstWeekday && weekday < 4) || (firstWeekday && weekday == 0) {
+			return year + 1, 1
+		}
+	}
+	return year, days/7 + 1
+}
+
+// Week returns the week number of this date, following the semantics of
+// MySQL's WEEK function for the given mode. It is only meaningful for dates
+// with non-zero month and day components.
+func (d Date) Week(mode int) int {
+	_, week := d.calcWeek(weekMode(mode))
+	return week
+}
+
+// ToStdTime returns the time.Time at midnight of this date in the given location.
+func (d Date) ToStdTime(loc *time.Location) time.Time {
+	return time.Date(d.Year(), time.Month(d.Month()), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// Hour returns the absolute value of the hour component of this time,
+// which can be larger than 23.
+func (t Time) Hour() int {
+	return int(t.hour &^ negMask)
+}
+
+func (t Time) Minute() int {
+	return int(t.minute)
+}
+
+func (t Time) Second() int {
+	return int(t.second)
+}
+
+func (t Time) Nanosecond() int {
+	return int(t.nanosecond)
+}
+
+// Neg returns whether this time is negative.
+func (t Time) Neg() bool {
+	return t.hour&negMask != 0
+}
+
+func (t Time) IsZero() bool {
+	return t.Hour() == 0 && t.minute == 0 && t.second == 0 && t.nanosecond == 0
+}
+
+// ToDuration returns the signed duration represented by this time.
+func (t Time) ToDuration() time.Duration {
+	dur := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.minute)*time.Minute +
+		time.Duration(t.second)*time.Second +
+		time.Duration(t.nanosecond)
+	if t.Neg() {
+		return -dur
+	}
+	return dur
+}
+
+// Round returns this time rounded to the given number of fractional second
+// digits. The rounding can carry over to the other components of the time,
+// and the result is clamped to the TIME range.
+func (t Time) Round(prec int) Time {
+	unit := time.Second
+	for i := 0; i < prec; i++ {
+		unit /= 10
+	}
+	if t.Nanosecond()%int(unit) == 0 {
+		return t
+	}
+
+	dur := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.minute)*time.Minute +
+		time.Duration(t.second)*time.Second +
+		time.Duration(t.nanosecond)
+	dur = dur.Round(unit)
+
+	hour := int(dur / time.Hour)
+	if hour > MaxHours {
+		return NewTime(t.Neg(), MaxHours, 59, 59, 0)
+	}
+	return NewTime(t.Neg(), hour, int(dur/time.Minute%60), int(dur/time.Second%60), int(dur%time.Second))
+}
+
+func (dt DateTime) IsZero() bool {
+	return dt.Date.IsZero() && dt.Time.IsZero()
+}
+
+// Round returns this datetime rounded to the given number of fractional
+// second digits. The rounding can carry over to the date; if the date has
+// zero components or is the last supported date, the fraction is truncated
+// instead.
+func (dt DateTime) Round(prec int) DateTime {
+	t := dt.Time.Round(prec)
+	if t.Hour() < 24 {
+		return DateTime{Date: dt.Date, Time: t}
+	}
+
+	daynr := dt.Date.DayNumber() + 1
+	if dt.Date.Month() == 0 || dt.Date.Day() == 0 || daynr > MaxDayNumber {
+		unit := int(time.Second)
+		for i := 0; i < prec; i++ {
+			unit /= 10
+		}
+		nsec := dt.Time.Nanosecond()
+		return DateTime{Date: dt.Date, Time: NewTime(false, 23, 59, 59, nsec-nsec%unit)}
+	}
+	return DateTime{Date: DateFromDayNumber(daynr)}
+}
+
+// ToStdTime returns the time.Time for this datetime in the given location.
+func (dt DateTime) ToStdTime(loc *time.Location) time.Time {
+	return time.Date(dt.Date.Year(), time.Month(dt.Date.Month()), dt.Date.Day(),
+		dt.Time.Hour(), dt.Time.Minute(), dt.Time.Second(), dt.Time.Nanosecond(), loc)
+}
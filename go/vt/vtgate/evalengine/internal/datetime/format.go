@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+// appendInt appends the decimal representation of n to b, left-padded
+// with zeroes to at least width digits.
+func appendInt(b []byte, n int, width int) []byte {
+	var buf [20]byte
+	i := len(buf)
+	for n >= 10 {
+		i--
+		buf[i] = byte('0' + n%10)
+		n /= 10
+	}
+	i--
+	buf[i] = byte('0' + n)
+	for w := len(buf) - i; w < width; w++ {
+		b = append(b, '0')
+	}
+	return append(b, buf[i:]...)
+}
+
+// appendFrac appends the fractional seconds of nsec with the given
+// number of digits, including the leading dot. Nothing is appended
+// when prec is 0.
+func appendFrac(b []byte, nsec int, prec uint8) []byte {
+	if prec == 0 {
+		return b
+	}
+	b = append(b, '.')
+	for i := uint8(0); i < prec; i++ {
+		nsec %= 1000000000
+		nsec *= 10
+		b = append(b, byte('0'+nsec/1000000000))
+	}
+	return b
+}
+
+// AppendFormat appends the canonical 'YYYY-MM-DD' representation of this date.
+func (d Date) AppendFormat(b []byte) []byte {
+	b = appendInt(b, d.Year(), 4)
+	b = append(b, '-')
+	b = appendInt(b, d.Month(), 2)
+	b = append(b, '-')
+	return appendInt(b, d.Day(), 2)
+}
+
+func (d Date) Format() []byte {
+	return d.AppendFormat(make([]byte, 0, 10))
+}
+
+// AppendFormat appends the canonical '[-]hh:mm:ss[.fraction]' representation
+// of this time, with prec fractional digits.
+func (t Time) AppendFormat(b []byte, prec uint8) []byte {
+	if t.Neg() {
+		b = append(b, '-')
+	}
+	b = appendInt(b, t.Hour(), 2)
+	b = append(b, ':')
+	b = appendInt(b, t.Minute(), 2)
+	b = append(b, ':')
+	b = appendInt(b, t.Second(), 2)
+	return appendFrac(b, t.Nanosecond(), prec)
+}
+
+func (t Time) Format(prec uint8) []byte {
+	return t.AppendFormat(make([]byte, 0, 16), prec)
+}
+
+// AppendFormat appends the canonical 'YYYY-MM-DD hh:mm:ss[.fraction]'
+// representation of this datetime, with prec fractional digits.
+func (dt DateTime) AppendFormat(b []byte, prec uint8) []byte {
+	b = dt.Date.AppendFormat(b)
+	b = append(b, ' ')
+	return dt.Time.AppendFormat(b, prec)
+}
+
+func (dt DateTime) Format(prec uint8) []byte {
+	return dt.AppendFormat(make([]byte, 0, 26), prec)
+}
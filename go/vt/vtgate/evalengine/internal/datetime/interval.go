@@ -0,0 +1,357 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// IntervalType is the unit of an INTERVAL expression, such as DAY or DAY_SECOND.
+type IntervalType uint8
+
+// The interval types are declared in the same order as in MySQL, which
+// decides the type of the result of date arithmetic based on their ranges.
+const (
+	IntervalYear IntervalType = iota
+	IntervalQuarter
+	IntervalMonth
+	IntervalWeek
+	IntervalDay
+	IntervalHour
+	IntervalMinute
+	IntervalSecond
+	IntervalMicrosecond
+	IntervalYearMonth
+	IntervalDayHour
+	IntervalDayMinute
+	IntervalDaySecond
+	IntervalHourMinute
+	IntervalHourSecond
+	IntervalMinuteSecond
+	IntervalDayMicrosecond
+	IntervalHourMicrosecond
+	IntervalMinuteMicrosecond
+	IntervalSecondMicrosecond
+)
+
+var intervalNames = [...]string{
+	"YEAR", "QUARTER", "MONTH", "WEEK", "DAY", "HOUR", "MINUTE", "SECOND", "MICROSECOND",
+	"YEAR_MONTH", "DAY_HOUR", "DAY_MINUTE", "DAY_SECOND", "HOUR_MINUTE", "HOUR_SECOND",
+	"MINUTE_SECOND", "DAY_MICROSECOND", "HOUR_MICROSECOND", "MINUTE_MICROSECOND",
+	"SECOND_MICROSECOND",
+}
+
+// ParseIntervalType returns the interval type with the given name, which
+// is matched case-insensitively.
+func ParseIntervalType(name string) (IntervalType, bool) {
+	for i, n := range intervalNames {
+		if strings.EqualFold(n, name) {
+			return IntervalType(i), true
+		}
+	}
+	return 0, false
+}
+
+func (unit IntervalType) String() string {
+	return intervalNames[unit]
+}
+
+// IsCompound returns whether the interval type has more than one component,
+// like DAY_SECOND. The values of compound intervals are parsed from strings.
+func (unit IntervalType) IsCompound() bool {
+	return unit >= IntervalYearMonth
+}
+
+// HasTimePart returns whether the interval type has any component smaller
+// than a day.
+func (unit IntervalType) HasTimePart() bool {
+	return unit > IntervalDay && unit != IntervalYearMonth
+}
+
+// HasMicrosecond returns whether the interval type has a microsecond component.
+func (unit IntervalType) HasMicrosecond() bool {
+	return unit == IntervalMicrosecond || unit >= IntervalDayMicrosecond
+}
+
+// Interval is the value of an INTERVAL expression, with each of its
+// components stored as an absolute value.
+type Interval struct {
+	unit IntervalType
+	neg  bool
+
+	year, month, day, hour, minute, second, usec int64
+}
+
+// maxIntervalValue bounds the components of an interval, so that date
+// arithmetic cannot overflow. Larger values are out of range for any date.
+const maxIntervalValue = 1 << 58
+
+func clampInterval(n int64) int64 {
+	if n > maxIntervalValue {
+		return maxIntervalValue
+	}
+	return n
+}
+
+// NewInterval returns an interval of n units of the given type, which must
+// not be compound.
+func NewInterval(unit IntervalType, n int64) Interval {
+	itv := Interval{unit: unit}
+	if n < 0 {
+		itv.neg = true
+		n = -n
+		if n < 0 {
+			n = math.MaxInt64
+		}
+	}
+	n = clampInterval(n)
+
+	switch unit {
+	case IntervalYear:
+		itv.year = n
+	case IntervalQuarter:
+		itv.month = n * 3
+	case IntervalMonth:
+		itv.month = n
+	case IntervalWeek:
+		itv.day = n * 7
+	case IntervalDay:
+		itv.day = n
+	case IntervalHour:
+		itv.hour = n
+	case IntervalMinute:
+		itv.minute = n
+	case IntervalSecond:
+		itv.second = n
+	case IntervalMicrosecond:
+		itv.usec = n
+	}
+	return itv
+}
+
+// NewIntervalSecond returns an interval of the given number of seconds and
+// microseconds, for SECOND intervals with a fractional value.
+func NewIntervalSecond(neg bool, sec, usec int64) Interval {
+	return Interval{
+		unit:   IntervalSecond,
+		neg:    neg,
+		second: clampInterval(sec),
+		usec:   usec,
+	}
+}
+
+// fields returns the components of the interval in the order in which they
+// are written in the string form of its type.
+func (itv *Interval) fields() []*int64 {
+	switch itv.unit {
+	case IntervalYearMonth:
+		return []*int64{&itv.year, &itv.month}
+	case IntervalDayHour:
+		return []*int64{&itv.day, &itv.hour}
+	case IntervalDayMinute:
+		return []*int64{&itv.day, &itv.hour, &itv.minute}
+	case IntervalDaySecond:
+		return []*int64{&itv.day, &itv.hour, &itv.minute, &itv.second}
+	case IntervalHourMinute:
+		return []*int64{&itv.hour, &itv.minute}
+	case IntervalHourSecond:
+		return []*int64{&itv.hour, &itv.minute, &itv.second}
+	case IntervalMinuteSecond:
+		return []*int64{&itv.minute, &itv.second}
+	case IntervalDayMicrosecond:
+		return []*int64{&itv.day, &itv.hour, &itv.minute, &itv.second, &itv.usec}
+	case IntervalHourMicrosecond:
+		return []*int64{&itv.hour, &itv.minute, &itv.second, &itv.usec}
+	case IntervalMinuteMicrosecond:
+		return []*int64{&itv.minute, &itv.second, &itv.usec}
+	case IntervalSecondMicrosecond:
+		return []*int64{&itv.second, &itv.usec}
+	default:
+		return nil
+	}
+}
+
+// ParseInterval parses the string form of an interval of a compound type,
+// like '1 10:30:00' for DAY_SECOND, following the rules of MySQL's
+// get_interval_info: components can be separated by any non-digit characters,
+// and when there are fewer components than the type has, they are assigned to
+// its smallest units. A trailing microsecond component with fewer than 6 digits
+// is a fraction of a second. The boolean result is false if the string has
+// more components than the type.
+func ParseInterval(s string, unit IntervalType) (Interval, bool) {
+	itv := Interval{unit: unit}
+	fields := itv.fields()
+	if fields == nil {
+		return itv, false
+	}
+
+	s = strings.TrimLeft(s, " \t\n\v\f\r")
+	if len(s) > 0 && s[0] == '-' {
+		itv.neg = true
+		s = s[1:]
+	}
+	for len(s) > 0 && !isDigit(s, 0) {
+		s = s[1:]
+	}
+
+	values := make([]int64, len(fields))
+	var fracDigits int
+	for i := 0; i < len(values); i++ {
+		var v int64
+		n := 0
+		for ; n < len(s) && isDigit(s, n); n++ {
+			v = clampInterval(v*10 + int64(s[n]-'0'))
+		}
+		fracDigits = n
+		values[i] = v
+
+		s = s[n:]
+		for len(s) > 0 && !isDigit(s, 0) {
+			s = s[1:]
+		}
+		if len(s) == 0 && i != len(values)-1 {
+			// align the components that we have with the smallest units
+			i++
+			copy(values[len(values)-i:], values[:i])
+			for j := 0; j < len(values)-i; j++ {
+				values[j] = 0
+			}
+			break
+		}
+	}
+
+	if unit.HasMicrosecond() {
+		for ; fracDigits < 6; fracDigits++ {
+			values[len(values)-1] *= 10
+		}
+	}
+	for i, f := range fields {
+		*f = values[i]
+	}
+	return itv, len(s) == 0
+}
+
+// Negate returns the interval with the opposite sign.
+func (itv Interval) Negate() Interval {
+	itv.neg = !itv.neg
+	return itv
+}
+
+const (
+	// maxIntervalDays is larger than the number of days between any two dates
+	maxIntervalDays = 3652500
+	usecPerDay      = 24 * 3600 * 1000000
+	maxTimeUsec     = ((MaxHours*60+59)*60+59)*1000000 + 999999
+)
+
+// microseconds returns the total length of the components of the interval
+// that are not years or months. The boolean result is false if the interval
+// is longer than the distance between any two dates.
+func (itv Interval) microseconds() (int64, bool) {
+	if itv.day > maxIntervalDays || itv.hour > maxIntervalDays*24 ||
+		itv.minute > maxIntervalDays*24*60 || itv.second > maxIntervalDays*24*3600 ||
+		itv.usec > maxIntervalDays*usecPerDay {
+		return 0, false
+	}
+	usec := (((itv.day*24+itv.hour)*60+itv.minute)*60+itv.second)*1000000 + itv.usec
+	if itv.neg {
+		usec = -usec
+	}
+	return usec, true
+}
+
+// AddInterval adds the given interval to the datetime, following the rules of
+// MySQL's date_add_interval: adding months or years to a date that does not
+// exist in the resulting month clamps it to the last day of that month. The
+// boolean result is false if the result is out of the DATETIME range.
+func (dt DateTime) AddInterval(itv Interval) (DateTime, bool) {
+	sign := int64(1)
+	if itv.neg {
+		sign = -1
+	}
+
+	switch itv.unit {
+	case IntervalYear:
+		year := int64(dt.Date.Year()) + sign*itv.year
+		if year < 0 || year > 9999 {
+			return dt, false
+		}
+		day := dt.Date.Day()
+		if dt.Date.Month() == 2 && day == 29 && daysInYear(int(year)) != 366 {
+			day = 28
+		}
+		dt.Date = NewDate(int(year), dt.Date.Month(), day)
+		return dt, true
+
+	case IntervalQuarter, IntervalMonth, IntervalYearMonth:
+		period := int64(dt.Date.Year())*12 + int64(dt.Date.Month()-1) + sign*(itv.year*12+itv.month)
+		if period < 0 || period >= 120000 {
+			return dt, false
+		}
+		year, month := int(period/12), int(period%12)+1
+		day := dt.Date.Day()
+		if last := DaysIn(month, year); day > last {
+			day = last
+		}
+		dt.Date = NewDate(year, month, day)
+		return dt, true
+
+	default:
+		usec, ok := itv.microseconds()
+		if !ok {
+			return dt, false
+		}
+		days, usec := usec/usecPerDay, usec%usecPerDay
+		t := time.Date(dt.Date.Year(), time.Month(dt.Date.Month()), dt.Date.Day()+int(days),
+			dt.Time.Hour(), dt.Time.Minute(), dt.Time.Second(), dt.Time.Nanosecond()+int(usec)*1000, time.UTC)
+		if t.Year() < 0 || t.Year() > 9999 {
+			return dt, false
+		}
+		return NewDateTimeFromStd(t), true
+	}
+}
+
+// AddInterval adds the given interval to the time. Only intervals without
+// years or months can be added to a time. The boolean result is false if the
+// result is out of the TIME range.
+func (t Time) AddInterval(itv Interval) (Time, bool) {
+	if itv.year != 0 || itv.month != 0 {
+		return t, false
+	}
+	delta, ok := itv.microseconds()
+	if !ok {
+		return t, false
+	}
+
+	usec := ((int64(t.Hour())*60+int64(t.Minute()))*60+int64(t.Second()))*1000000 + int64(t.Nanosecond()/1000)
+	if t.Neg() {
+		usec = -usec
+	}
+	usec += delta
+
+	neg := usec < 0
+	if neg {
+		usec = -usec
+	}
+	if usec > maxTimeUsec {
+		return t, false
+	}
+	sec := usec / 1000000
+	return NewTime(neg, int(sec/3600), int(sec/60%60), int(sec%60), int(usec%1000000)*1000), true
+}
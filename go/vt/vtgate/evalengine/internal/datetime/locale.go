@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import "time"
+
+// Locale contains the names of days and months in a language, like
+// the locales that can be selected with MySQL's lc_time_names.
+type Locale struct {
+	Name         string
+	Days         [7]string
+	Months       [12]string
+	AbbrevDays   [7]string
+	AbbrevMonths [12]string
+}
+
+// LocaleEnUS is the default locale, used when lc_time_names is not set.
+var LocaleEnUS = &Locale{
+	Name:   "en_US",
+	Days:   [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	Months: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+
+	AbbrevDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	AbbrevMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+}
+
+// LocaleDeDE is the locale for German (Germany).
+var LocaleDeDE = &Locale{
+	Name:   "de_DE",
+	Days:   [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+
+	AbbrevDays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	AbbrevMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+}
+
+// LocaleEsES is the locale for Spanish (Spain).
+var LocaleEsES = &Locale{
+	Name:   "es_ES",
+	Days:   [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	Months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+
+	AbbrevDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	AbbrevMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+}
+
+var locales = map[string]*Locale{
+	LocaleEnUS.Name: LocaleEnUS,
+	LocaleDeDE.Name: LocaleDeDE,
+	LocaleEsES.Name: LocaleEsES,
+}
+
+// LookupLocale returns the locale with the given name, or nil if
+// the locale is not supported.
+func LookupLocale(name string) *Locale {
+	return locales[name]
+}
+
+// DayName returns the name of the given day of the week.
+func (l *Locale) DayName(wd time.Weekday) string {
+	return l.Days[wd]
+}
+
+// MonthName returns the name of the given month, which must be in
+// the range [1, 12].
+func (l *Locale) MonthName(month int) string {
+	return l.Months[month-1]
+}
+
+// AbbrevDayName returns the abbreviated name of the given day of the week.
+func (l *Locale) AbbrevDayName(wd time.Weekday) string {
+	return l.AbbrevDays[wd]
+}
+
+// AbbrevMonthName returns the abbreviated name of the given month, which
+// must be in the range [1, 12].
+func (l *Locale) AbbrevMonthName(month int) string {
+	return l.AbbrevMonths[month-1]
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"strings"
+)
+
+// MaxHours is the largest number of hours that can be stored in a MySQL TIME value.
+const MaxHours = 838
+
+// yyPartYear is the pivot year for two-digit years: years below it are
+// in the 21st century, years at or above it in the 20th.
+const yyPartYear = 70
+
+func isDigit(s string, i int) bool {
+	return i < len(s) && s[i] >= '0' && s[i] <= '9'
+}
+
+func isPunct(c byte) bool {
+	return (c >= '!' && c <= '/') || (c >= ':' && c <= '@') || (c >= '[' && c <= '`') || (c >= '{' && c <= '~')
+}
+
+// countDigits returns the number of consecutive ASCII digits at the start of s.
+func countDigits(s string) int {
+	n := 0
+	for isDigit(s, n) {
+		n++
+	}
+	return n
+}
+
+// parseDigits parses the first n characters of s, which must all be digits.
+func parseDigits(s string, n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		v = v*10 + int(s[i]-'0')
+	}
+	return v
+}
+
+// parseComponent parses between 1 and max digits at the start of s,
+// returning the parsed value and the remainder of the string.
+func parseComponent(s string, max int) (int, string, bool) {
+	n := countDigits(s)
+	if n == 0 || n > max {
+		return 0, s, false
+	}
+	return parseDigits(s, n), s[n:], true
+}
+
+// parseFraction parses the digits of a fractional second at the start
+// of s, returning it as nanoseconds along with the number of digits seen
+// (capped to DefaultPrecision) and the remainder of the string.
+func parseFraction(s string) (nsec int, prec int, rest string) {
+	n := countDigits(s)
+	for i := 0; i < 9; i++ {
+		nsec *= 10
+		if i < n {
+			nsec += int(s[i] - '0')
+		}
+	}
+	prec = n
+	if prec > DefaultPrecision {
+		prec = DefaultPrecision
+	}
+	return nsec, prec, s[n:]
+}
+
+func adjustYear(year, digits int) int {
+	if digits <= 2 {
+		if year < yyPartYear {
+			return year + 2000
+		}
+		return year + 1900
+	}
+	return year
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysInYear returns the number of days in the given year. Like MySQL, it
+// does not consider the year 0 to be a leap year.
+func daysInYear(year int) int {
+	if year != 0 && isLeap(year) {
+		return 366
+	}
+	return 365
+}
+
+// DaysIn returns the number of days in the given month of the given year.
+func DaysIn(month, year int) int {
+	switch month {
+	case 2:
+		if isLeap(year) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	default:
+		return 31
+	}
+}
+
+func validDate(year, month, day int) bool {
+	if year > 9999 || month > 12 || day > 31 {
+		return false
+	}
+	if month > 0 && day > 0 {
+		return day <= DaysIn(month, year)
+	}
+	return true
+}
+
+func validTimeOfDay(hour, minute, second int) bool {
+	return hour < 24 && minute < 60 && second < 60
+}
+
+// parseTimeOfDay parses the 'hh[:mm[:ss[.fraction]]]' time component of
+// a datetime string.
+func parseTimeOfDay(s string) (t Time, prec int, rest string, ok bool) {
+	var hour, minute, second, nsec int
+
+	hour, s, ok = parseComponent(s, 2)
+	if !ok {
+		return
+	}
+	if len(s) > 1 && isPunct(s[0]) && isDigit(s, 1) {
+		minute, s, ok = parseComponent(s[1:], 2)
+		if !ok {
+			return
+		}
+		if len(s) > 1 && isPunct(s[0]) && isDigit(s, 1) {
+			second, s, ok = parseComponent(s[1:], 2)
+			if !ok {
+				return
+			}
+			if len(s) > 0 && s[0] == '.' {
+				nsec, prec, s = parseFraction(s[1:])
+			}
+		}
+	}
+	if !validTimeOfDay(hour, minute, second) {
+		return t, 0, s, false
+	}
+	return NewTime(false, hour, minute, second, nsec), prec, s, true
+}
+
+// parseCompactDateTime parses a datetime without delimiters, in one of the
+// 'YYYYMMDD', 'YYMMDD', 'YYYYMMDDhhmmss' or 'YYMMDDhhmmss' forms, optionally
+// followed by a fraction.
+func parseCompactDateTime(s string, n int) (dt DateTime, prec int, hasTime bool, ok bool) {
+	var year, month, day, hour, minute, second, nsec int
+	var yearDigits int
+
+	switch n {
+	case 6, 12:
+		yearDigits = 2
+	case 8, 14:
+		yearDigits = 4
+	default:
+		return
+	}
+
+	year = adjustYear(parseDigits(s, yearDigits), yearDigits)
+	s = s[yearDigits:]
+	month = parseDigits(s, 2)
+	day = parseDigits(s[2:], 2)
+	s = s[4:]
+
+	if len(s) >= 6 {
+		hasTime = true
+		hour = parseDigits(s, 2)
+		minute = parseDigits(s[2:], 2)
+		second = parseDigits(s[4:], 2)
+		s = s[6:]
+	}
+	if len(s) > 0 {
+		if s[0] != '.' || !hasTime {
+			return
+		}
+		nsec, prec, _ = parseFraction(s[1:])
+	}
+	if !validDate(year, month, day) || !validTimeOfDay(hour, minute, second) {
+		return
+	}
+	return NewDateTime(NewDate(year, month, day), NewTime(false, hour, minute, second, nsec)), prec, hasTime, true
+}
+
+// parseDateTime parses a date or datetime string, also reporting whether
+// the string contained a time component.
+func parseDateTime(s string) (dt DateTime, prec int, hasTime bool, ok bool) {
+	s = strings.TrimSpace(s)
+
+	if n := countDigits(s); n >= 6 && (n == len(s) || s[n] == '.') {
+		return parseCompactDateTime(s, n)
+	}
+
+	var year, month, day int
+	yearDigits := countDigits(s)
+	year, s, ok = parseComponent(s, 4)
+	if !ok || len(s) < 2 || !isPunct(s[0]) {
+		return dt, 0, false, false
+	}
+	year = adjustYear(year, yearDigits)
+	month, s, ok = parseComponent(s[1:], 2)
+	if !ok || len(s) < 2 || !isPunct(s[0]) {
+		return dt, 0, false, false
+	}
+	day, s, ok = parseComponent(s[1:], 2)
+	if !ok || !validDate(year, month, day) {
+		return dt, 0, false, false
+	}
+	dt.Date = NewDate(year, month, day)
+
+	if len(s) == 0 {
+		return dt, 0, false, true
+	}
+	switch s[0] {
+	case 'T':
+		s = s[1:]
+	case ' ':
+		s = strings.TrimLeft(s, " ")
+	default:
+		return dt, 0, false, false
+	}
+
+	dt.Time, prec, s, ok = parseTimeOfDay(s)
+	if !ok || len(s) > 0 {
+		return dt, 0, false, false
+	}
+	return dt, prec, true, true
+}
+
+// ParseDateTime parses a DATETIME value from a string in any of the formats
+// accepted by MySQL: 'YYYY-MM-DD hh:mm:ss[.fraction]' with any punctuation as
+// delimiter, two-digit years, and the delimiter-less 'YYYYMMDDhhmmss' forms.
+// A date without a time component is also accepted, at midnight.
+// The returned precision is the number of fractional digits in the input.
+func ParseDateTime(s string) (DateTime, int, bool) {
+	dt, prec, _, ok := parseDateTime(s)
+	return dt, prec, ok
+}
+
+// ParseDateOrDateTime is like ParseDateTime, but it also reports whether the
+// string had a time component, which is what decides whether MySQL parses it
+// as a DATETIME or as a DATE.
+func ParseDateOrDateTime(s string) (dt DateTime, prec int, hasTime bool, ok bool) {
+	return parseDateTime(s)
+}
+
+// ParseDate parses a DATE value from a string. Datetime strings are also
+// accepted, and their time component is discarded.
+func ParseDate(s string) (Date, bool) {
+	dt, _, _, ok := parseDateTime(s)
+	return dt.Date, ok
+}
+
+// ParseTime parses a TIME value from a string in any of the formats accepted
+// by MySQL: '[-][D ]hh:mm:ss[.fraction]', 'hh:mm', the delimiter-less
+// 'hhmmss[.fraction]' forms, or a full datetime string, whose time component
+// is used. Times beyond the TIME range are clamped to it.
+// The returned precision is the number of fractional digits in the input.
+func ParseTime(s string) (Time, int, bool) {
+	s = strings.TrimSpace(s)
+
+	if dt, prec, hasTime, ok := parseDateTime(s); ok && hasTime {
+		return dt.Time, prec, true
+	}
+
+	var neg bool
+	if len(s) > 0 && s[0] == '-' {
+		neg = true
+		s = s[1:]
+	}
+
+	var days, hour, minute, second, nsec, prec int
+	var hasDays, ok bool
+
+	n := countDigits(s)
+	if n == 0 {
+		return Time{}, 0, false
+	}
+
+	switch {
+	case n < len(s) && s[n] == ' ':
+		days, s, ok = parseComponent(s, 9)
+		if !ok {
+			return Time{}, 0, false
+		}
+		hasDays = true
+		s = strings.TrimLeft(s, " ")
+		hour, s, ok = parseComponent(s, 3)
+		if !ok {
+			return Time{}, 0, false
+		}
+		fallthrough
+	case n < len(s) && s[n] == ':':
+		if !hasDays {
+			hour, s, ok = parseComponent(s, 9)
+			if !ok {
+				return Time{}, 0, false
+			}
+		}
+		if len(s) > 1 && s[0] == ':' {
+			minute, s, ok = parseComponent(s[1:], 2)
+			if !ok {
+				return Time{}, 0, false
+			}
+			if len(s) > 1 && s[0] == ':' {
+				second, s, ok = parseComponent(s[1:], 2)
+				if !ok {
+					return Time{}, 0, false
+				}
+			}
+		}
+	case n == len(s) || s[n] == '.':
+		v := parseDigits(s, n)
+		hour, minute, second = v/10000, (v/100)%100, v%100
+		s = s[n:]
+	default:
+		return Time{}, 0, false
+	}
+
+	if len(s) > 0 && s[0] == '.' {
+		nsec, prec, s = parseFraction(s[1:])
+	}
+	if len(s) > 0 || minute >= 60 || second >= 60 {
+		return Time{}, 0, false
+	}
+
+	hour += days * 24
+	// the TIME range ends at 838:59:59 without any fractional seconds
+	if hour > MaxHours || hour == MaxHours && minute == 59 && second == 59 && nsec > 0 {
+		hour, minute, second, nsec = MaxHours, 59, 59, 0
+	}
+	return NewTime(neg, hour, minute, second, nsec), prec, true
+}
+
+// ParseDateTimeInt64 parses a DATETIME out of an integer in any of the
+// 'YYYYMMDD', 'YYMMDD', 'YYYYMMDDhhmmss' or 'YYMMDDhhmmss' forms, following
+// the rules of MySQL's number_to_datetime.
+func ParseDateTimeInt64(nr int64) (dt DateTime, ok bool) {
+	switch {
+	case nr == 0:
+		return dt, true
+	case nr < 0:
+		return dt, false
+	case nr < 101:
+		return dt, false
+	case nr <= (yyPartYear-1)*10000+1231:
+		nr = (nr + 20000000) * 1000000
+	case nr < yyPartYear*10000+101:
+		return dt, false
+	case nr <= 991231:
+		nr = (nr + 19000000) * 1000000
+	case nr < 10000101:
+		return dt, false
+	case nr <= 99991231:
+		nr = nr * 1000000
+	case nr < 101000000:
+		return dt, false
+	case nr <= (yyPartYear-1)*10000000000+1231235959:
+		nr = nr + 20000000000000
+	case nr < yyPartYear*10000000000+101000000:
+		return dt, false
+	case nr <= 991231235959:
+		nr = nr + 19000000000000
+	}
+
+	part1 := nr / 1000000
+	part2 := nr % 1000000
+
+	year, month, day := int(part1/10000), int(part1/100%100), int(part1%100)
+	hour, minute, second := int(part2/10000), int(part2/100%100), int(part2%100)
+
+	if !validDate(year, month, day) || !validTimeOfDay(hour, minute, second) {
+		return dt, false
+	}
+	return NewDateTime(NewDate(year, month, day), NewTime(false, hour, minute, second, 0)), true
+}
+
+// ParseTimeInt64 parses a TIME out of an integer in the '[-]hhmmss' form.
+// Integers large enough to be datetimes are parsed as such, and their
+// time component is used.
+func ParseTimeInt64(nr int64) (t Time, ok bool) {
+	var neg bool
+	if nr < 0 {
+		neg = true
+		nr = -nr
+	}
+	if nr >= 10000000000 {
+		dt, ok := ParseDateTimeInt64(nr)
+		return dt.Time, ok && !neg
+	}
+
+	hour, minute, second := nr/10000, nr/100%100, nr%100
+	if minute >= 60 || second >= 60 {
+		return t, false
+	}
+	if hour > MaxHours {
+		hour, minute, second = MaxHours, 59, 59
+	}
+	return NewTime(neg, int(hour), int(minute), int(second), 0), true
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDateTime(t *testing.T) {
+	var tests = []struct {
+		input  string
+		output string
+		prec   int
+		ok     bool
+	}{
+		{input: "2023-01-02 03:04:05", output: "2023-01-02 03:04:05", ok: true},
+		{input: "2023-01-02T03:04:05", output: "2023-01-02 03:04:05", ok: true},
+		{input: "2023/1/2 3:4:5", output: "2023-01-02 03:04:05", ok: true},
+		{input: "23-01-02 03:04:05", output: "2023-01-02 03:04:05", ok: true},
+		{input: "99-01-02", output: "1999-01-02 00:00:00", ok: true},
+		{input: "2023-01-02", output: "2023-01-02 00:00:00", ok: true},
+		{input: "2023-01-02 03:04", output: "2023-01-02 03:04:00", ok: true},
+		{input: "2023-01-02 03:04:05.123", output: "2023-01-02 03:04:05.123000", prec: 3, ok: true},
+		{input: "2023-01-02 03:04:05.1234567", output: "2023-01-02 03:04:05.123456", prec: 6, ok: true},
+		{input: "20230102030405", output: "2023-01-02 03:04:05", ok: true},
+		{input: "20230102", output: "2023-01-02 00:00:00", ok: true},
+		{input: "230102", output: "2023-01-02 00:00:00", ok: true},
+		{input: "0000-00-00", output: "0000-00-00 00:00:00", ok: true},
+		{input: "2024-02-29", output: "2024-02-29 00:00:00", ok: true},
+		{input: "2023-02-29", ok: false},
+		{input: "2023-13-01", ok: false},
+		{input: "2023-01-02 24:00:00", ok: false},
+		{input: "2023-01-02 03:04:05 foo", ok: false},
+		{input: "foobar", ok: false},
+		{input: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			dt, prec, ok := ParseDateTime(test.input)
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.Equal(t, test.output, string(dt.Format(6)[:len(test.output)]))
+				assert.Equal(t, test.prec, prec)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	var tests = []struct {
+		input  string
+		output string
+		ok     bool
+	}{
+		{input: "12:34:56", output: "12:34:56.000000", ok: true},
+		{input: "-12:34:56", output: "-12:34:56.000000", ok: true},
+		{input: "12:34", output: "12:34:00.000000", ok: true},
+		{input: "123:34:56.5", output: "123:34:56.500000", ok: true},
+		{input: "1 02:03:04", output: "26:03:04.000000", ok: true},
+		{input: "0 02:03:04", output: "02:03:04.000000", ok: true},
+		{input: "123456", output: "12:34:56.000000", ok: true},
+		{input: "3456", output: "00:34:56.000000", ok: true},
+		{input: "56", output: "00:00:56.000000", ok: true},
+		{input: "2023-01-02 03:04:05", output: "03:04:05.000000", ok: true},
+		{input: "900:00:00", output: "838:59:59.000000", ok: true},
+		{input: "-900:00:00", output: "-838:59:59.000000", ok: true},
+		{input: "838:59:59.5", output: "838:59:59.000000", ok: true},
+		{input: "-838:59:59.000001", output: "-838:59:59.000000", ok: true},
+		{input: "838:59:58.5", output: "838:59:58.500000", ok: true},
+		{input: "12:60:00", ok: false},
+		{input: "2023-01-02", ok: false},
+		{input: "foo", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			tm, _, ok := ParseTime(test.input)
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.Equal(t, test.output, string(tm.Format(6)))
+			}
+		})
+	}
+}
+
+func TestParseDateTimeInt64(t *testing.T) {
+	var tests = []struct {
+		input  int64
+		output string
+		ok     bool
+	}{
+		{input: 0, output: "0000-00-00 00:00:00", ok: true},
+		{input: 20230102, output: "2023-01-02 00:00:00", ok: true},
+		{input: 230102, output: "2023-01-02 00:00:00", ok: true},
+		{input: 990102, output: "1999-01-02 00:00:00", ok: true},
+		{input: 20230102030405, output: "2023-01-02 03:04:05", ok: true},
+		{input: 100, ok: false},
+		{input: 20231302, ok: false},
+	}
+
+	for _, test := range tests {
+		dt, ok := ParseDateTimeInt64(test.input)
+		assert.Equal(t, test.ok, ok, "%d", test.input)
+		if test.ok {
+			assert.Equal(t, test.output, string(dt.Format(0)), "%d", test.input)
+		}
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	var tests = []struct {
+		input  string
+		unit   IntervalType
+		output Interval
+		ok     bool
+	}{
+		{input: "1-2", unit: IntervalYearMonth, output: Interval{unit: IntervalYearMonth, year: 1, month: 2}, ok: true},
+		{input: "-1-2", unit: IntervalYearMonth, output: Interval{unit: IntervalYearMonth, neg: true, year: 1, month: 2}, ok: true},
+		{input: "2", unit: IntervalYearMonth, output: Interval{unit: IntervalYearMonth, month: 2}, ok: true},
+		{input: "1 2:3:4", unit: IntervalDaySecond, output: Interval{unit: IntervalDaySecond, day: 1, hour: 2, minute: 3, second: 4}, ok: true},
+		{input: "3:4", unit: IntervalDaySecond, output: Interval{unit: IntervalDaySecond, minute: 3, second: 4}, ok: true},
+		{input: " 1 2", unit: IntervalDayHour, output: Interval{unit: IntervalDayHour, day: 1, hour: 2}, ok: true},
+		{input: "1.5", unit: IntervalSecondMicrosecond, output: Interval{unit: IntervalSecondMicrosecond, second: 1, usec: 500000}, ok: true},
+		{input: "1.000001", unit: IntervalSecondMicrosecond, output: Interval{unit: IntervalSecondMicrosecond, second: 1, usec: 1}, ok: true},
+		{input: "5", unit: IntervalSecondMicrosecond, output: Interval{unit: IntervalSecondMicrosecond, usec: 500000}, ok: true},
+		{input: "1 2:3:4.25", unit: IntervalDayMicrosecond, output: Interval{unit: IntervalDayMicrosecond, day: 1, hour: 2, minute: 3, second: 4, usec: 250000}, ok: true},
+		{input: "1 2 3", unit: IntervalDayHour, ok: false},
+		{input: "1", unit: IntervalDay, ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			itv, ok := ParseInterval(test.input, test.unit)
+			assert.Equal(t, test.ok, ok)
+			if test.ok {
+				assert.Equal(t, test.output, itv)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import "strconv"
+
+// hour12 returns the hour of the given time in a 12-hour clock, where
+// both midnight and noon are 12.
+func hour12(t Time) int {
+	return (t.Hour()%24+11)%12 + 1
+}
+
+func meridiem(t Time) string {
+	if t.Hour()%24 < 12 {
+		return "AM"
+	}
+	return "PM"
+}
+
+// appendPrefill appends the decimal representation of n to b, left-padded
+// with zeroes to the given width like MySQL's append_with_prefill. Unlike
+// appendInt, it accepts negative numbers, which only appear when formatting
+// the weeks and days of dates with zero components.
+func appendPrefill(b []byte, n int, width int) []byte {
+	if n >= 0 {
+		return appendInt(b, n, width)
+	}
+	digits := strconv.AppendInt(nil, int64(n), 10)
+	for w := len(digits); w < width; w++ {
+		b = append(b, '0')
+	}
+	return append(b, digits...)
+}
+
+// daySuffix returns the English ordinal suffix for the given day of the month.
+func daySuffix(day int) string {
+	if day >= 10 && day <= 19 {
+		return "th"
+	}
+	switch day % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// Strftime appends to b the given datetime formatted according to the
+// specifiers supported by MySQL's DATE_FORMAT, using the given locale for the
+// names of days and months. Any character following a '%' that is not a known
+// specifier is output literally. The boolean result is false if the format
+// contains the name of a month or day that the date does not have, in which
+// case MySQL returns NULL.
+func Strftime(b []byte, format []byte, dt DateTime, locale *Locale) ([]byte, bool) {
+	d, t := dt.Date, dt.Time
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			b = append(b, format[i])
+			continue
+		}
+
+		i++
+		switch format[i] {
+		case 'M':
+			if d.Month() == 0 {
+				return nil, false
+			}
+			b = append(b, locale.MonthName(d.Month())...)
+		case 'b':
+			if d.Month() == 0 {
+				return nil, false
+			}
+			b = append(b, locale.AbbrevMonthName(d.Month())...)
+		case 'W':
+			if d.Month() == 0 && d.Year() == 0 {
+				return nil, false
+			}
+			b = append(b, locale.DayName(d.Weekday())...)
+		case 'a':
+			if d.Month() == 0 && d.Year() == 0 {
+				return nil, false
+			}
+			b = append(b, locale.AbbrevDayName(d.Weekday())...)
+		case 'w':
+			if d.Month() == 0 && d.Year() == 0 {
+				return nil, false
+			}
+			b = appendInt(b, int(d.Weekday()), 0)
+		case 'D':
+			b = appendInt(b, d.Day(), 0)
+			b = append(b, daySuffix(d.Day())...)
+		case 'j':
+			b = appendPrefill(b, d.DayNumber()-NewDate(d.Year(), 1, 1).DayNumber()+1, 3)
+		case 'U':
+			_, week := d.calcWeek(weekFirstWeekday)
+			b = appendPrefill(b, week, 2)
+		case 'u':
+			_, week := d.calcWeek(weekMondayFirst)
+			b = appendPrefill(b, week, 2)
+		case 'V':
+			_, week := d.calcWeek(weekYear | weekFirstWeekday)
+			b = appendPrefill(b, week, 2)
+		case 'v':
+			_, week := d.calcWeek(weekYear | weekMondayFirst)
+			b = appendPrefill(b, week, 2)
+		case 'X':
+			year, _ := d.calcWeek(weekYear | weekFirstWeekday)
+			b = appendPrefill(b, year, 4)
+		case 'x':
+			year, _ := d.calcWeek(weekYear | weekMondayFirst)
+			b = appendPrefill(b, year, 4)
+		case 'Y':
+			b = appendInt(b, d.Year(), 4)
+		case 'y':
+			b = appendInt(b, d.Year()%100, 2)
+		case 'm':
+			b = appendInt(b, d.Month(), 2)
+		case 'c':
+			b = appendInt(b, d.Month(), 0)
+		case 'd':
+			b = appendInt(b, d.Day(), 2)
+		case 'e':
+			b = appendInt(b, d.Day(), 0)
+		case 'H':
+			b = appendInt(b, t.Hour(), 2)
+		case 'k':
+			b = appendInt(b, t.Hour(), 0)
+		case 'h', 'I':
+			b = appendInt(b, hour12(t), 2)
+		case 'l':
+			b = appendInt(b, hour12(t), 0)
+		case 'i':
+			b = appendInt(b, t.Minute(), 2)
+		case 'S', 's':
+			b = appendInt(b, t.Second(), 2)
+		case 'f':
+			b = appendInt(b, t.Nanosecond()/1000, 6)
+		case 'p':
+			b = append(b, meridiem(t)...)
+		case 'r':
+			b = appendInt(b, hour12(t), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Minute(), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Second(), 2)
+			b = append(b, ' ')
+			b = append(b, meridiem(t)...)
+		case 'T':
+			b = appendInt(b, t.Hour(), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Minute(), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Second(), 2)
+		default:
+			b = append(b, format[i])
+		}
+	}
+	return b, true
+}
+
+// StrftimeTime appends to b the given time formatted like MySQL's TIME_FORMAT
+// does. Only the specifiers for hours, minutes, seconds and microseconds are
+// supported, and hours are not limited to a day, so '838:00:00' is formatted
+// as 838 by %H. The boolean result is false if the format contains a
+// specifier for a part of a date, in which case MySQL returns NULL.
+func StrftimeTime(b []byte, format []byte, t Time) ([]byte, bool) {
+	if t.Neg() {
+		b = append(b, '-')
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			b = append(b, format[i])
+			continue
+		}
+
+		i++
+		switch format[i] {
+		case 'M', 'b', 'W', 'a', 'w', 'D', 'j', 'U', 'u', 'V', 'v', 'X', 'x', 'Y', 'y', 'm', 'c', 'd', 'e':
+			return nil, false
+		case 'H':
+			b = appendInt(b, t.Hour(), 2)
+		case 'k':
+			b = appendInt(b, t.Hour(), 0)
+		case 'h', 'I':
+			b = appendInt(b, hour12(t), 2)
+		case 'l':
+			b = appendInt(b, hour12(t), 0)
+		case 'i':
+			b = appendInt(b, t.Minute(), 2)
+		case 'S', 's':
+			b = appendInt(b, t.Second(), 2)
+		case 'f':
+			b = appendInt(b, t.Nanosecond()/1000, 6)
+		case 'p':
+			b = append(b, meridiem(t)...)
+		case 'r':
+			b = appendInt(b, hour12(t), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Minute(), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Second(), 2)
+			b = append(b, ' ')
+			b = append(b, meridiem(t)...)
+		case 'T':
+			b = appendInt(b, t.Hour(), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Minute(), 2)
+			b = append(b, ':')
+			b = appendInt(b, t.Second(), 2)
+		default:
+			b = append(b, format[i])
+		}
+	}
+	return b, true
+}
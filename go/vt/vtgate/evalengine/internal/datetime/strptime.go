@@ -0,0 +1,362 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import "strings"
+
+// StrptimeParts reports which parts of a value the given format specifies
+// when used with Strptime, following MySQL's get_date_time_result_type. MySQL
+// uses them to decide the type returned by STR_TO_DATE: a DATE if the format
+// only has date specifiers, a TIME if it only has time specifiers, and a
+// DATETIME otherwise.
+func StrptimeParts(format string) (date, time, frac bool) {
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'd', 'e', 'c', 'm', 'y', 'Y', 'b', 'M', 'D', 'W', 'a', 'w', 'j', 'U', 'u', 'V', 'v', 'x', 'X':
+			date = true
+		case 'f':
+			time = true
+			frac = true
+		case 'H', 'k', 'h', 'I', 'l', 'i', 's', 'S', 'p', 'r', 'T':
+			time = true
+		}
+	}
+	return date, time, frac
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r'
+}
+
+func isAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// strptimeNumber parses up to width digits from the start of s. The boolean
+// result is false if s does not start with a digit.
+func strptimeNumber(s string, width int) (int, string, bool) {
+	if width > len(s) {
+		width = len(s)
+	}
+	n := countDigits(s[:width])
+	if n == 0 {
+		return 0, s, false
+	}
+	return parseDigits(s, n), s[n:], true
+}
+
+// strptimeWord matches the word at the start of s against the given names,
+// case-insensitively. Like MySQL's check_word, a prefix of a name is also
+// accepted if it's not ambiguous. It returns the index of the matched name,
+// or -1 if there's no match.
+func strptimeWord(s string, names []string) (int, string) {
+	n := 0
+	for n < len(s) && isAlpha(s[n]) {
+		n++
+	}
+	if n == 0 {
+		return -1, s
+	}
+
+	word := s[:n]
+	match := -1
+	for i, name := range names {
+		if strings.EqualFold(name, word) {
+			return i, s[n:]
+		}
+		if len(word) < len(name) && strings.EqualFold(name[:len(word)], word) {
+			if match >= 0 {
+				return -1, s
+			}
+			match = i
+		}
+	}
+	if match < 0 {
+		return -1, s
+	}
+	return match, s[n:]
+}
+
+type strptimeState struct {
+	year, month, day          int
+	hour, minute, second      int
+	usec                      int
+	yearday                   int
+	weekday                   int
+	week, weekYear            int
+	daypart                   int
+	usaTime                   bool
+	sundayFirst, strictWeek   bool
+	strictWeekYearSundayFirst bool
+}
+
+// Strptime parses value according to the specifiers of format, like MySQL's
+// STR_TO_DATE does. Components that are not in the format are zero, and the
+// rest of the value is ignored once the format has been consumed. The boolean
+// result is false if the value does not match the format, or if the date
+// that it describes is invalid. Dates with zero components are not considered
+// invalid here.
+func Strptime(value, format string) (DateTime, bool) {
+	// like in MySQL, anything in the value after the parts matched by the
+	// format is ignored
+	st := strptimeState{week: -1, weekYear: -1}
+	if _, ok := st.parse(value, format); !ok {
+		return DateTime{}, false
+	}
+
+	if st.usaTime {
+		if st.hour > 12 || st.hour < 1 {
+			return DateTime{}, false
+		}
+		st.hour = st.hour%12 + st.daypart
+	}
+
+	if st.yearday > 0 {
+		days := NewDate(st.year, 1, 1).DayNumber() + st.yearday - 1
+		if days <= 0 || days > MaxDayNumber {
+			return DateTime{}, false
+		}
+		d := DateFromDayNumber(days)
+		st.year, st.month, st.day = d.Year(), d.Month(), d.Day()
+	}
+
+	if st.week >= 0 && st.weekday > 0 {
+		// %V and %v need %X and %x respectively, while %U and %u must be
+		// used with %Y instead
+		if st.strictWeek && (st.weekYear < 0 || st.strictWeekYearSundayFirst != st.sundayFirst) ||
+			!st.strictWeek && st.weekYear >= 0 {
+			return DateTime{}, false
+		}
+
+		year := st.year
+		if st.strictWeek {
+			year = st.weekYear
+		}
+		days := NewDate(year, 1, 1).DayNumber()
+		weekdayJan1 := calcWeekday(days, st.sundayFirst)
+
+		if st.sundayFirst {
+			if weekdayJan1 != 0 {
+				days += 7
+			}
+			days += -weekdayJan1 + (st.week-1)*7 + st.weekday%7
+		} else {
+			if weekdayJan1 > 3 {
+				days += 7
+			}
+			days += -weekdayJan1 + (st.week-1)*7 + (st.weekday - 1)
+		}
+		if days <= 0 || days > MaxDayNumber {
+			return DateTime{}, false
+		}
+		d := DateFromDayNumber(days)
+		st.year, st.month, st.day = d.Year(), d.Month(), d.Day()
+	}
+
+	if st.month > 12 || st.day > 31 || st.hour > 23 || st.minute > 59 || st.second > 59 {
+		return DateTime{}, false
+	}
+	if st.month > 0 && st.day > DaysIn(st.month, st.year) {
+		return DateTime{}, false
+	}
+
+	return NewDateTime(
+		NewDate(st.year, st.month, st.day),
+		NewTime(false, st.hour, st.minute, st.second, st.usec*1000),
+	), true
+}
+
+func (st *strptimeState) parse(value, format string) (string, bool) {
+	var ok bool
+	for i := 0; i < len(format); i++ {
+		value = strings.TrimLeft(value, " \t\n\v\f\r")
+		if len(value) == 0 {
+			break
+		}
+
+		if format[i] != '%' || i == len(format)-1 {
+			if !isSpace(format[i]) {
+				if value[0] != format[i] {
+					return value, false
+				}
+				value = value[1:]
+			}
+			continue
+		}
+
+		i++
+		switch format[i] {
+		case 'Y':
+			n := len(value)
+			if st.year, value, ok = strptimeNumber(value, 4); !ok {
+				return value, false
+			}
+			if n-len(value) <= 2 {
+				st.year = year2000(st.year)
+			}
+		case 'y':
+			if st.year, value, ok = strptimeNumber(value, 2); !ok {
+				return value, false
+			}
+			st.year = year2000(st.year)
+		case 'm', 'c':
+			if st.month, value, ok = strptimeNumber(value, 2); !ok {
+				return value, false
+			}
+		case 'M', 'b':
+			names := LocaleEnUS.Months[:]
+			if format[i] == 'b' {
+				names = LocaleEnUS.AbbrevMonths[:]
+			}
+			var month int
+			if month, value = strptimeWord(value, names); month < 0 {
+				return value, false
+			}
+			st.month = month + 1
+		case 'd', 'e':
+			if st.day, value, ok = strptimeNumber(value, 2); !ok {
+				return value, false
+			}
+		case 'D':
+			if st.day, value, ok = strptimeNumber(value, 2); !ok {
+				return value, false
+			}
+			// skip the English suffix of the day
+			if len(value) > 2 {
+				value = value[2:]
+			} else {
+				value = ""
+			}
+		case 'h', 'I', 'l':
+			st.usaTime = true
+			fallthrough
+		case 'k', 'H':
+			if st.hour, value, ok = strptimeNumber(value, 2); !ok {
+				return value, false
+			}
+		case 'i':
+			if st.minute, value, ok = strptimeNumber(value, 2); !ok {
+				return value, false
+			}
+		case 's', 'S':
+			if st.second, value, ok = strptimeNumber(value, 2); !ok {
+				return value, false
+			}
+		case 'f':
+			n := len(value)
+			if st.usec, value, ok = strptimeNumber(value, 6); !ok {
+				return value, false
+			}
+			for digits := n - len(value); digits < 6; digits++ {
+				st.usec *= 10
+			}
+		case 'p':
+			if len(value) < 2 || !st.usaTime {
+				return value, false
+			}
+			switch {
+			case strings.EqualFold(value[:2], "PM"):
+				st.daypart = 12
+			case !strings.EqualFold(value[:2], "AM"):
+				return value, false
+			}
+			value = value[2:]
+		case 'W', 'a':
+			names := LocaleEnUS.Days[:]
+			if format[i] == 'a' {
+				names = LocaleEnUS.AbbrevDays[:]
+			}
+			var weekday int
+			if weekday, value = strptimeWord(value, names); weekday < 0 {
+				return value, false
+			}
+			// weekdays are numbered from 1 for Monday to 7 for Sunday
+			st.weekday = weekday
+			if weekday == 0 {
+				st.weekday = 7
+			}
+		case 'w':
+			var weekday int
+			if weekday, value, ok = strptimeNumber(value, 1); !ok || weekday >= 7 {
+				return value, false
+			}
+			st.weekday = weekday
+			if weekday == 0 {
+				st.weekday = 7
+			}
+		case 'j':
+			if st.yearday, value, ok = strptimeNumber(value, 3); !ok {
+				return value, false
+			}
+		case 'U', 'u', 'V', 'v':
+			st.sundayFirst = format[i] == 'U' || format[i] == 'V'
+			st.strictWeek = format[i] == 'V' || format[i] == 'v'
+			if st.week, value, ok = strptimeNumber(value, 2); !ok || st.week > 53 || st.strictWeek && st.week == 0 {
+				return value, false
+			}
+		case 'X', 'x':
+			st.strictWeekYearSundayFirst = format[i] == 'X'
+			if st.weekYear, value, ok = strptimeNumber(value, 4); !ok {
+				return value, false
+			}
+		case 'r':
+			if value, ok = st.parse(value, "%I:%i:%S %p"); !ok {
+				return value, false
+			}
+		case 'T':
+			if value, ok = st.parse(value, "%H:%i:%S"); !ok {
+				return value, false
+			}
+		case '.':
+			for len(value) > 0 && isPunct(value[0]) {
+				value = value[1:]
+			}
+		case '@':
+			for len(value) > 0 && isAlpha(value[0]) {
+				value = value[1:]
+			}
+		case '#':
+			for len(value) > 0 && isDigit(value, 0) {
+				value = value[1:]
+			}
+		case '%':
+			if value[0] != '%' {
+				return value, false
+			}
+			value = value[1:]
+		default:
+			return value, false
+		}
+	}
+	return value, true
+}
+
+// year2000 converts a year with at most two digits into a full year, with
+// the same pivot that MySQL uses when parsing dates.
+func year2000(year int) int {
+	if year >= 100 {
+		return year
+	}
+	if year < yyPartYear {
+		return year + 2000
+	}
+	return year + 1900
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datetime
+
+import (
+	"strings"
+	"time"
+)
+
+// The range of the offsets that MySQL accepts for a time zone, which goes
+// from '-13:59' to '+14:00'.
+const (
+	minOffsetSeconds = -(13*3600 + 59*60)
+	maxOffsetSeconds = 14 * 3600
+)
+
+// parseOffset parses a time zone given as an offset from UTC, like '+05:30',
+// following MySQL's str_to_offset.
+func parseOffset(tz string) (int, bool) {
+	if len(tz) < 4 {
+		return 0, false
+	}
+
+	neg := tz[0] == '-'
+	if tz[0] != '+' && !neg {
+		return 0, false
+	}
+	tz = tz[1:]
+
+	colon := strings.IndexByte(tz, ':')
+	if colon < 0 || colon == len(tz)-1 {
+		return 0, false
+	}
+	hours, minutes := tz[:colon], tz[colon+1:]
+	if countDigits(hours) != len(hours) || countDigits(minutes) != len(minutes) || len(hours) > 2 || len(minutes) > 2 {
+		return 0, false
+	}
+
+	m := parseDigits(minutes, len(minutes))
+	offset := (parseDigits(hours, len(hours))*60 + m) * 60
+	if neg {
+		offset = -offset
+	}
+	if m > 59 || offset < minOffsetSeconds || offset > maxOffsetSeconds {
+		return 0, false
+	}
+	return offset, true
+}
+
+// ParseTimeZone returns the location for a time zone given like in MySQL:
+// either as an offset from UTC like '+05:30', as the name of a zone in the
+// time zone database like 'Europe/Amsterdam', or as 'SYSTEM' for the local
+// time zone. The boolean result is false if the time zone is not known.
+func ParseTimeZone(tz string) (*time.Location, bool) {
+	if offset, ok := parseOffset(tz); ok {
+		return time.FixedZone(tz, offset), true
+	}
+	if strings.EqualFold(tz, "SYSTEM") {
+		return time.Local, true
+	}
+	// an empty name and 'Local' have special meanings for LoadLocation
+	// which MySQL doesn't know about
+	if tz == "" || tz == "Local" {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
@@ -451,10 +451,11 @@ func (d Decimal) divRound(d2 Decimal, precision int32) Decimal {
 	return q.Add(New(1, -precision))
 }
 
-// mod returns d % d2.
-func (d Decimal) mod(d2 Decimal) Decimal {
-	quo := d.divRound(d2, -d.exp+1).truncate(0)
-	return d.sub(d2.mul(quo))
+// Mod returns d % d2, which like in MySQL has the sign of d and the
+// largest scale of d and d2. d2 must not be zero.
+func (d Decimal) Mod(d2 Decimal) Decimal {
+	_, r := d.quoRem(d2, 0)
+	return r
 }
 
 func (d Decimal) Ceil() Decimal {
@@ -474,6 +475,22 @@ func (d Decimal) Ceil() Decimal {
 	return Decimal{value: z, exp: 0}
 }
 
+func (d Decimal) Floor() Decimal {
+	if d.isInteger() {
+		return d
+	}
+
+	exp := big.NewInt(10)
+
+	// NOTE(vadim): must negate after casting to prevent int32 overflow
+	exp.Exp(exp, big.NewInt(-int64(d.exp)), nil)
+
+	// big.Int.Div performs Euclidean division, which rounds towards negative
+	// infinity for positive divisors
+	z := new(big.Int).Div(d.value, exp)
+	return Decimal{value: z, exp: 0}
+}
+
 func (d Decimal) truncate(precision int32) Decimal {
 	d.ensureInitialized()
 	if precision >= 0 && -precision > d.exp {
@@ -482,6 +499,20 @@ func (d Decimal) truncate(precision int32) Decimal {
 	return d
 }
 
+// Truncate truncates the decimal towards zero to the given number of decimal
+// places. A negative number of places truncates digits of the integral part.
+func (d Decimal) Truncate(places int32) Decimal {
+	d.ensureInitialized()
+	if -places <= d.exp {
+		return d
+	}
+	d = d.rescale(-places)
+	if places < 0 {
+		d = d.rescale(0)
+	}
+	return d
+}
+
 // isInteger returns true when decimal can be represented as an integer value, otherwise, it returns false.
 func (d Decimal) isInteger() bool {
 	// The most typical case, all decimal with exponent higher or equal 0 can be represented as integer
@@ -508,6 +508,45 @@ func TestDecimal_RoundAndStringFixed(t *testing.T) {
 	}
 }
 
+func TestDecimal_Truncate(t *testing.T) {
+	tests := []struct {
+		input    string
+		places   int32
+		expected string
+	}{
+		{"1.454", 0, "1"},
+		{"1.454", 1, "1.4"},
+		{"1.454", 2, "1.45"},
+		{"1.454", 3, "1.454"},
+		{"1.454", 4, "1.454"},
+		{"1.999", 2, "1.99"},
+		{"-1.999", 2, "-1.99"},
+		{"-1.999", 0, "-1"},
+		{"545", -1, "540"},
+		{"545", -2, "500"},
+		{"545", -3, "0"},
+		{"-545.5", -1, "-540"},
+		{"123456789012345678901234567890.5", -29, "100000000000000000000000000000"},
+		{"0", -1, "0"},
+	}
+
+	for _, test := range tests {
+		d, err := NewFromString(test.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := NewFromString(test.expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := d.Truncate(test.places)
+		if !got.Equal(expected) {
+			t.Errorf("Truncating %s to %d places, got %s, expected %s",
+				d, test.places, got, expected)
+		}
+	}
+}
+
 func TestDecimal_Add(t *testing.T) {
 	type Inp struct {
 		a string
@@ -944,7 +983,7 @@ func TestDecimal_Mod(t *testing.T) {
 		if err != nil {
 			t.FailNow()
 		}
-		c := a.mod(b)
+		c := a.Mod(b)
 		if c.String() != res {
 			t.Errorf("expected %s, got %s", res, c.String())
 		}
@@ -64,26 +64,36 @@ func NewString(raw []byte) *Value {
 	}
 }
 
+// Depth returns the maximum depth of this JSON value. The document is walked
+// with an explicit stack instead of recursion, so that arbitrarily deep values
+// cannot overflow the goroutine stack.
 func (v *Value) Depth() int {
-	max := func(a, b int) int {
-		if a > b {
-			return a
-		}
-		return b
+	type frame struct {
+		v     *Value
+		depth int
 	}
 
 	var depth int
-	switch v.t {
-	case TypeObject:
-		for _, kv := range v.o.kvs {
-			depth = max(kv.v.Depth(), depth)
+	stack := []frame{{v: v, depth: 1}}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if top.depth > depth {
+			depth = top.depth
 		}
-	case TypeArray:
-		for _, a := range v.a {
-			depth = max(a.Depth(), depth)
+		switch top.v.t {
+		case TypeObject:
+			for _, kv := range top.v.o.kvs {
+				stack = append(stack, frame{v: kv.v, depth: top.depth + 1})
+			}
+		case TypeArray:
+			for _, a := range top.v.a {
+				stack = append(stack, frame{v: a, depth: top.depth + 1})
+			}
 		}
 	}
-	return depth + 1
+	return depth
 }
 
 func (v *Value) Len() int {
@@ -96,3 +106,26 @@ func (v *Value) Len() int {
 		return 1
 	}
 }
+
+// Clone returns a deep copy of v, which can be modified without affecting v.
+func (v *Value) Clone() *Value {
+	switch v.t {
+	case TypeTrue, TypeFalse, TypeNull:
+		return v
+	}
+	c := &Value{s: v.s, t: v.t}
+	if v.a != nil {
+		c.a = make([]*Value, len(v.a))
+		for i, vv := range v.a {
+			c.a[i] = vv.Clone()
+		}
+	}
+	if v.o.kvs != nil {
+		c.o.kvs = make([]kv, len(v.o.kvs))
+		for i, kv := range v.o.kvs {
+			c.o.kvs[i].k = kv.k
+			c.o.kvs[i].v = kv.v.Clone()
+		}
+	}
+	return c
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Vitess Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package json
+
+import (
+	"testing"
+)
+
+func TestDepth(t *testing.T) {
+	var cases = []struct {
+		doc   string
+		depth int
+	}{
+		{`1`, 1},
+		{`"foo"`, 1},
+		{`[]`, 1},
+		{`{}`, 1},
+		{`[1, 2]`, 2},
+		{`{"a": 1}`, 2},
+		{`[1, [2, [3]]]`, 4},
+		{`{"a": [], "b": {"c": [1]}}`, 4},
+	}
+
+	for _, tc := range cases {
+		if depth := MustParse(tc.doc).Depth(); depth != tc.depth {
+			t.Errorf("Depth(%s) = %d, expected %d", tc.doc, depth, tc.depth)
+		}
+	}
+}
+
+func TestDepthDeeplyNested(t *testing.T) {
+	const nesting = 5000
+
+	// build the document directly: the parser refuses documents nested deeper than MaxDepth
+	doc := NewArray([]*Value{ValueTrue})
+	for i := 1; i < nesting; i++ {
+		if i%2 == 0 {
+			doc = NewArray([]*Value{NewNumber([]byte("1")), doc})
+		} else {
+			obj := NewObject()
+			obj.o.Set("a", doc, Set)
+			doc = obj
+		}
+	}
+
+	if depth := doc.Depth(); depth != nesting+1 {
+		t.Errorf("Depth() = %d, expected %d", depth, nesting+1)
+	}
+}
@@ -105,6 +105,21 @@ func (jp *Path) String() string {
 	return b.String()
 }
 
+// IsRoot returns whether the path only selects the root of the document,
+// i.e. it's '$'.
+func (jp *Path) IsRoot() bool {
+	return jp.kind == jpDocumentRoot && jp.next == nil
+}
+
+// EndsInArrayLocation returns whether the last leg of the path selects a
+// position in an array, like in '$.a[1]'.
+func (jp *Path) EndsInArrayLocation() bool {
+	for jp.next != nil {
+		jp = jp.next
+	}
+	return jp.kind == jpArrayLocation
+}
+
 func (jp *Path) ContainsWildcards() bool {
 	for jp != nil {
 		switch jp.kind {
@@ -218,20 +233,25 @@ func (jp *Path) Match(doc *Value, wrap bool, match func(value *Value)) {
 	m.value(jp, doc)
 }
 
-func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
+// transform walks the path on v and calls t with the last leg of the path
+// and the value it applies to, along with a function that replaces that value
+// in the document.
+func (jp *Path) transform(v *Value, set func(*Value), t func(pp *Path, vv *Value, set func(*Value))) {
 	if v == nil {
 		return
 	}
 	if jp.next == nil {
-		t(jp, v)
+		t(jp, v, set)
 		return
 	}
 	switch jp.kind {
 	case jpDocumentRoot:
-		jp.next.transform(v, t)
+		jp.next.transform(v, set, t)
 	case jpMember:
 		if obj, ok := v.Object(); ok {
-			jp.next.transform(obj.Get(jp.name), t)
+			jp.next.transform(obj.Get(jp.name), func(vv *Value) {
+				obj.Set(jp.name, vv, Set)
+			}, t)
 		}
 	case jpArrayLocation:
 		if ary, ok := v.Array(); ok {
@@ -240,7 +260,9 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				panic("range in transformation path expression")
 			}
 			if from >= 0 && from < len(ary) {
-				jp.next.transform(ary[from], t)
+				jp.next.transform(ary[from], func(vv *Value) {
+					ary[from] = vv
+				}, t)
 			}
 		} else if jp.offset0 == 0 || jp.offset0 == -1 {
 			/*
@@ -248,7 +270,7 @@ func (jp *Path) transform(v *Value, t func(pp *Path, vv *Value)) {
 				the result of the evaluation is the same as if the value had been
 				wrapped in a single-element array:
 			*/
-			jp.next.transform(v, t)
+			jp.next.transform(v, set, t)
 		}
 	case jpMemberAny, jpArrayLocationAny, jpAny:
 		panic("wildcard in transformation path expression")
@@ -262,15 +284,81 @@ const (
 	Insert
 	Replace
 	Remove
+	ArrayAppend
+	ArrayInsert
 )
 
-func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) error {
+// arrayAppend appends value to the array that the last leg of a path selects
+// out of v. If the selected value is not an array, it's replaced with an
+// array that contains it followed by value.
+func arrayAppend(pp *Path, v *Value, set func(*Value), value *Value) {
+	target, setTarget := v, set
+	switch pp.kind {
+	case jpMember:
+		obj, ok := v.Object()
+		if !ok {
+			return
+		}
+		target = obj.Get(pp.name)
+		setTarget = func(vv *Value) {
+			obj.Set(pp.name, vv, Set)
+		}
+	case jpArrayLocation:
+		if ary, ok := v.Array(); ok {
+			from, _ := pp.arrayOffsets(ary)
+			if from < 0 || from >= len(ary) {
+				return
+			}
+			target = ary[from]
+			setTarget = func(vv *Value) {
+				ary[from] = vv
+			}
+		} else if pp.offset0 != 0 && pp.offset0 != -1 {
+			return
+		}
+	}
+	if target == nil {
+		return
+	}
+	if ary, ok := target.Array(); ok {
+		target.InsertArrayItem(len(ary), value)
+		return
+	}
+	setTarget(NewArray([]*Value{target, value}))
+}
+
+// ApplyTransform applies the transformation to doc for each one of the paths,
+// using the matching value for all the transformations but Remove. The
+// document is modified in place, but the result must be used instead of doc
+// because the root of the document can change: for instance, when the path
+// is '$'. None of the paths may contain wildcards, and for ArrayInsert they
+// must end in an array location.
+func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value) (*Value, error) {
 	if t != Remove && len(paths) != len(values) {
 		panic("missing Values for transformation")
 	}
+	setDoc := func(vv *Value) {
+		doc = vv
+	}
 	for i, p := range paths {
-		transform := func(pp *Path, vv *Value) {
+		transform := func(pp *Path, vv *Value, set func(*Value)) {
+			switch t {
+			case ArrayAppend:
+				arrayAppend(pp, vv, set, values[i])
+				return
+			case ArrayInsert:
+				if ary, ok := vv.Array(); ok && pp.kind == jpArrayLocation {
+					from, _ := pp.arrayOffsets(ary)
+					vv.InsertArrayItem(from, values[i])
+				}
+				return
+			}
+
 			switch pp.kind {
+			case jpDocumentRoot:
+				if t == Set || t == Replace {
+					set(values[i])
+				}
 			case jpArrayLocation:
 				if ary, ok := vv.Array(); ok {
 					from, to := pp.arrayOffsets(ary)
@@ -282,6 +370,20 @@ func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value
 					} else {
 						vv.SetArrayItem(from, values[i], t)
 					}
+					return
+				}
+				if t == Remove {
+					return
+				}
+				// a value that is not an array is handled as if it was
+				// wrapped in a single-element array, so setting any
+				// position past the first one turns it into an array
+				from, _ := pp.arrayOffsets([]*Value{vv})
+				switch {
+				case from == 0 && (t == Set || t == Replace):
+					set(values[i])
+				case from > 0 && (t == Set || t == Insert):
+					set(NewArray([]*Value{vv, values[i]}))
 				}
 			case jpMember:
 				if obj, ok := vv.Object(); ok {
@@ -293,9 +395,9 @@ func ApplyTransform(t Transformation, doc *Value, paths []*Path, values []*Value
 				}
 			}
 		}
-		p.transform(doc, transform)
+		p.transform(doc, setDoc, transform)
 	}
-	return nil
+	return doc, nil
 }
 
 func MatchPath(rawJSON, rawPath []byte, match func(value *Value)) error {
@@ -172,6 +172,20 @@ func TestTransformations(t *testing.T) {
 			Paths:    []string{`$[2]`, `$[1].b[1]`, `$[1].b[1]`},
 			Expected: `["a", {"b": [true]}]`,
 		},
+		{
+			T:        ArrayAppend,
+			Document: Document1,
+			Paths:    []string{`$[2]`, `$[1].b[0]`, `$[0]`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `[["a", 3], {"b": [[true, 2], false]}, [10, 20, 1]]`,
+		},
+		{
+			T:        ArrayInsert,
+			Document: Document1,
+			Paths:    []string{`$[0]`, `$[3][last]`, `$[3][5]`},
+			Values:   []string{"1", "2", "3"},
+			Expected: `[1, "a", {"b": [true, false]}, [10, 2, 20, 3]]`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -187,7 +201,7 @@ func TestTransformations(t *testing.T) {
 			values = append(values, json(t, v))
 		}
 
-		err := ApplyTransform(tc.T, doc, paths, values)
+		doc, err := ApplyTransform(tc.T, doc, paths, values)
 		if err != nil {
 			t.Fatal(err)
 		}
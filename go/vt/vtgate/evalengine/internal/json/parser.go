@@ -176,13 +176,6 @@ func parseValue(s string, c *cache, depth int) (*Value, string, error) {
 	}
 	if s[0] == 'n' {
 		if len(s) < len("null") || s[:len("null")] != "null" {
-			// Try parsing NaN
-			if len(s) >= 3 && strings.EqualFold(s[:3], "nan") {
-				v := c.getValue()
-				v.t = TypeNumber
-				v.s = s[:3]
-				return v, s[3:], nil
-			}
 			return nil, s, fmt.Errorf("unexpected value found: %q", s)
 		}
 		return ValueNull, s[len("null"):], nil
@@ -192,6 +185,9 @@ func parseValue(s string, c *cache, depth int) (*Value, string, error) {
 	if err != nil {
 		return nil, tail, fmt.Errorf("cannot parse number: %s", err)
 	}
+	if !validNumber(ns) {
+		return nil, s, fmt.Errorf("cannot parse number: invalid number %q", ns)
+	}
 	v := c.getValue()
 	v.t = TypeNumber
 	v.s = ns
@@ -311,8 +307,34 @@ func escapeString(dst []byte, s string) []byte {
 		return dst
 	}
 
-	// Slow path.
-	return strconv.AppendQuote(dst, s)
+	// Slow path: escape the same characters that MySQL does, which leaves
+	// any non-ASCII character untouched.
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		default:
+			if c < 0x20 {
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			} else {
+				dst = append(dst, c)
+			}
+		}
+	}
+	dst = append(dst, '"')
+	return dst
 }
 
 func hasSpecialChars(s string) bool {
@@ -385,7 +407,7 @@ func unescapeStringBestEffort(s string) string {
 				break
 			}
 			x1, err := strconv.ParseUint(s[2:6], 16, 16)
-			if err != nil {
+			if err != nil || !isLowSurrogate(rune(x1)) {
 				b = append(b, "\\u"...)
 				b = append(b, xs...)
 				break
@@ -408,6 +430,10 @@ func unescapeStringBestEffort(s string) string {
 	return hack.String(b)
 }
 
+func isLowSurrogate(r rune) bool {
+	return r >= 0xdc00 && r < 0xe000
+}
+
 // parseRawKey is similar to parseRawString, but is optimized
 // for small-sized keys without escape sequences.
 func parseRawKey(s string) (string, string, bool, error) {
@@ -481,6 +507,52 @@ func parseRawNumber(s string) (string, string, error) {
 	return s, "", nil
 }
 
+// validNumber returns whether s follows the grammar of numbers in JSON
+// documents: an optional minus sign, an integer part without leading zeroes,
+// and optional fraction and exponent parts with at least one digit each.
+// parseRawNumber only finds where a number ends, so it accepts values like
+// "1-2" or ".5" that MySQL rejects.
+func validNumber(s string) bool {
+	digits := func(i int) int {
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i
+	}
+
+	i := 0
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(s) && s[i] == '0':
+		i++
+	case i < len(s) && s[i] >= '1' && s[i] <= '9':
+		i = digits(i)
+	default:
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		j := digits(i + 1)
+		if j == i+1 {
+			return false
+		}
+		i = j
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			i++
+		}
+		j := digits(i)
+		if j == i {
+			return false
+		}
+		i = j
+	}
+	return i == len(s)
+}
+
 // Object represents JSON object.
 //
 // Object cannot be used from concurrent goroutines.
@@ -528,13 +600,23 @@ func (o *Object) getKV() *kv {
 	return &o.kvs[len(o.kvs)-1]
 }
 
+// keyLess orders the keys of an object like MySQL does in its binary JSON
+// format, which is also the order in which it prints them: shorter keys go
+// first, and keys of the same length are compared byte-wise.
+func keyLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
 func (o *Object) sort() {
 	if len(o.kvs) < 2 {
 		return
 	}
 
 	slices.SortStableFunc(o.kvs, func(a, b kv) bool {
-		return a.k < b.k
+		return keyLess(a.k, b.k)
 	})
 	uniq := o.kvs[:1]
 	for _, kv := range o.kvs[1:] {
@@ -559,7 +641,7 @@ func (o *Object) find(key string) (int, bool) {
 	for i < j {
 		h := int(uint(i+j) >> 1) // avoid overflow when computing h
 		// i ≤ h < j
-		if o.kvs[h].k < key {
+		if keyLess(o.kvs[h].k, key) {
 			i = h + 1 // preserves cmp(x[i - 1], target) < 0
 		} else {
 			j = h // preserves cmp(x[j], target) >= 0
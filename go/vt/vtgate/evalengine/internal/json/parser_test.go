@@ -101,6 +101,7 @@ func TestUnescapeStringBestEffort(t *testing.T) {
 		testUnescapeStringBestEffort(t, `\"x\uyz\"`, `"x\uyz"`)
 		testUnescapeStringBestEffort(t, `\u12\"пролw`, `\u12"пролw`)
 		testUnescapeStringBestEffort(t, `п\ud83eи`, "п\\ud83eи")
+		testUnescapeStringBestEffort(t, `п\ud83e\u00e9и`, "п\\ud83eéи")
 	})
 }
 
@@ -271,9 +272,11 @@ func TestParserParse(t *testing.T) {
 	})
 
 	t.Run("invalid-number", func(t *testing.T) {
-		_, err := p.Parse("123+456")
-		if err != nil {
-			t.Fatalf("unexpected error when parsing int")
+		for _, s := range []string{"123+456", "1-2", "2023-01-05", "01", ".5", "1.", "1e", "-", "NaN", "[inf]"} {
+			_, err := p.Parse(s)
+			if err == nil {
+				t.Fatalf("expecting non-nil error when parsing %q", s)
+			}
 		}
 	})
 
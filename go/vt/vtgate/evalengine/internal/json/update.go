@@ -67,22 +67,39 @@ func (v *Value) SetArrayItem(idx int, value *Value, t Transformation) {
 	if v == nil || v.t != TypeArray || idx < 0 {
 		return
 	}
-	switch t {
-	case Insert:
-		if idx < len(v.a) {
-			return
-		}
-		fallthrough
-	case Set:
-		for idx >= len(v.a) {
-			v.a = append(v.a, ValueNull)
+	// like in MySQL, setting a position past the end of the array appends
+	// the value instead of padding the array up to that position
+	if idx >= len(v.a) {
+		if t == Set || t == Insert {
+			v.a = append(v.a, value)
 		}
+		return
 	}
-	if idx < len(v.a) {
+	if t == Set || t == Replace {
 		v.a[idx] = value
 	}
 }
 
+// InsertArrayItem inserts the value in the array v at idx position, moving
+// the items after it one position forward. Positions past the end of the
+// array append the value, and negative positions insert it at the start.
+//
+// The value must be unchanged during v lifetime.
+func (v *Value) InsertArrayItem(idx int, value *Value) {
+	if v == nil || v.t != TypeArray {
+		return
+	}
+	switch {
+	case idx < 0:
+		idx = 0
+	case idx > len(v.a):
+		idx = len(v.a)
+	}
+	v.a = append(v.a, nil)
+	copy(v.a[idx+1:], v.a[idx:])
+	v.a[idx] = value
+}
+
 func (v *Value) DelArrayItem(n int) {
 	if v == nil || v.t != TypeArray {
 		return
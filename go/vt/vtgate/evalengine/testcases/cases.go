@@ -77,6 +77,71 @@ type FnLength struct{ defaultEnv }
 type FnBitLength struct{ defaultEnv }
 type FnAscii struct{ defaultEnv }
 type FnRepeat struct{ defaultEnv }
+type FnTrim struct{ defaultEnv }
+type FnPow struct{ defaultEnv }
+type FnDateFormat struct{ defaultEnv }
+type FnSqrt struct{ defaultEnv }
+type Floor struct{ defaultEnv }
+type FnExp struct{ defaultEnv }
+type FnLog struct{ defaultEnv }
+type JSONDepth struct{ defaultEnv }
+type FnTrig struct{ defaultEnv }
+type FnInverseTrig struct{ defaultEnv }
+type FnSubstring struct{ defaultEnv }
+type FnSign struct{ defaultEnv }
+type FnRegexpLike struct{ defaultEnv }
+type FnRand struct{ defaultEnv }
+type TemporalArithmetic struct{ defaultEnv }
+type FnConcatWS struct{ defaultEnv }
+type FnTruncate struct{ defaultEnv }
+type FnHex struct{ defaultEnv }
+type FnConv struct{ defaultEnv }
+type JSONUnquote struct{ defaultEnv }
+type FnCrc32 struct{ defaultEnv }
+type FnSecToTime struct{ defaultEnv }
+type TimeConversion struct{ defaultEnv }
+type FnField struct{ defaultEnv }
+type FnDateComponents struct{ defaultEnv }
+type JSONContains struct{ defaultEnv }
+type FnTimeComponents struct{ defaultEnv }
+type FnPad struct{ defaultEnv }
+type FnWeek struct{ defaultEnv }
+type FnBinOct struct{ defaultEnv }
+type FnDayMonthName struct{ defaultEnv }
+type FnRegexpInstr struct{ defaultEnv }
+type FnLastDay struct{ defaultEnv }
+type FnDateMath struct{ defaultEnv }
+type FnSubstringIndex struct{ defaultEnv }
+type FnDateDiff struct{ defaultEnv }
+type FnTimeDiff struct{ defaultEnv }
+type FnStrToDate struct{ defaultEnv }
+type FnFromUnixtime struct{ defaultEnv }
+type FnLocate struct{ defaultEnv }
+type FnUnixTimestamp struct{ defaultEnv }
+type FnChar struct{ defaultEnv }
+type FnMakeDate struct{ defaultEnv }
+type FnMakeTime struct{ defaultEnv }
+type FnReplace struct{ defaultEnv }
+type FnCoalesceTemporal struct{ defaultEnv }
+type FnTimeFormat struct{ defaultEnv }
+type FnTimestampAdd struct{ defaultEnv }
+type FnTimestampDiff struct{ defaultEnv }
+type FnConvertTz struct{ defaultEnv }
+type FnExtract struct{ defaultEnv }
+type FnToDays struct{ defaultEnv }
+type FnMD5 struct{ defaultEnv }
+type FnSHA1 struct{ defaultEnv }
+type FnSHA2 struct{ defaultEnv }
+type Modulo struct{ defaultEnv }
+type FnConcat struct{ defaultEnv }
+type JSONValid struct{ defaultEnv }
+type JSONType struct{ defaultEnv }
+type JSONQuote struct{ defaultEnv }
+type JSONMergePatch struct{ defaultEnv }
+type JSONMergePreserve struct{ defaultEnv }
+type JSONModify struct{ defaultEnv }
+type JSONRemove struct{ defaultEnv }
+type FnFormat struct{ defaultEnv }
 
 var Cases = []TestCase{
 	JSONExtract{},
@@ -114,6 +179,71 @@ var Cases = []TestCase{
 	FnBitLength{},
 	FnAscii{},
 	FnRepeat{},
+	FnTrim{},
+	FnPow{},
+	FnDateFormat{},
+	FnSqrt{},
+	Floor{},
+	FnExp{},
+	FnLog{},
+	JSONDepth{},
+	FnTrig{},
+	FnInverseTrig{},
+	FnSubstring{},
+	FnSign{},
+	FnRegexpLike{},
+	FnRand{},
+	TemporalArithmetic{},
+	FnConcatWS{},
+	FnTruncate{},
+	FnHex{},
+	FnConv{},
+	JSONUnquote{},
+	FnCrc32{},
+	FnSecToTime{},
+	TimeConversion{},
+	FnField{},
+	FnDateComponents{},
+	JSONContains{},
+	FnTimeComponents{},
+	FnPad{},
+	FnWeek{},
+	FnBinOct{},
+	FnDayMonthName{},
+	FnRegexpInstr{},
+	FnLastDay{},
+	FnDateMath{},
+	FnSubstringIndex{},
+	FnDateDiff{},
+	FnTimeDiff{},
+	FnStrToDate{},
+	FnFromUnixtime{},
+	FnLocate{},
+	FnUnixTimestamp{},
+	FnChar{},
+	FnMakeDate{},
+	FnMakeTime{},
+	FnReplace{},
+	FnCoalesceTemporal{},
+	FnTimeFormat{},
+	FnTimestampAdd{},
+	FnTimestampDiff{},
+	FnConvertTz{},
+	FnExtract{},
+	FnToDays{},
+	FnMD5{},
+	FnSHA1{},
+	FnSHA2{},
+	Modulo{},
+	FnConcat{},
+	JSONValid{},
+	JSONType{},
+	JSONQuote{},
+	JSONMergePatch{},
+	JSONMergePreserve{},
+	JSONModify{},
+	JSONRemove{},
+	FnFormat{},
 }
 
 func (JSONPathOperations) Test(yield Iterator) {
@@ -216,6 +346,10 @@ func (Ceil) Test(yield Iterator) {
 		"-1.5e0",
 		"9223372036854775810.4",
 		"-9223372036854775810.4",
+		"99999999999999999999999999999999999999999999999999999999999999999",
+		"-99999999999999999999999999999999999999999999999999999999999999999",
+		"9999999999999999999999999999999999999999999999999999999999999999.5",
+		"-9999999999999999999999999999999999999999999999999999999999999999.5",
 	}
 
 	for _, num := range ceilInputs {
@@ -224,6 +358,29 @@ func (Ceil) Test(yield Iterator) {
 	}
 }
 
+func (Floor) Test(yield Iterator) {
+	var floorInputs = []string{
+		"0",
+		"1",
+		"-1",
+		"'1.5'",
+		"NULL",
+		"'ABC'",
+		"1.5e0",
+		"-1.5e0",
+		"9223372036854775810.4",
+		"-9223372036854775810.4",
+		"99999999999999999999999999999999999999999999999999999999999999999",
+		"-99999999999999999999999999999999999999999999999999999999999999999",
+		"9999999999999999999999999999999999999999999999999999999999999999.5",
+		"-9999999999999999999999999999999999999999999999999999999999999999.5",
+	}
+
+	for _, num := range floorInputs {
+		yield(fmt.Sprintf("FLOOR(%s)", num), nil)
+	}
+}
+
 // HACK: for CASE comparisons, the expression is supposed to decompose like this:
 //
 //	CASE a WHEN b THEN bb WHEN c THEN cc ELSE d
@@ -328,6 +485,11 @@ func (DecimalClamping) Test(yield Iterator) {
 			}
 		}
 	}
+	for _, half := range []string{"0.5", "-0.5", "2.5", "-2.5", "1.25", "-1.25", "9.5", "-9.5"} {
+		yield(fmt.Sprintf("CAST(%s AS DECIMAL)", half), nil)
+		yield(fmt.Sprintf("CAST(%s AS DECIMAL(1, 0))", half), nil)
+		yield(fmt.Sprintf("CAST(%s AS DECIMAL(3, 1))", half), nil)
+	}
 }
 
 func (BitwiseOperatorsUnary) Test(yield Iterator) {
@@ -352,6 +514,7 @@ func (WeightString) Test(yield Iterator) {
 	var inputs = []string{
 		`'foobar'`, `_latin1 'foobar'`,
 		`'foobar' as char(12)`, `'foobar' as binary(12)`,
+		`'foobar' as binary(3)`, `'' as binary(2)`,
 		`_latin1 'foobar' as char(12)`, `_latin1 'foobar' as binary(12)`,
 		`1234.0`, `12340e0`,
 		`0x1234`, `0x1234 as char(12)`, `0x1234 as char(2)`,
@@ -498,6 +661,7 @@ func (NegateArithmetic) Test(yield Iterator) {
 		strconv.FormatUint(math.MaxUint64, 10),
 		strconv.FormatUint(math.MaxInt64, 10),
 		strconv.FormatInt(math.MinInt64, 10),
+		`CAST(1 AS UNSIGNED)`, `CAST(-1 AS UNSIGNED)`,
 		`'foobar'`, `'FOOBAR'`,
 	}
 
@@ -546,7 +710,7 @@ func (LikeComparison) Test(yield Iterator) {
 
 func (MultiComparisons) Test(yield Iterator) {
 	var numbers = []string{
-		`0`, `-1`, `1`, `0.0`, `1.0`, `-1.0`, `1.0E0`, `-1.0E0`, `0.0E0`,
+		`0`, `-1`, `1`, `0.0`, `1.0`, `-1.0`, `1.0E0`, `-1.0E0`, `0.0E0`, `2.5`, `-0.125`,
 		strconv.FormatUint(math.MaxUint64, 10),
 		strconv.FormatUint(math.MaxInt64, 10),
 		strconv.FormatInt(math.MinInt64, 10),
@@ -727,6 +891,9 @@ func (FnAscii) Test(yield Iterator) {
 	for _, str := range inputStrings {
 		yield(fmt.Sprintf("ASCII(%s)", str), nil)
 	}
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("ASCII(%s)", num), nil)
+	}
 }
 
 func (FnRepeat) Test(yield Iterator) {
@@ -737,3 +904,855 @@ func (FnRepeat) Test(yield Iterator) {
 		}
 	}
 }
+
+func (FnTrim) Test(yield Iterator) {
+	inputs := append([]string{
+		"'  abc  '", "'\\tabc\\t'", "'\\nabc\\n'", "' \\t abc \\n '", "'xxabcxx'",
+	}, inputStrings...)
+
+	for _, str := range inputs {
+		yield(fmt.Sprintf("TRIM(%s)", str), nil)
+		yield(fmt.Sprintf("LTRIM(%s)", str), nil)
+		yield(fmt.Sprintf("RTRIM(%s)", str), nil)
+		yield(fmt.Sprintf("TRIM(BOTH FROM %s)", str), nil)
+		yield(fmt.Sprintf("TRIM(LEADING 'x' FROM %s)", str), nil)
+		yield(fmt.Sprintf("TRIM(TRAILING 'x' FROM %s)", str), nil)
+	}
+	for _, remstr := range []string{"'xy'", "'xyz'", "'ab'", "''"} {
+		for _, str := range []string{"'xyzxyzab'", "'xyxxyab'", "'abxyxy'", "'xyxyx'"} {
+			yield(fmt.Sprintf("TRIM(%s FROM %s)", remstr, str), nil)
+			yield(fmt.Sprintf("TRIM(LEADING %s FROM %s)", remstr, str), nil)
+			yield(fmt.Sprintf("TRIM(TRAILING %s FROM %s)", remstr, str), nil)
+		}
+	}
+}
+
+func (FnSubstring) Test(yield Iterator) {
+	positions := []string{"0", "1", "3", "-2", "-100", "100", "1.5", "NULL", "LENGTH(%s) - 2"}
+	counts := []string{"0", "2", "-1", "100", "NULL", "CHAR_LENGTH(%s) - 3"}
+
+	for _, str := range inputStrings {
+		for _, pos := range positions {
+			if strings.Contains(pos, "%s") {
+				pos = fmt.Sprintf(pos, str)
+			}
+			yield(fmt.Sprintf("SUBSTRING(%s, %s)", str, pos), nil)
+			yield(fmt.Sprintf("SUBSTRING(%s FROM %s)", str, pos), nil)
+			for _, cnt := range counts {
+				if strings.Contains(cnt, "%s") {
+					cnt = fmt.Sprintf(cnt, str)
+				}
+				yield(fmt.Sprintf("SUBSTRING(%s FROM %s FOR %s)", str, pos, cnt), nil)
+			}
+		}
+	}
+}
+
+func (FnSign) Test(yield Iterator) {
+	for _, num := range inputMath {
+		yield(fmt.Sprintf("SIGN(%s)", num), nil)
+	}
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("SIGN(%s)", num), nil)
+	}
+}
+
+func (FnRegexpLike) Test(yield Iterator) {
+	inputs := []string{
+		"'Vitess'",
+		"'Vitess' COLLATE utf8mb4_0900_ai_ci",
+		"'Vitess' COLLATE utf8mb4_0900_as_cs",
+		"'Vitess' COLLATE utf8mb4_bin",
+		"_latin1 'Vitess' COLLATE latin1_swedish_ci",
+		"_latin1 'Vitess' COLLATE latin1_bin",
+		"NULL",
+	}
+	patterns := []string{"'vitess'", "'^VIT'", "'ess$'", "'V.*s'", "NULL"}
+	matchTypes := []string{"'c'", "'i'", "'ci'", "'ic'", "NULL"}
+
+	for _, in := range inputs {
+		for _, pat := range patterns {
+			yield(fmt.Sprintf("REGEXP_LIKE(%s, %s)", in, pat), nil)
+			yield(fmt.Sprintf("%s REGEXP %s", in, pat), nil)
+			yield(fmt.Sprintf("%s NOT REGEXP %s", in, pat), nil)
+			for _, mt := range matchTypes {
+				yield(fmt.Sprintf("REGEXP_LIKE(%s, %s, %s)", in, pat, mt), nil)
+			}
+		}
+	}
+}
+
+func (FnRand) Test(yield Iterator) {
+	// the unseeded form is not deterministic, so only seeds can be compared
+	for _, seed := range inputMath {
+		yield(fmt.Sprintf("RAND(%s)", seed), nil)
+	}
+	for _, seed := range []string{"2", "3", "255", "65536", "4294967295", "4294967296", "-4294967296", "9223372036854775807"} {
+		yield(fmt.Sprintf("RAND(%s)", seed), nil)
+	}
+}
+
+func (TemporalArithmetic) Test(yield Iterator) {
+	temporals := []string{
+		"DATE '2023-02-28'", "DATE '2023-03-01'", "DATE '2023-12-31'", "DATE '2024-01-01'",
+		"TIMESTAMP '2023-01-01 00:00:59'", "TIMESTAMP '2023-01-01 00:01:00'",
+		"TIME '09:59:59'", "TIME '10:00:00'",
+	}
+	for _, lhs := range temporals {
+		yield(fmt.Sprintf("%s + 0", lhs), nil)
+		yield(fmt.Sprintf("-%s", lhs), nil)
+		for _, rhs := range temporals {
+			yield(fmt.Sprintf("%s - %s", lhs, rhs), nil)
+		}
+	}
+}
+
+func (FnConcatWS) Test(yield Iterator) {
+	separators := []string{"','", "''", "NULL", "_binary '-'", "_latin1 ', '"}
+	values := append([]string{"NULL", "''", "1", "2.5"}, inputStrings...)
+
+	for _, sep := range separators {
+		genSubsets(values, 3, func(args []string) {
+			yield(fmt.Sprintf("CONCAT_WS(%s, %s, %s, %s)", sep, args[0], args[1], args[2]), nil)
+		})
+	}
+}
+
+func (FnTruncate) Test(yield Iterator) {
+	decimals := []string{"0", "1", "2", "5", "-1", "-2", "-20", "'1'", "1.5", "NULL", "18446744073709551615"}
+	for _, num := range inputMath {
+		for _, d := range decimals {
+			yield(fmt.Sprintf("TRUNCATE(%s, %s)", num, d), nil)
+		}
+	}
+	for _, num := range []string{
+		"DATE '2023-06-15'", "TIMESTAMP '2023-06-15 01:02:03'", "TIMESTAMP '2023-06-15 01:02:03.456'",
+		"TIME '10:20:30'", "TIME '10:20:30.5'",
+	} {
+		for _, d := range []string{"0", "1", "-2"} {
+			yield(fmt.Sprintf("TRUNCATE(%s, %s)", num, d), nil)
+		}
+	}
+}
+
+func (FnHex) Test(yield Iterator) {
+	for _, num := range inputMath {
+		yield(fmt.Sprintf("HEX(%s)", num), nil)
+	}
+	for _, num := range []string{
+		"-2", "-255", "-9223372036854775808", "-9223372036854775809",
+		"18446744073709551615", "18446744073709551616", "-1e30", "1e30", "2.5", "-2.5",
+	} {
+		yield(fmt.Sprintf("HEX(%s)", num), nil)
+	}
+}
+
+func (FnConv) Test(yield Iterator) {
+	for _, num := range inputMath {
+		yield(fmt.Sprintf("CONV(%s, 10, 16)", num), nil)
+		yield(fmt.Sprintf("CONV(%s, 10, -16)", num), nil)
+	}
+	for _, num := range []string{"'FF'", "'ff'", "'7fffffffffffffff'", "'ffffffffffffffffffff'", "'-ff'", "'1g'", "'zz'", "'hello'", "'Hello'"} {
+		for _, bases := range []string{"16, 2", "16, 10", "-16, -10", "36, 10", "16, 36", "36, 36", "36, -36"} {
+			yield(fmt.Sprintf("CONV(%s, %s)", num, bases), nil)
+		}
+	}
+	for _, num := range []string{"''", "'xyz'", "'-'", "' '", "'.5'"} {
+		yield(fmt.Sprintf("CONV(%s, 10, 16)", num), nil)
+		yield(fmt.Sprintf("CONV(%s, 16, 2)", num), nil)
+	}
+	for _, bases := range []string{"1, 10", "10, 1", "37, 10", "10, 37", "-37, 10", "10, -1", "NULL, 10", "10, NULL"} {
+		yield(fmt.Sprintf("CONV(10, %s)", bases), nil)
+	}
+}
+
+func (JSONUnquote) Test(yield Iterator) {
+	for _, str := range []string{
+		`'"café"'`, `'"caf\\u00e9"'`, `'"\\u65e5\\u672c"'`, `'"\\ud83d\\ude0a"'`,
+		`'"中文测试"'`, `'"a\\tb\\n"'`, `'"\\u0041"'`, `'[1, "é"]'`, `'"é"'`, `NULL`,
+	} {
+		yield(fmt.Sprintf("JSON_UNQUOTE(%s)", str), nil)
+	}
+}
+
+func (FnCrc32) Test(yield Iterator) {
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("CRC32(%s)", str), nil)
+	}
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("CRC32(%s)", num), nil)
+	}
+}
+
+func (FnSecToTime) Test(yield Iterator) {
+	for _, sec := range []string{"0", "3661", "3661.5", "-3661.5", "3661.25", "0.1234567", "-0.75", "3661.5e0", "'3661.5'", "86400", "90061", "-90061", "3020399", "3020400", "3020399.5", "-3020400", "18446744073709551615", "NULL"} {
+		yield(fmt.Sprintf("SEC_TO_TIME(%s)", sec), nil)
+		yield(fmt.Sprintf("TIME_TO_SEC(SEC_TO_TIME(%s))", sec), nil)
+	}
+	for _, t := range []string{"'01:01:01'", "'01:01:01.5'", "'-01:01:01.250'", "TIME '12:34:56.789'", "10101.5", "'25:01:01'", "'-25:01:01'", "'838:59:59'", "'2023-06-15 10:00:00'", "NULL"} {
+		yield(fmt.Sprintf("TIME_TO_SEC(%s)", t), nil)
+		yield(fmt.Sprintf("SEC_TO_TIME(TIME_TO_SEC(%s))", t), nil)
+	}
+}
+
+func (TimeConversion) Test(yield Iterator) {
+	for _, t := range []string{
+		"'01:00:00'", "'-01:00:00'", "'-838:59:59'", "'12:34:56.5'", "'12:34:59.5'", "'-01:00:00.5'",
+		"'12:34:56.123456'", "'2023-06-15 12:34:56'", "'not a time'", "-10203", "10203.5", "NULL",
+	} {
+		for _, as := range []string{"TIME", "TIME(1)", "TIME(3)", "TIME(6)"} {
+			yield(fmt.Sprintf("CAST(%s AS %s)", t, as), nil)
+			yield(fmt.Sprintf("CAST(CAST(%s AS %s) AS CHAR)", t, as), nil)
+		}
+	}
+}
+
+func (FnField) Test(yield Iterator) {
+	for _, needle := range []string{"1", "'1'", "'01'", "1.0", "1.0e0", "'a'", "'A'", "_binary 'a'", "0", "NULL"} {
+		yield(fmt.Sprintf("FIELD(%s, '01', '1', 1, 'a')", needle), nil)
+		yield(fmt.Sprintf("FIELD(%s, 'a', '01', '1')", needle), nil)
+		yield(fmt.Sprintf("FIELD(%s, NULL, 1.00, 'A')", needle), nil)
+		yield(fmt.Sprintf("FIELD(%s, 2, 1)", needle), nil)
+	}
+}
+
+func (FnDateComponents) Test(yield Iterator) {
+	for _, fn := range []string{"YEAR", "MONTH", "DAY", "DAYOFMONTH", "DAYOFWEEK", "WEEKDAY", "DAYOFYEAR", "QUARTER"} {
+		for _, d := range inputDateTimes {
+			yield(fmt.Sprintf("%s(%s)", fn, d), nil)
+		}
+		for _, d := range []string{"'2023-00-15'", "'2023-13-01'", "'2023-02-30'", "'23-06-15'", "20230615", "'2024-12-31'", "'2023-06-18'", "'2023-03-31'", "'2023-04-01'", "'2023-10-01'"} {
+			yield(fmt.Sprintf("%s(%s)", fn, d), nil)
+		}
+		for _, d := range []string{"TIME '10:20:30'", "CAST('25:00:00' AS TIME)", "CAST('-10:00:00' AS TIME)"} {
+			yield(fmt.Sprintf("%s(%s)", fn, d), nil)
+		}
+	}
+}
+
+func (JSONContains) Test(yield Iterator) {
+	const doc = `'{"a": 1, "b": [1, 2, {"c": 3}], "d": {"e": "x"}}'`
+	for _, candidate := range []string{`'1'`, `'2'`, `'[2, 1]'`, `'{"c": 3}'`, `'"x"'`, `'{"e": "x"}'`} {
+		for _, path := range []string{"'$'", "'$.a'", "'$.b'", "'$.d'", "'$.d.e'", "'$.missing'", "'$.b[10]'", "NULL"} {
+			yield(fmt.Sprintf("JSON_CONTAINS(%s, %s, %s)", doc, candidate, path), nil)
+		}
+	}
+}
+
+func (FnTimeComponents) Test(yield Iterator) {
+	for _, fn := range []string{"HOUR", "MINUTE", "SECOND", "MICROSECOND"} {
+		for _, d := range inputDateTimes {
+			yield(fmt.Sprintf("%s(%s)", fn, d), nil)
+		}
+		for _, t := range []string{"'10:20:30'", "'838:59:59'", "'-838:59:59'", "'900:00:00'", "'2 10:20:30'", "'10:20:30.123456'", "'10:61:00'", "102030", "102030.5", "TIME '10:20:30.25'"} {
+			yield(fmt.Sprintf("%s(%s)", fn, t), nil)
+		}
+	}
+}
+
+func (FnPad) Test(yield Iterator) {
+	for _, fn := range []string{"LPAD", "RPAD"} {
+		for _, str := range inputStrings {
+			for _, length := range []string{"-1", "0", "3", "10", "NULL", "'5'", "2.5"} {
+				for _, pad := range []string{"'x'", "'ab'", "''", "NULL", "'é'", "_binary 'z'"} {
+					yield(fmt.Sprintf("%s(%s, %s, %s)", fn, str, length, pad), nil)
+				}
+			}
+		}
+	}
+}
+
+func (FnWeek) Test(yield Iterator) {
+	dates := append([]string{"'2000-01-01'", "'2008-12-31'", "'2021-01-03'", "'2024-12-30'", "'2027-01-01'"}, inputDateTimes...)
+	for _, d := range dates {
+		yield(fmt.Sprintf("WEEK(%s)", d), nil)
+		yield(fmt.Sprintf("WEEKOFYEAR(%s)", d), nil)
+		for mode := 0; mode < 8; mode++ {
+			yield(fmt.Sprintf("WEEK(%s, %d)", d, mode), nil)
+		}
+		yield(fmt.Sprintf("WEEK(%s, NULL)", d), nil)
+	}
+}
+
+func (FnBinOct) Test(yield Iterator) {
+	for _, fn := range []string{"BIN", "OCT"} {
+		for _, num := range inputMath {
+			yield(fmt.Sprintf("%s(%s)", fn, num), nil)
+		}
+		for _, num := range inputBitwise {
+			yield(fmt.Sprintf("%s(%s)", fn, num), nil)
+		}
+		for _, num := range []string{"''", "'xyz'", "'-'"} {
+			yield(fmt.Sprintf("%s(%s)", fn, num), nil)
+		}
+	}
+}
+
+func (FnDayMonthName) Test(yield Iterator) {
+	for _, fn := range []string{"DAYNAME", "MONTHNAME"} {
+		for _, d := range inputDateTimes {
+			yield(fmt.Sprintf("%s(%s)", fn, d), nil)
+		}
+		for _, d := range []string{"'2023-05-00'", "'2023-00-15'", "'2023-02-30'", "'2024-02-29'", "20231201"} {
+			yield(fmt.Sprintf("%s(%s)", fn, d), nil)
+		}
+	}
+}
+
+func (FnRegexpInstr) Test(yield Iterator) {
+	inputs := []string{"'dog cat dog'", "'Dog Cat Dog' COLLATE utf8mb4_bin", "'ñandú ñu'", "NULL"}
+	patterns := []string{"'dog'", "'cat'", "'d.g'", "''", "NULL"}
+	options := []string{"1", "2", "4", "1, 2", "1, 3", "1, 1, 0", "1, 1, 1", "1, 2, 1", "2, 1, 1, 'i'", "1, 1, NULL"}
+
+	for _, in := range inputs {
+		for _, pat := range patterns {
+			yield(fmt.Sprintf("REGEXP_INSTR(%s, %s)", in, pat), nil)
+			for _, opt := range options {
+				yield(fmt.Sprintf("REGEXP_INSTR(%s, %s, %s)", in, pat, opt), nil)
+			}
+		}
+	}
+}
+
+func (FnLastDay) Test(yield Iterator) {
+	for _, d := range inputDateTimes {
+		yield(fmt.Sprintf("LAST_DAY(%s)", d), nil)
+	}
+	for _, d := range []string{"'2024-02-10'", "'2023-02-10'", "'1900-02-01'", "'2023-04-15'", "'2023-09-00'", "'2023-00-15'", "'2023-02-30'"} {
+		yield(fmt.Sprintf("LAST_DAY(%s)", d), nil)
+	}
+}
+
+func (FnDateMath) Test(yield Iterator) {
+	var intervals = []string{
+		"1 MICROSECOND", "1 SECOND", "1.5 SECOND", "0.5 SECOND", "'0.5' SECOND", "0.5e0 SECOND", "-1 MINUTE", "1 HOUR", "1 DAY", "-1 DAY", "1 WEEK",
+		"1 MONTH", "1 QUARTER", "1 YEAR", "-1 YEAR", "NULL DAY",
+		"'1.5' SECOND_MICROSECOND", "'1:2.5' MINUTE_MICROSECOND", "'1:2' MINUTE_SECOND",
+		"'1:2:3.5' HOUR_MICROSECOND", "'1:2:3' HOUR_SECOND", "'1:2' HOUR_MINUTE",
+		"'1 2:3:4.5' DAY_MICROSECOND", "'1 2:3:4' DAY_SECOND", "'1 2:3' DAY_MINUTE", "'1 2' DAY_HOUR",
+		"'1-2' YEAR_MONTH", "'-1 10' DAY_HOUR", "'1:1' DAY_SECOND",
+	}
+	var dates = append(inputDateTimes, "'2023-01-31'", "'2024-02-29'", "'9999-12-31 23:59:59'", "TIME '10:30:00'")
+	for _, d := range dates {
+		for _, i := range intervals {
+			yield(fmt.Sprintf("DATE_ADD(%s, INTERVAL %s)", d, i), nil)
+			yield(fmt.Sprintf("DATE_SUB(%s, INTERVAL %s)", d, i), nil)
+		}
+	}
+	for _, d := range dates {
+		yield(fmt.Sprintf("%s + INTERVAL 1 DAY", d), nil)
+		yield(fmt.Sprintf("%s - INTERVAL 1 DAY", d), nil)
+		yield(fmt.Sprintf("ADDDATE(%s, 31)", d), nil)
+		yield(fmt.Sprintf("SUBDATE(%s, 31)", d), nil)
+	}
+}
+
+func (FnSubstringIndex) Test(yield Iterator) {
+	var inputs = append(inputStrings, "'www.mysql.com'", "'a.b.c' COLLATE utf8mb4_bin", "_latin1 'a.b'")
+	for _, str := range inputs {
+		for _, delim := range []string{"'.'", "'b'", "'B'", "''", "NULL", "1", "_binary '.'"} {
+			for _, count := range []string{"0", "1", "2", "-1", "-2", "10", "-10", "NULL", "'1'", "1.5"} {
+				yield(fmt.Sprintf("SUBSTRING_INDEX(%s, %s, %s)", str, delim, count), nil)
+			}
+		}
+	}
+}
+
+func (FnDateDiff) Test(yield Iterator) {
+	var dates = append(inputDateTimes, "'2024-03-01'", "'0001-01-01'", "'9999-12-31 23:59:59'", "'2023-02-30'", "'2023-01-01 23:59:59'")
+	for _, d1 := range dates {
+		for _, d2 := range dates {
+			yield(fmt.Sprintf("DATEDIFF(%s, %s)", d1, d2), nil)
+		}
+	}
+}
+
+func (FnTimeDiff) Test(yield Iterator) {
+	var inputs = append(inputDateTimes,
+		"'10:00:00'", "'-01:30:00.5'", "'838:59:59'", "TIME '08:30:00.25'", "100000", "83000.5",
+		"'2000-01-01 00:00:00'", "'2023-01-01 10:00:00.000001'",
+	)
+	for _, t1 := range inputs {
+		for _, t2 := range inputs {
+			yield(fmt.Sprintf("TIMEDIFF(%s, %s)", t1, t2), nil)
+		}
+	}
+}
+
+func (FnStrToDate) Test(yield Iterator) {
+	var inputs = []struct {
+		value, format string
+	}{
+		{"'2023-06-15'", "'%Y-%m-%d'"},
+		{"'15/6/23'", "'%d/%c/%y'"},
+		{"'June 15th, 2023'", "'%M %D, %Y'"},
+		{"'Thu Jun 15 2023'", "'%a %b %e %Y'"},
+		{"'2023-06-15 03:30:45 PM'", "'%Y-%m-%d %h:%i:%s %p'"},
+		{"'2023-06-15 12:00:00 am'", "'%Y-%m-%d %r'"},
+		{"'2023-06-15 15:30:45.123'", "'%Y-%m-%d %T.%f'"},
+		{"'15:30:45'", "'%H:%i:%s'"},
+		{"'03:30:45 PM'", "'%r'"},
+		{"'2023166'", "'%Y%j'"},
+		{"'202324 Thursday'", "'%X%V %W'"},
+		{"'2023 24 4'", "'%x %v %w'"},
+		{"'2023 24 Thu'", "'%Y %U %a'"},
+		{"'2023-06-15 extra'", "'%Y-%m-%d'"},
+		{"'2023/06/15'", "'%Y-%m-%d'"},
+		{"'2023-02-30'", "'%Y-%m-%d'"},
+		{"'2023-06'", "'%Y-%m-%d'"},
+		{"'2023-06'", "'%Y-%m'"},
+		{"'00/00/0000'", "'%m/%d/%Y'"},
+		{"'13:00 PM'", "'%h:%i %p'"},
+		{"'foo'", "'%Y'"},
+		{"'2023-06-15'", "NULL"},
+		{"NULL", "'%Y-%m-%d'"},
+	}
+	for _, in := range inputs {
+		yield(fmt.Sprintf("STR_TO_DATE(%s, %s)", in.value, in.format), nil)
+	}
+}
+
+func (FnFromUnixtime) Test(yield Iterator) {
+	var timestamps = []string{
+		"0", "1", "1686843045", "1686843045.5", "1686843045.123456789", "1686843045.25e0",
+		"'1686843045'", "'1686843045.5'", "-1", "-0.5", "32536771199", "32536771200",
+		"18446744073709551615", "NULL", "'foo'",
+	}
+	for _, ts := range timestamps {
+		yield(fmt.Sprintf("FROM_UNIXTIME(%s)", ts), nil)
+		for _, format := range inputDateFormats {
+			yield(fmt.Sprintf("FROM_UNIXTIME(%s, %s)", ts, format), nil)
+		}
+	}
+}
+
+func (FnLocate) Test(yield Iterator) {
+	var substrings = []string{
+		"''", "'a'", "'A'", "'bc'", "'ú'", "_binary 'ú'", "X'C3BA'", "'テ'", "1", "NULL",
+	}
+	var inputs = append(inputStrings,
+		"'abcabc'", "'ñandú'", "_binary 'ñandú'", "'ÑANDÚ' COLLATE utf8mb4_bin", "_latin1 'abc'",
+	)
+	for _, sub := range substrings {
+		for _, str := range inputs {
+			yield(fmt.Sprintf("LOCATE(%s, %s)", sub, str), nil)
+			yield(fmt.Sprintf("INSTR(%s, %s)", str, sub), nil)
+			yield(fmt.Sprintf("POSITION(%s IN %s)", sub, str), nil)
+			for _, pos := range []string{"-1", "0", "1", "2", "4", "100", "NULL"} {
+				yield(fmt.Sprintf("LOCATE(%s, %s, %s)", sub, str, pos), nil)
+			}
+		}
+	}
+}
+
+func (FnUnixTimestamp) Test(yield Iterator) {
+	var inputs = append(inputDateTimes,
+		"'1970-01-01 00:00:00'", "'1969-12-31 23:59:59.5'", "'3001-01-18 23:59:59'", "'3001-01-19 00:00:00'",
+		"'2023-06-15 15:30:45.5'", "20230615153045.25", "TIMESTAMP '2023-06-15 01:02:03.123'",
+	)
+	for _, d := range inputs {
+		yield(fmt.Sprintf("UNIX_TIMESTAMP(%s)", d), nil)
+	}
+}
+
+func (FnChar) Test(yield Iterator) {
+	var inputs = append(inputConversions,
+		"65", "256", "65535", "65536", "16777215", "16777216", "4294967295", "4294967296", "-1", "0xE282AC",
+	)
+	for _, in := range inputs {
+		yield(fmt.Sprintf("CHAR(%s)", in), nil)
+		yield(fmt.Sprintf("CHAR(%s, 66, NULL)", in), nil)
+		yield(fmt.Sprintf("CHAR(%s USING utf8mb4)", in), nil)
+		yield(fmt.Sprintf("CHAR(%s USING latin1)", in), nil)
+	}
+}
+
+func (FnMakeDate) Test(yield Iterator) {
+	var years = []string{"0", "23", "69", "70", "99", "100", "2023", "2024", "9999", "10000", "-1", "'2023'", "2023.5", "NULL"}
+	var days = []string{"-1", "0", "1", "59", "60", "365", "366", "730", "1000", "3652424", "'60'", "1.5", "NULL"}
+	for _, y := range years {
+		for _, d := range days {
+			yield(fmt.Sprintf("MAKEDATE(%s, %s)", y, d), nil)
+		}
+	}
+}
+
+func (FnMakeTime) Test(yield Iterator) {
+	var hours = []string{"0", "12", "23", "24", "100", "838", "839", "-1", "-839", "18446744073709551615", "'12'", "1.5", "NULL"}
+	var minutes = []string{"0", "30", "59", "60", "-1", "NULL"}
+	var seconds = []string{"0", "30", "59", "60", "-1", "30.5", "59.9999999", "'30.25'", "30.5e0", "NULL"}
+	for _, h := range hours {
+		for _, m := range minutes {
+			for _, s := range seconds {
+				yield(fmt.Sprintf("MAKETIME(%s, %s, %s)", h, m, s), nil)
+			}
+		}
+	}
+}
+
+func (FnReplace) Test(yield Iterator) {
+	var cases = []struct {
+		str, from, to string
+	}{
+		{"'www.mysql.com'", "'w'", "'Ww'"},
+		{"'aaa'", "'aa'", "'b'"},
+		{"'abc'", "''", "'x'"},
+		{"'ABC'", "'b'", "'x'"},
+		{"'ñandú'", "'ú'", "'u'"},
+		{"123", "2", "9"},
+		{"_binary 'abc'", "'b'", "'x'"},
+		{"_latin1 'abc'", "'b'", "'ü'"},
+		{"'abc' COLLATE utf8mb4_bin", "'b'", "'x'"},
+		{"'abc'", "'b' COLLATE utf8mb4_bin", "'x'"},
+		{"'abc'", "'b'", "'x' COLLATE utf8mb4_bin"},
+		{"'abc' COLLATE utf8mb4_bin", "'b' COLLATE utf8mb4_general_ci", "'x'"},
+		{"'abc' COLLATE utf8mb4_bin", "'b'", "'x' COLLATE utf8mb4_general_ci"},
+		{"NULL", "'b'", "'x'"},
+		{"'abc'", "NULL", "'x'"},
+		{"'abc'", "'b'", "NULL"},
+	}
+	for _, tc := range cases {
+		yield(fmt.Sprintf("REPLACE(%s, %s, %s)", tc.str, tc.from, tc.to), nil)
+	}
+}
+
+func (FnCoalesceTemporal) Test(yield Iterator) {
+	var args = []string{
+		"NULL",
+		"CAST('2023-01-01' AS DATE)",
+		"CAST('2023-01-01 12:34:56.5' AS DATETIME)",
+		"CAST('2023-01-01 12:34:56.5' AS DATETIME(2))",
+		"CAST('12:34:56' AS TIME)",
+		"TIMESTAMP '2023-06-15 01:02:03'",
+	}
+
+	for _, a := range args {
+		for _, b := range args {
+			yield(fmt.Sprintf("COALESCE(%s, %s)", a, b), nil)
+		}
+	}
+}
+
+func (FnTimeFormat) Test(yield Iterator) {
+	var times = []string{
+		"NULL", "'10:20:30'", "'10:20:30.123456'", "'23:59:59'", "'00:00:00'",
+		"'2023-01-01'", "'2023-01-01 10:20:30'", "'not a time'", "102030",
+		"CAST('22:00:00' AS TIME)", "'838:59:59'", "'-10:20:30'", "'100:00:00'",
+	}
+	var formats = []string{"'%H:%i:%s'", "'%h %I %l %p'", "'%r'", "'%T.%f'", "'%k %S %%'", "'%Y'", "'%H %d'"}
+
+	for _, t := range times {
+		for _, f := range formats {
+			yield(fmt.Sprintf("TIME_FORMAT(%s, %s)", t, f), nil)
+		}
+	}
+}
+
+func (FnTimestampAdd) Test(yield Iterator) {
+	var units = []string{"MICROSECOND", "SECOND", "MINUTE", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR"}
+	var values = []string{"1", "-1", "1500000", "NULL"}
+
+	for _, unit := range units {
+		for _, v := range values {
+			for _, d := range inputDateTimes {
+				yield(fmt.Sprintf("TIMESTAMPADD(%s, %s, %s)", unit, v, d), nil)
+			}
+		}
+	}
+}
+
+func (FnTimestampDiff) Test(yield Iterator) {
+	var units = []string{"MICROSECOND", "SECOND", "MINUTE", "HOUR", "DAY", "WEEK", "MONTH", "QUARTER", "YEAR"}
+
+	for _, unit := range units {
+		for _, d1 := range inputDateTimes {
+			for _, d2 := range inputDateTimes {
+				yield(fmt.Sprintf("TIMESTAMPDIFF(%s, %s, %s)", unit, d1, d2), nil)
+			}
+		}
+	}
+}
+
+func (FnConvertTz) Test(yield Iterator) {
+	var zones = []string{
+		"NULL", "'+00:00'", "'+05:30'", "'-13:59'", "'+14:00'", "'+14:01'", "'01:00'",
+		"'UTC'", "'Europe/Amsterdam'", "'America/New_York'", "'Not/AZone'",
+	}
+
+	for _, d := range inputDateTimes {
+		for _, from := range zones {
+			for _, to := range zones {
+				yield(fmt.Sprintf("CONVERT_TZ(%s, %s, %s)", d, from, to), nil)
+			}
+		}
+	}
+}
+
+func (FnExtract) Test(yield Iterator) {
+	var units = []string{
+		"YEAR", "QUARTER", "MONTH", "DAY", "HOUR", "MINUTE", "SECOND", "MICROSECOND",
+		"YEAR_MONTH", "DAY_HOUR", "DAY_MINUTE", "DAY_SECOND", "HOUR_MINUTE", "HOUR_SECOND",
+		"MINUTE_SECOND", "DAY_MICROSECOND", "HOUR_MICROSECOND", "MINUTE_MICROSECOND",
+		"SECOND_MICROSECOND",
+	}
+	var args = append([]string{"'10:20:30'", "'-10:20:30.5'", "CAST('100:20:30' AS TIME)", "102030"}, inputDateTimes...)
+
+	for _, unit := range units {
+		for _, arg := range args {
+			yield(fmt.Sprintf("EXTRACT(%s FROM %s)", unit, arg), nil)
+		}
+	}
+}
+
+func (FnToDays) Test(yield Iterator) {
+	var dates = append(inputDateTimes, "'0000-01-01'", "'0000-12-31'", "'0001-01-01'", "'9999-12-31 23:59:59.999999'", "950501", "'2023-02-30'")
+	for _, d := range dates {
+		yield(fmt.Sprintf("TO_DAYS(%s)", d), nil)
+		yield(fmt.Sprintf("TO_SECONDS(%s)", d), nil)
+		yield(fmt.Sprintf("FROM_DAYS(TO_DAYS(%s))", d), nil)
+	}
+
+	var days = []string{"NULL", "-1", "0", "1", "365", "366", "730669", "3652424", "3652425", "3652499", "3652500", "'730669'", "730669.5"}
+	for _, n := range days {
+		yield(fmt.Sprintf("FROM_DAYS(%s)", n), nil)
+	}
+}
+
+func (FnMD5) Test(yield Iterator) {
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("MD5(%s)", str), nil)
+	}
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("MD5(%s)", num), nil)
+	}
+}
+
+func (FnSHA1) Test(yield Iterator) {
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("SHA1(%s)", str), nil)
+		yield(fmt.Sprintf("SHA(%s)", str), nil)
+	}
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("SHA1(%s)", num), nil)
+	}
+}
+
+func (FnSHA2) Test(yield Iterator) {
+	var lengths = []string{"0", "224", "256", "384", "512", "1", "-256", "'384'", "'foo'", "256.4", "NULL"}
+	for _, str := range inputStrings {
+		for _, l := range lengths {
+			yield(fmt.Sprintf("SHA2(%s, %s)", str, l), nil)
+		}
+	}
+	for _, num := range inputConversions {
+		yield(fmt.Sprintf("SHA2(%s, 256)", num), nil)
+	}
+}
+
+func (Modulo) Test(yield Iterator) {
+	var inputs = []string{
+		"0", "1", "-1", "2", "-3", "5", "7", "0.0", "2.5", "-2.5", "5.5", "5.50", "0.001", "1.5e0", "-1.5e0",
+		"'5.5'", "'foo'", "0x0A", "NULL",
+		strconv.FormatUint(math.MaxUint64, 10),
+		strconv.FormatInt(math.MinInt64, 10),
+	}
+	for _, lhs := range inputs {
+		for _, rhs := range inputs {
+			yield(fmt.Sprintf("%s %% %s", lhs, rhs), nil)
+			yield(fmt.Sprintf("MOD(%s, %s)", lhs, rhs), nil)
+		}
+	}
+}
+
+func (FnConcat) Test(yield Iterator) {
+	values := append([]string{"NULL", "''", "1", "2.5", "1 = 1", "1 = 2", "_binary 'x'"}, inputStrings...)
+	genSubsets(values, 2, func(args []string) {
+		yield(fmt.Sprintf("CONCAT(%s, %s)", args[0], args[1]), nil)
+	})
+}
+
+func (FnPow) Test(yield Iterator) {
+	var powInputs = []string{
+		"0", "1", "2", "-2", "0.5", "-0.5", "3", "-3", "1.5e0", "-1.5e0", "'2'", "NULL",
+	}
+
+	for _, base := range powInputs {
+		for _, exp := range powInputs {
+			yield(fmt.Sprintf("POW(%s, %s)", base, exp), nil)
+			yield(fmt.Sprintf("POWER(%s, %s)", base, exp), nil)
+		}
+	}
+}
+
+func (FnDateFormat) Test(yield Iterator) {
+	for _, d := range inputDateTimes {
+		for _, f := range inputDateFormats {
+			yield(fmt.Sprintf("DATE_FORMAT(%s, %s)", d, f), nil)
+		}
+	}
+}
+
+func (FnSqrt) Test(yield Iterator) {
+	for _, num := range inputMath {
+		yield(fmt.Sprintf("SQRT(%s)", num), nil)
+	}
+}
+
+func (FnExp) Test(yield Iterator) {
+	for _, num := range inputMath {
+		yield(fmt.Sprintf("EXP(%s)", num), nil)
+	}
+}
+
+func (FnLog) Test(yield Iterator) {
+	for _, num := range inputMath {
+		yield(fmt.Sprintf("LN(%s)", num), nil)
+		yield(fmt.Sprintf("LOG(%s)", num), nil)
+		yield(fmt.Sprintf("LOG2(%s)", num), nil)
+		yield(fmt.Sprintf("LOG10(%s)", num), nil)
+	}
+	for _, base := range inputMath {
+		for _, num := range inputMath {
+			yield(fmt.Sprintf("LOG(%s, %s)", base, num), nil)
+		}
+	}
+}
+
+func (JSONDepth) Test(yield Iterator) {
+	for _, obj := range inputJSONObjects {
+		yield(fmt.Sprintf("JSON_DEPTH('%s')", obj), nil)
+	}
+	for _, prim := range inputJSONPrimitives {
+		yield(fmt.Sprintf("JSON_DEPTH(JSON_ARRAY(%s))", prim), nil)
+	}
+	yield(fmt.Sprintf("JSON_DEPTH('%s%s')", strings.Repeat("[", 90), strings.Repeat("]", 90)), nil)
+}
+
+func (JSONValid) Test(yield Iterator) {
+	for _, obj := range inputJSONObjects {
+		yield(fmt.Sprintf("JSON_VALID('%s')", obj), nil)
+	}
+	for _, prim := range inputJSONPrimitives {
+		yield(fmt.Sprintf("JSON_VALID(%s)", prim), nil)
+	}
+	yield("JSON_VALID('{\"a\": 1')", nil)
+	yield("JSON_VALID('[1, 2,]')", nil)
+	for _, num := range []string{"1-2", "123+456", "2023-01-05", "01", ".5", "1.", "1e", "-", "NaN"} {
+		yield(fmt.Sprintf("JSON_VALID('%s')", num), nil)
+	}
+}
+
+func (JSONType) Test(yield Iterator) {
+	for _, obj := range inputJSONObjects {
+		yield(fmt.Sprintf("JSON_TYPE('%s')", obj), nil)
+	}
+	for _, prim := range inputJSONPrimitives {
+		yield(fmt.Sprintf("JSON_TYPE(JSON_ARRAY(%s))", prim), nil)
+	}
+	for _, num := range []string{"1", "-1", "1.5", "1e3", "9223372036854775808", "18446744073709551616"} {
+		yield(fmt.Sprintf("JSON_TYPE('%s')", num), nil)
+	}
+	yield("JSON_TYPE('2023-01-05')", nil)
+	yield("JSON_TYPE(CAST('2023-01-05' AS DATE))", nil)
+}
+
+func (JSONQuote) Test(yield Iterator) {
+	for _, str := range inputStrings {
+		yield(fmt.Sprintf("JSON_QUOTE(%s)", str), nil)
+	}
+	for _, obj := range inputJSONObjects {
+		yield(fmt.Sprintf("JSON_QUOTE('%s')", obj), nil)
+	}
+	yield(`JSON_QUOTE('a\\b\nc\td"e')`, nil)
+	yield("JSON_QUOTE(CONCAT('a', CHAR(1), 'b'))", nil)
+}
+
+func (JSONMergePatch) Test(yield Iterator) {
+	patches := []string{
+		`'{"a": null}'`, `'{"a": {"d": 5}}'`, `'{"b": [1]}'`, `'{"e": null, "f": true}'`,
+		`'[1, 2]'`, `'1'`, `'null'`, `NULL`, `JSON_OBJECT('c', NULL)`,
+	}
+	for _, obj := range inputJSONObjects {
+		for _, patch := range patches {
+			yield(fmt.Sprintf("JSON_MERGE_PATCH('%s', %s)", obj, patch), nil)
+		}
+	}
+	genSubsets(patches, 3, func(args []string) {
+		yield(fmt.Sprintf("JSON_MERGE_PATCH(%s, %s, %s)", args[0], args[1], args[2]), nil)
+	})
+}
+
+func (JSONMergePreserve) Test(yield Iterator) {
+	docs := []string{
+		`'{"a": 1}'`, `'{"a": {"d": 5}}'`, `'{"b": [1]}'`,
+		`'[1, 2]'`, `'1'`, `'"x"'`, `'null'`, `NULL`, `JSON_ARRAY()`,
+	}
+	for _, obj := range inputJSONObjects {
+		for _, doc := range docs {
+			yield(fmt.Sprintf("JSON_MERGE_PRESERVE('%s', %s)", obj, doc), nil)
+		}
+	}
+	genSubsets(docs, 3, func(args []string) {
+		yield(fmt.Sprintf("JSON_MERGE_PRESERVE(%s, %s, %s)", args[0], args[1], args[2]), nil)
+	})
+}
+
+func (JSONModify) Test(yield Iterator) {
+	for _, fn := range []string{"JSON_SET", "JSON_INSERT", "JSON_REPLACE", "JSON_ARRAY_APPEND", "JSON_ARRAY_INSERT"} {
+		for _, obj := range inputJSONObjects {
+			for _, path := range inputJSONPaths {
+				yield(fmt.Sprintf("%s('%s', '%s', 1)", fn, obj, path), nil)
+			}
+			yield(fmt.Sprintf("%s('%s', '$[last]', 'x', '$.e', JSON_ARRAY(1))", fn, obj), nil)
+			yield(fmt.Sprintf("%s('%s', '$[5]', NULL)", fn, obj), nil)
+		}
+		for _, prim := range inputJSONPrimitives {
+			yield(fmt.Sprintf("%s('[1, {\"a\": 2}]', '$[1].a', %s)", fn, prim), nil)
+		}
+		for _, doc := range []string{`'[true, false]'`, `'{"x": 1}'`, `'"x"'`, `'1'`} {
+			yield(fmt.Sprintf("%s('{\"a\": 1}', '$.c', CAST(%s AS JSON))", fn, doc), nil)
+		}
+	}
+}
+
+func (JSONRemove) Test(yield Iterator) {
+	for _, obj := range inputJSONObjects {
+		for _, path := range inputJSONPaths {
+			yield(fmt.Sprintf("JSON_REMOVE('%s', '%s')", obj, path), nil)
+		}
+		yield(fmt.Sprintf("JSON_REMOVE('%s', '$[0]', '$[0]')", obj), nil)
+		yield(fmt.Sprintf("JSON_REMOVE('%s', '$[last]', NULL)", obj), nil)
+	}
+}
+
+func (FnFormat) Test(yield Iterator) {
+	values := append([]string{"1234567.891", "-1234567.891", "-999.5", "-1234.5e0", "'-12345.678'", "-9223372036854775808"}, inputMath...)
+	for _, num := range values {
+		for _, dec := range []string{"0", "1", "2", "-1", "40", "NULL"} {
+			yield(fmt.Sprintf("FORMAT(%s, %s)", num, dec), nil)
+		}
+	}
+}
+
+func (FnTrig) Test(yield Iterator) {
+	for _, num := range inputMath {
+		yield(fmt.Sprintf("SIN(%s)", num), nil)
+		yield(fmt.Sprintf("COS(%s)", num), nil)
+		yield(fmt.Sprintf("TAN(%s)", num), nil)
+		yield(fmt.Sprintf("COT(%s)", num), nil)
+		yield(fmt.Sprintf("DEGREES(%s)", num), nil)
+		yield(fmt.Sprintf("RADIANS(%s)", num), nil)
+	}
+}
+
+func (FnInverseTrig) Test(yield Iterator) {
+	for _, num1 := range inputMath {
+		yield(fmt.Sprintf("ASIN(%s)", num1), nil)
+		yield(fmt.Sprintf("ACOS(%s)", num1), nil)
+		yield(fmt.Sprintf("ATAN(%s)", num1), nil)
+		for _, num2 := range inputMath {
+			yield(fmt.Sprintf("ATAN(%s, %s)", num1, num2), nil)
+			yield(fmt.Sprintf("ATAN2(%s, %s)", num1, num2), nil)
+		}
+	}
+}
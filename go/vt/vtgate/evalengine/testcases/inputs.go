@@ -29,6 +29,7 @@ var inputJSONObjects = []string{
 	`{"a": 1, "b": 2, "c": {"d": 4}}`,
 	`["a", {"b": [true, false]}, [10, 20]]`,
 	`[10, 20, [30, 40]]`,
+	`{"bb": 1, "a": 2, "ccc": 3, "B": 4}`,
 }
 
 var inputJSONPaths = []string{
@@ -107,3 +108,51 @@ var inputStrings = []string{
 	// "_utf32 'AabcÅå'",
 	// "_ucs2 'AabcÅå'",
 }
+
+var inputDateTimes = []string{
+	"NULL",
+	"'2023-01-01 00:00:00'",
+	"'2023-01-01 12:00:00'",
+	"'2023-06-15 15:30:45.123456'",
+	"'2023-12-31 23:59:59'",
+	"'2000-02-29'",
+	"'0000-00-00'",
+	"'0000-00-00 00:00:00'",
+	"'2023-00-00'",
+	"0",
+	"'not a date'",
+	"20230615153045",
+	"DATE '2023-06-15'",
+	"TIMESTAMP '2023-06-15 01:02:03'",
+}
+
+var inputDateFormats = []string{
+	"'%Y-%m-%d %H:%i:%s'",
+	"'%y %c %e %k %f'",
+	"'%h %I %l %p'",
+	"'%r'",
+	"'%T'",
+	"'%q %Z'",
+	"'%W %a %w %M %b'",
+	"'%j %D %%'",
+	"'%U %u %V %v %X %x'",
+}
+
+var inputMath = []string{
+	"0",
+	"1",
+	"-1",
+	"'1.5'",
+	"NULL",
+	"'ABC'",
+	"1.5e0",
+	"-1.5e0",
+	"9223372036854775810.4",
+	"-9223372036854775810.4",
+	"4",
+	"0.25",
+	"-0.0e0",
+	"PI()",
+	"-PI()",
+	"PI() / 2",
+}
@@ -83,6 +83,15 @@ func (ast *astCompiler) translateComparisonExpr2(op sqlparser.ComparisonExprOper
 		return &LikeExpr{BinaryExpr: binaryExpr}, nil
 	case sqlparser.NotLikeOp:
 		return &LikeExpr{BinaryExpr: binaryExpr, Negate: true}, nil
+	case sqlparser.RegexpOp, sqlparser.NotRegexpOp:
+		// the REGEXP operator is the same as REGEXP_LIKE without a match type
+		regexp := &builtinRegexpLike{
+			CallExpr: CallExpr{Arguments: []Expr{left, right}, Method: "REGEXP_LIKE"},
+		}
+		if op == sqlparser.NotRegexpOp {
+			return ast.translateLogicalNot(regexp), nil
+		}
+		return regexp, nil
 	default:
 		return nil, vterrors.Errorf(vtrpcpb.Code_UNIMPLEMENTED, op.ToString())
 	}
@@ -213,6 +222,18 @@ func (ast *astCompiler) translateLiteral(lit *sqlparser.Literal) (*Literal, erro
 }
 
 func (ast *astCompiler) translateBinaryExpr(binary *sqlparser.BinaryExpr) (Expr, error) {
+	if _, ok := binary.Right.(*sqlparser.IntervalExpr); ok {
+		switch binary.Operator {
+		case sqlparser.PlusOp:
+			return ast.translateDateMath(binary, binary.Left, binary.Right, false, false)
+		case sqlparser.MinusOp:
+			return ast.translateDateMath(binary, binary.Left, binary.Right, false, true)
+		}
+	}
+	if _, ok := binary.Left.(*sqlparser.IntervalExpr); ok && binary.Operator == sqlparser.PlusOp {
+		return ast.translateDateMath(binary, binary.Right, binary.Left, false, false)
+	}
+
 	left, err := ast.translateExpr(binary.Left)
 	if err != nil {
 		return nil, err
@@ -235,6 +256,8 @@ func (ast *astCompiler) translateBinaryExpr(binary *sqlparser.BinaryExpr) (Expr,
 		return &ArithmeticExpr{BinaryExpr: binaryExpr, Op: &opArithMul{}}, nil
 	case sqlparser.DivOp:
 		return &ArithmeticExpr{BinaryExpr: binaryExpr, Op: &opArithDiv{}}, nil
+	case sqlparser.ModOp:
+		return &ArithmeticExpr{BinaryExpr: binaryExpr, Op: &opArithMod{}}, nil
 	case sqlparser.BitAndOp:
 		return &BitwiseExpr{BinaryExpr: binaryExpr, Op: &opBitAnd{}}, nil
 	case sqlparser.BitOrOp:
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"strings"
 
+	"vitess.io/vitess/go/mysql/collations"
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/datetime"
 )
 
 type argError string
@@ -43,7 +45,86 @@ func (ast *astCompiler) translateFuncArgs(fnargs []sqlparser.Expr) ([]Expr, erro
 	return args, nil
 }
 
+// translateFsp returns the fractional seconds precision given to a function
+// that returns the current time, which must be an integer literal from 0 to 6
+func (ast *astCompiler) translateFsp(call sqlparser.Expr, name string, fsp sqlparser.Expr) (uint8, error) {
+	if fsp == nil {
+		return 0, nil
+	}
+	lit, ok := fsp.(*sqlparser.Literal)
+	if !ok {
+		return 0, translateExprNotSupported(call)
+	}
+	prec, _, err := ast.translateIntegral(lit)
+	if err != nil {
+		return 0, err
+	}
+	if prec > 6 {
+		return 0, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT, "Too-big precision %d specified for '%s'. Maximum is 6.", prec, name)
+	}
+	return uint8(prec), nil
+}
+
+// translateDateMath translates the addition or subtraction of an INTERVAL
+// to a date. When days is true, the interval can also be a plain number of
+// days, like in the short forms of ADDDATE and SUBDATE.
+func (ast *astCompiler) translateDateMath(call sqlparser.Expr, date, interval sqlparser.Expr, days, sub bool) (Expr, error) {
+	unit := datetime.IntervalDay
+	value := interval
+	if itv, ok := interval.(*sqlparser.IntervalExpr); ok {
+		if unit, ok = datetime.ParseIntervalType(itv.Unit); !ok {
+			return nil, translateExprNotSupported(call)
+		}
+		value = itv.Expr
+	} else if !days {
+		return nil, translateExprNotSupported(call)
+	}
+
+	dateExpr, err := ast.translateExpr(date)
+	if err != nil {
+		return nil, err
+	}
+	valueExpr, err := ast.translateExpr(value)
+	if err != nil {
+		return nil, err
+	}
+
+	method := "DATE_ADD"
+	if sub {
+		method = "DATE_SUB"
+	}
+	return &builtinDateMath{
+		CallExpr: CallExpr{Arguments: []Expr{dateExpr, valueExpr}, Method: method},
+		unit:     unit,
+		sub:      sub,
+	}, nil
+}
+
+func (ast *astCompiler) translateDateMathFunc(fn *sqlparser.FuncExpr, method string) (Expr, error) {
+	if len(fn.Exprs) != 2 {
+		return nil, argError(method)
+	}
+	var args [2]sqlparser.Expr
+	for i, expr := range fn.Exprs {
+		aliased, ok := expr.(*sqlparser.AliasedExpr)
+		if !ok {
+			return nil, translateExprNotSupported(fn)
+		}
+		args[i] = aliased.Expr
+	}
+	days := method == "adddate" || method == "subdate"
+	sub := method == "date_sub" || method == "subdate"
+	return ast.translateDateMath(fn, args[0], args[1], days, sub)
+}
+
 func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (Expr, error) {
+	// the INTERVAL argument of the date arithmetic functions is not an
+	// expression on its own, so they need to be translated separately
+	switch method := fn.Name.Lowered(); method {
+	case "date_add", "date_sub", "adddate", "subdate":
+		return ast.translateDateMathFunc(fn, method)
+	}
+
 	var args TupleExpr
 	for _, expr := range fn.Exprs {
 		aliased, ok := expr.(*sqlparser.AliasedExpr)
@@ -102,6 +183,171 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (Expr, error)
 			return nil, argError(method)
 		}
 		return &builtinCeil{CallExpr: call}, nil
+	case "floor":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinFloor{CallExpr: call}, nil
+	case "sign":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSign{CallExpr: call}, nil
+	case "pow", "power":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinPow{CallExpr: call}, nil
+	case "mod":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		// MOD(N, M) is the same as N % M
+		return &ArithmeticExpr{
+			BinaryExpr: BinaryExpr{Left: args[0], Right: args[1]},
+			Op:         &opArithMod{},
+		}, nil
+	case "sqrt":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSqrt{CallExpr: call}, nil
+	case "exp":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinExp{CallExpr: call}, nil
+	case "ln":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinLn{CallExpr: call}, nil
+	case "log":
+		switch len(args) {
+		case 1, 2:
+			return &builtinLog{CallExpr: call}, nil
+		default:
+			return nil, argError(method)
+		}
+	case "log2":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinLog2{CallExpr: call}, nil
+	case "log10":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinLog10{CallExpr: call}, nil
+	case "pi":
+		if len(args) != 0 {
+			return nil, argError(method)
+		}
+		return &builtinPi{CallExpr: call}, nil
+	case "truncate":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinTruncate{CallExpr: call}, nil
+	case "conv":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinConv{CallExpr: call}, nil
+	case "bin":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinBin{CallExpr: call}, nil
+	case "oct":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinOct{CallExpr: call}, nil
+	case "degrees":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinDegrees{CallExpr: call}, nil
+	case "radians":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinRadians{CallExpr: call}, nil
+	case "rand":
+		if len(args) > 1 {
+			return nil, argError(method)
+		}
+		return &builtinRand{CallExpr: call}, nil
+	case "sin":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSin{CallExpr: call}, nil
+	case "cos":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinCos{CallExpr: call}, nil
+	case "tan":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinTan{CallExpr: call}, nil
+	case "cot":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinCot{CallExpr: call}, nil
+	case "asin":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinAsin{CallExpr: call}, nil
+	case "acos":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinAcos{CallExpr: call}, nil
+	case "atan":
+		switch len(args) {
+		case 1:
+			return &builtinAtan{CallExpr: call}, nil
+		case 2:
+			return &builtinAtan2{CallExpr: call}, nil
+		default:
+			return nil, argError(method)
+		}
+	case "atan2":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinAtan2{CallExpr: call}, nil
+	case "field":
+		if len(args) < 2 {
+			return nil, argError(method)
+		}
+		return &builtinField{CallExpr: call}, nil
+	case "concat":
+		if len(args) < 1 {
+			return nil, argError(method)
+		}
+		return &builtinConcat{CallExpr: call}, nil
+	case "format":
+		switch len(args) {
+		case 2:
+			return &builtinFormat{CallExpr: call}, nil
+		case 3:
+			// the locale argument is not supported yet
+			return nil, translateExprNotSupported(fn)
+		default:
+			return nil, argError(method)
+		}
+	case "concat_ws":
+		if len(args) < 2 {
+			return nil, argError(method)
+		}
+		return &builtinConcatWS{CallExpr: call}, nil
 	case "lower", "lcase":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -137,6 +383,31 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (Expr, error)
 			return nil, argError(method)
 		}
 		return &builtinRepeat{CallExpr: call}, nil
+	case "lpad":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinPad{CallExpr: call, left: true}, nil
+	case "rpad":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinPad{CallExpr: call, left: false}, nil
+	case "replace":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinReplace{CallExpr: call}, nil
+	case "substring_index":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinSubstringIndex{CallExpr: call}, nil
+	case "instr":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinInstr{CallExpr: call}, nil
 	case "from_base64":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -147,6 +418,217 @@ func (ast *astCompiler) translateFuncExpr(fn *sqlparser.FuncExpr) (Expr, error)
 			return nil, argError(method)
 		}
 		return &builtinToBase64{CallExpr: call}, nil
+	case "crc32":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinCrc32{CallExpr: call}, nil
+	case "md5":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinMD5{CallExpr: call}, nil
+	case "sha1", "sha":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSHA1{CallExpr: call}, nil
+	case "sha2":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinSHA2{CallExpr: call}, nil
+	case "random_bytes":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinRandomBytes{CallExpr: call}, nil
+	case "curdate", "current_date":
+		if len(args) != 0 {
+			return nil, argError(method)
+		}
+		return &builtinCurdate{CallExpr: call}, nil
+	case "curtime":
+		if len(args) > 1 {
+			return nil, argError(method)
+		}
+		var fsp sqlparser.Expr
+		if len(args) == 1 {
+			fsp = fn.Exprs[0].(*sqlparser.AliasedExpr).Expr
+		}
+		prec, err := ast.translateFsp(fn, method, fsp)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinCurtime{CallExpr: call, prec: prec}, nil
+	case "sysdate":
+		if len(args) > 1 {
+			return nil, argError(method)
+		}
+		var fsp sqlparser.Expr
+		if len(args) == 1 {
+			fsp = fn.Exprs[0].(*sqlparser.AliasedExpr).Expr
+		}
+		prec, err := ast.translateFsp(fn, method, fsp)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinSysdate{CallExpr: call, prec: prec}, nil
+	case "year":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinYear{CallExpr: call}, nil
+	case "month":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinMonth{CallExpr: call}, nil
+	case "day", "dayofmonth":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinDay{CallExpr: call}, nil
+	case "dayofweek":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinDayOfWeek{CallExpr: call}, nil
+	case "weekday":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinWeekDay{CallExpr: call}, nil
+	case "dayofyear":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinDayOfYear{CallExpr: call}, nil
+	case "dayname":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinDayName{CallExpr: call}, nil
+	case "monthname":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinMonthName{CallExpr: call}, nil
+	case "last_day":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinLastDay{CallExpr: call}, nil
+	case "datediff":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinDateDiff{CallExpr: call}, nil
+	case "to_days":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinToDays{CallExpr: call}, nil
+	case "from_days":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinFromDays{CallExpr: call}, nil
+	case "to_seconds":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinToSeconds{CallExpr: call}, nil
+	case "timediff":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinTimeDiff{CallExpr: call}, nil
+	case "quarter":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinQuarter{CallExpr: call}, nil
+	case "week":
+		if len(args) < 1 || len(args) > 2 {
+			return nil, argError(method)
+		}
+		return &builtinWeek{CallExpr: call}, nil
+	case "weekofyear":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinWeekOfYear{CallExpr: call}, nil
+	case "hour":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinHour{CallExpr: call}, nil
+	case "minute":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinMinute{CallExpr: call}, nil
+	case "second":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSecond{CallExpr: call}, nil
+	case "microsecond":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinMicrosecond{CallExpr: call}, nil
+	case "sec_to_time":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinSecToTime{CallExpr: call}, nil
+	case "time_to_sec":
+		if len(args) != 1 {
+			return nil, argError(method)
+		}
+		return &builtinTimeToSec{CallExpr: call}, nil
+	case "date_format":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinDateFormat{CallExpr: call}, nil
+	case "time_format":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinTimeFormat{CallExpr: call}, nil
+	case "str_to_date":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinStrToDate{CallExpr: call}, nil
+	case "from_unixtime":
+		if len(args) < 1 || len(args) > 2 {
+			return nil, argError(method)
+		}
+		return &builtinFromUnixtime{CallExpr: call}, nil
+	case "unix_timestamp":
+		if len(args) > 1 {
+			return nil, argError(method)
+		}
+		return &builtinUnixTimestamp{CallExpr: call}, nil
+	case "convert_tz":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinConvertTz{CallExpr: call}, nil
+	case "makedate":
+		if len(args) != 2 {
+			return nil, argError(method)
+		}
+		return &builtinMakeDate{CallExpr: call}, nil
+	case "maketime":
+		if len(args) != 3 {
+			return nil, argError(method)
+		}
+		return &builtinMakeTime{CallExpr: call}, nil
 	case "json_depth":
 		if len(args) != 1 {
 			return nil, argError(method)
@@ -192,6 +674,180 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (Expr, error)
 		}
 		return &ws, nil
 
+	case *sqlparser.TrimFuncExpr:
+		var args []Expr
+		str, err := ast.translateExpr(call.StringArg)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, str)
+
+		if call.TrimArg != nil {
+			rem, err := ast.translateExpr(call.TrimArg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, rem)
+		}
+
+		var trim = call.Type
+		switch call.TrimFuncType {
+		case sqlparser.LTrimType:
+			trim = sqlparser.LeadingTrimType
+		case sqlparser.RTrimType:
+			trim = sqlparser.TrailingTrimType
+		}
+		return &builtinTrim{
+			CallExpr: CallExpr{
+				Arguments: args,
+				Method:    strings.ToUpper(call.TrimFuncType.ToString()),
+			},
+			trim: trim,
+		}, nil
+
+	case *sqlparser.SubstrExpr:
+		var args []sqlparser.Expr
+		args = append(args, call.Name, call.From)
+		if call.To != nil {
+			args = append(args, call.To)
+		}
+		cargs, err := ast.translateFuncArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinSubstring{
+			CallExpr: CallExpr{Arguments: cargs, Method: "SUBSTRING"},
+		}, nil
+
+	case *sqlparser.CharExpr:
+		args, err := ast.translateFuncArgs(call.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		var collate collations.ID = collations.CollationBinaryID
+		if call.Charset != "" {
+			collate, err = ast.translateConvertCharset(call.Charset, false)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &builtinChar{
+			CallExpr: CallExpr{Arguments: args, Method: "CHAR"},
+			collate:  collate,
+		}, nil
+
+	case *sqlparser.LocateExpr:
+		var args []sqlparser.Expr
+		args = append(args, call.SubStr, call.Str)
+		if call.Pos != nil {
+			args = append(args, call.Pos)
+		}
+		cargs, err := ast.translateFuncArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinLocate{
+			CallExpr: CallExpr{Arguments: cargs, Method: "LOCATE"},
+		}, nil
+
+	case *sqlparser.TimestampFuncExpr:
+		// only the simple units are supported, from MICROSECOND to YEAR
+		unit, ok := datetime.ParseIntervalType(call.Unit)
+		if !ok || unit.IsCompound() {
+			return nil, translateExprNotSupported(call)
+		}
+
+		switch call.Name {
+		case "timestampadd":
+			date, err := ast.translateExpr(call.Expr2)
+			if err != nil {
+				return nil, err
+			}
+			value, err := ast.translateExpr(call.Expr1)
+			if err != nil {
+				return nil, err
+			}
+			return &builtinDateMath{
+				CallExpr: CallExpr{Arguments: []Expr{date, value}, Method: "TIMESTAMPADD"},
+				unit:     unit,
+			}, nil
+		case "timestampdiff":
+			args, err := ast.translateFuncArgs([]sqlparser.Expr{call.Expr1, call.Expr2})
+			if err != nil {
+				return nil, err
+			}
+			return &builtinTimestampDiff{
+				CallExpr: CallExpr{Arguments: args, Method: "TIMESTAMPDIFF"},
+				unit:     unit,
+			}, nil
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+
+	case *sqlparser.ExtractFuncExpr:
+		unit, ok := datetime.ParseIntervalType(call.IntervalTypes.ToString())
+		if !ok {
+			return nil, translateExprNotSupported(call)
+		}
+		arg, err := ast.translateExpr(call.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinExtract{
+			CallExpr: CallExpr{Arguments: []Expr{arg}, Method: "EXTRACT"},
+			unit:     unit,
+		}, nil
+
+	case *sqlparser.CurTimeFuncExpr:
+		prec, err := ast.translateFsp(call, call.Name.String(), call.Fsp)
+		if err != nil {
+			return nil, err
+		}
+
+		switch call.Name.Lowered() {
+		case "now", "current_timestamp", "localtime", "localtimestamp":
+			return &builtinNow{
+				CallExpr: CallExpr{Method: strings.ToUpper(call.Name.String())},
+				prec:     prec,
+			}, nil
+		case "current_time":
+			return &builtinCurtime{
+				CallExpr: CallExpr{Method: "CURRENT_TIME"},
+				prec:     prec,
+			}, nil
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+
+	case *sqlparser.RegexpLikeExpr:
+		args := []sqlparser.Expr{call.Expr, call.Pattern}
+		if call.MatchType != nil {
+			args = append(args, call.MatchType)
+		}
+		cargs, err := ast.translateFuncArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinRegexpLike{
+			CallExpr: CallExpr{Arguments: cargs, Method: "REGEXP_LIKE"},
+		}, nil
+
+	case *sqlparser.RegexpInstrExpr:
+		args := []sqlparser.Expr{call.Expr, call.Pattern}
+		for _, opt := range []sqlparser.Expr{call.Position, call.Occurrence, call.ReturnOption, call.MatchType} {
+			if opt == nil {
+				break
+			}
+			args = append(args, opt)
+		}
+		cargs, err := ast.translateFuncArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinRegexpInstr{
+			CallExpr: CallExpr{Arguments: cargs, Method: "REGEXP_INSTR"},
+		}, nil
+
 	case *sqlparser.JSONExtractExpr:
 		args, err := ast.translateFuncArgs(append([]sqlparser.Expr{call.JSONDoc}, call.PathList...))
 		if err != nil {
@@ -216,6 +872,92 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (Expr, error)
 			},
 		}, nil
 
+	case *sqlparser.JSONValueModifierExpr:
+		doc, err := ast.translateExpr(call.JSONDoc)
+		if err != nil {
+			return nil, err
+		}
+		args := []Expr{doc}
+		for _, param := range call.Params {
+			path, err := ast.translateExpr(param.Key)
+			if err != nil {
+				return nil, err
+			}
+			value, err := ast.translateExpr(param.Value)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, path, value)
+		}
+
+		var method string
+		switch call.Type {
+		case sqlparser.JSONSetType:
+			method = "JSON_SET"
+		case sqlparser.JSONInsertType:
+			method = "JSON_INSERT"
+		case sqlparser.JSONReplaceType:
+			method = "JSON_REPLACE"
+		case sqlparser.JSONArrayAppendType:
+			method = "JSON_ARRAY_APPEND"
+		case sqlparser.JSONArrayInsertType:
+			method = "JSON_ARRAY_INSERT"
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+		return &builtinJSONModify{
+			CallExpr: CallExpr{Arguments: args, Method: method},
+			modify:   call.Type,
+		}, nil
+
+	case *sqlparser.JSONRemoveExpr:
+		args, err := ast.translateFuncArgs(append(sqlparser.Exprs{call.JSONDoc}, call.PathList...))
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONRemove{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_REMOVE",
+		}}, nil
+
+	case *sqlparser.JSONValueMergeExpr:
+		args, err := ast.translateFuncArgs(append(sqlparser.Exprs{call.JSONDoc}, call.JSONDocList...))
+		if err != nil {
+			return nil, err
+		}
+		switch call.Type {
+		case sqlparser.JSONMergePatchType:
+			return &builtinJSONMergePatch{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_MERGE_PATCH",
+			}}, nil
+		case sqlparser.JSONMergePreserveType:
+			return &builtinJSONMergePreserve{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_MERGE_PRESERVE",
+			}}, nil
+		case sqlparser.JSONMergeType:
+			// JSON_MERGE is a deprecated synonym of JSON_MERGE_PRESERVE
+			return &builtinJSONMergePreserve{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_MERGE",
+			}}, nil
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+
+	case *sqlparser.JSONQuoteExpr:
+		arg, err := ast.translateExpr(call.StringArg)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONQuote{
+			CallExpr: CallExpr{
+				Arguments: []Expr{arg},
+				Method:    "JSON_QUOTE",
+			},
+		}, nil
+
 	case *sqlparser.JSONObjectExpr:
 		var args []Expr
 		for _, param := range call.Params {
@@ -258,6 +1000,62 @@ func (ast *astCompiler) translateCallable(call sqlparser.Callable) (Expr, error)
 			Method:    "JSON_CONTAINS_PATH",
 		}}, nil
 
+	case *sqlparser.JSONContainsExpr:
+		if len(call.PathList) > 1 {
+			return nil, argError("json_contains")
+		}
+		exprs := []sqlparser.Expr{call.Target, call.Candidate}
+		exprs = append(exprs, call.PathList...)
+		args, err := ast.translateFuncArgs(exprs)
+		if err != nil {
+			return nil, err
+		}
+		return &builtinJSONContains{CallExpr: CallExpr{
+			Arguments: args,
+			Method:    "JSON_CONTAINS",
+		}}, nil
+
+	case *sqlparser.JSONAttributesExpr:
+		var args []Expr
+		doc, err := ast.translateExpr(call.JSONDoc)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, doc)
+
+		if call.Path != nil {
+			path, err := ast.translateExpr(call.Path)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, path)
+		}
+
+		switch call.Type {
+		case sqlparser.DepthAttributeType:
+			return &builtinJSONDepth{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_DEPTH",
+			}}, nil
+		case sqlparser.LengthAttributeType:
+			return &builtinJSONLength{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_LENGTH",
+			}}, nil
+		case sqlparser.ValidAttributeType:
+			return &builtinJSONValid{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_VALID",
+			}}, nil
+		case sqlparser.TypeAttributeType:
+			return &builtinJSONType{CallExpr: CallExpr{
+				Arguments: args,
+				Method:    "JSON_TYPE",
+			}}, nil
+		default:
+			return nil, translateExprNotSupported(call)
+		}
+
 	case *sqlparser.JSONKeysExpr:
 		var args []Expr
 		doc, err := ast.translateExpr(call.JSONDoc)
@@ -23,6 +23,7 @@ import (
 	vtrpcpb "vitess.io/vitess/go/vt/proto/vtrpc"
 	"vitess.io/vitess/go/vt/sqlparser"
 	"vitess.io/vitess/go/vt/vterrors"
+	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/datetime"
 	"vitess.io/vitess/go/vt/vtgate/evalengine/internal/decimal"
 )
 
@@ -111,7 +112,13 @@ func (ast *astCompiler) translateConvertExpr(expr sqlparser.Expr, convertType *s
 		if err != nil {
 			return nil, err
 		}
-	case "BINARY", "DOUBLE", "REAL", "SIGNED", "SIGNED INTEGER", "UNSIGNED", "UNSIGNED INTEGER", "JSON":
+	case "TIME", "DATETIME":
+		if convert.Length > datetime.DefaultPrecision {
+			return nil, vterrors.Errorf(vtrpcpb.Code_INVALID_ARGUMENT,
+				"Too-big precision %d specified for '%s'. Maximum is %d.",
+				convert.Length, sqlparser.String(expr), datetime.DefaultPrecision)
+		}
+	case "BINARY", "DOUBLE", "REAL", "SIGNED", "SIGNED INTEGER", "UNSIGNED", "UNSIGNED INTEGER", "JSON", "DATE":
 		// Supported types for conv expression
 	default:
 		// For unsupported types, we should return an error on translation instead of returning an error on runtime.
@@ -51,6 +51,14 @@ func (expr *UnaryExpr) constant() bool {
 	return expr.Inner.constant()
 }
 
+// casting a TIME into a DATE or a DATETIME depends on the current date
+func (expr *ConvertExpr) constant() bool {
+	if expr.Type == "DATE" || expr.Type == "DATETIME" {
+		return false
+	}
+	return expr.Inner.constant()
+}
+
 func (expr *Literal) simplify(_ *ExpressionEnv) error {
 	return nil
 }
@@ -182,6 +190,147 @@ func (c *builtinWeightString) simplify(env *ExpressionEnv) error {
 	return err
 }
 
+// RAND is not deterministic, so it can never be folded into a constant,
+// even when its seed is one
+func (c *builtinRand) constant() bool {
+	return false
+}
+
+// RANDOM_BYTES isn't deterministic either
+func (c *builtinRandomBytes) constant() bool {
+	return false
+}
+
+// NOW returns the time of the statement, which is only known when evaluating it
+func (c *builtinNow) constant() bool {
+	return false
+}
+
+func (c *builtinCurdate) constant() bool {
+	return false
+}
+
+func (c *builtinCurtime) constant() bool {
+	return false
+}
+
+// SYSDATE isn't even constant within a statement
+func (c *builtinSysdate) constant() bool {
+	return false
+}
+
+// DATE_ADD of a TIME and an interval with days or larger units depends on
+// the current date
+func (c *builtinDateMath) constant() bool {
+	return false
+}
+
+// dateArgsConstant returns whether the arguments of a function that uses the
+// date of its arguments are constant. TIME arguments are on the current date,
+// which is only known when evaluating them.
+func dateArgsConstant(args TupleExpr) bool {
+	if !args.constant() {
+		return false
+	}
+	env := EmptyExpressionEnv()
+	for _, arg := range args {
+		if t, _ := arg.typeof(env); t == sqltypes.Time {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *builtinYear) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinMonth) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinDay) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinDayOfWeek) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinWeekDay) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinDayOfYear) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinQuarter) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinLastDay) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinWeek) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinWeekOfYear) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinDateDiff) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinToDays) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinToSeconds) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+func (c *builtinExtract) constant() bool {
+	return dateArgsConstant(c.Arguments)
+}
+
+// DAYNAME, MONTHNAME and DATE_FORMAT depend on the session's lc_time_names,
+// which is only known when evaluating them
+func (c *builtinDayName) constant() bool {
+	return false
+}
+
+func (c *builtinMonthName) constant() bool {
+	return false
+}
+
+func (c *builtinDateFormat) constant() bool {
+	return false
+}
+
+func (c *builtinTimestampDiff) constant() bool {
+	return false
+}
+
+func (c *builtinConvertTz) constant() bool {
+	return false
+}
+
+// FROM_UNIXTIME depends on the session's time zone, and also on its
+// lc_time_names when it has a format
+func (c *builtinFromUnixtime) constant() bool {
+	return false
+}
+
+// UNIX_TIMESTAMP depends on the time of the statement or on the session's
+// time zone
+func (c *builtinUnixTimestamp) constant() bool {
+	return false
+}
+
 func simplifyExpr(env *ExpressionEnv, e Expr) (Expr, error) {
 	if e.constant() {
 		res, err := env.Evaluate(e)
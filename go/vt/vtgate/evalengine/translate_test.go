@@ -17,9 +17,12 @@ limitations under the License.
 package evalengine
 
 import (
+	"bytes"
 	"strings"
 	"testing"
+	"time"
 
+	"vitess.io/vitess/go/mysql/collations"
 	"vitess.io/vitess/go/sqltypes"
 	"vitess.io/vitess/go/vt/sqlparser"
 
@@ -356,17 +359,41 @@ func TestTranslationFailures(t *testing.T) {
 		expectedErr string
 	}{
 		{
-			expression:  "cast('2023-01-07 12:34:56' as date)",
-			expectedErr: "Unsupported type conversion: DATE",
-		}, {
-			expression:  "cast('2023-01-07 12:34:56' as datetime(5))",
-			expectedErr: "Unsupported type conversion: DATETIME(5)",
+			expression:  "cast('2023-01-07 12:34:56' as datetime(7))",
+			expectedErr: "Too-big precision 7 specified for ''2023-01-07 12:34:56''. Maximum is 6.",
 		}, {
 			expression:  "cast('3.4' as FLOAT)",
 			expectedErr: "Unsupported type conversion: FLOAT",
 		}, {
 			expression:  "cast('3.4' as FLOAT(3))",
 			expectedErr: "Unsupported type conversion: FLOAT(3)",
+		}, {
+			expression:  "pow(2)",
+			expectedErr: "Incorrect parameter count in the call to native function 'pow'",
+		},
+		{
+			expression:  "timestampdiff(day_hour, '2023-01-01', '2023-01-02')",
+			expectedErr: "expr cannot be translated, not supported: timestampdiff(day_hour, '2023-01-01', '2023-01-02')",
+		},
+		{
+			expression:  "pi(1)",
+			expectedErr: "Incorrect parameter count in the call to native function 'pi'",
+		},
+		{
+			expression:  "now(7)",
+			expectedErr: "Too-big precision 7 specified for 'now'. Maximum is 6.",
+		},
+		{
+			expression:  "curtime(7)",
+			expectedErr: "Too-big precision 7 specified for 'curtime'. Maximum is 6.",
+		},
+		{
+			expression:  "curdate(1)",
+			expectedErr: "Incorrect parameter count in the call to native function 'curdate'",
+		},
+		{
+			expression:  "cast('10:00:00' as time(7))",
+			expectedErr: "Too-big precision 7 specified for ''10:00:00''. Maximum is 6.",
 		},
 	}
 
@@ -424,3 +451,96 @@ func TestCardinalityWithBindVariables(t *testing.T) {
 		})
 	}
 }
+
+type evaluateCase struct {
+	expression string
+	expected   sqltypes.Value
+}
+
+// testEvaluateCases evaluates each expression in the given cases like the
+// MySQL golden tests do, and checks the result against the expected value
+func testEvaluateCases(t *testing.T, cases []evaluateCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			res, err := testSingle(t, "SELECT "+tc.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, res.Value(), "expected %s", tc.expected.String())
+		})
+	}
+}
+
+type evaluateErrorCase struct {
+	expression string
+	err        string
+}
+
+// testEvaluateErrors evaluates each expression in the given cases like the
+// MySQL golden tests do, and checks that it fails with the expected error.
+// Expressions with constant arguments are evaluated during translation, so the
+// error can be returned by either step.
+func testEvaluateErrors(t *testing.T, cases []evaluateErrorCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.expression, func(t *testing.T) {
+			_, err := testSingle(t, "SELECT "+tc.expression)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+// translateForEnv translates the given expression so it can be evaluated
+// in a custom environment, like one with a fixed statement time.
+func translateForEnv(t *testing.T, expression string) Expr {
+	t.Helper()
+	expr, err := convert(t, "SELECT "+expression, true)
+	require.NoError(t, err)
+	return expr
+}
+
+// testColumnTypeOf evaluates the expression, which must use column0, for each
+// one of the given values of the column, and checks that the result has the
+// same type that TypeOf reports for the column's type.
+func testColumnTypeOf(t *testing.T, expression string, cases []sqltypes.Value, expected []sqltypes.Value) {
+	t.Helper()
+
+	expr := translateForEnv(t, expression)
+
+	for i, value := range cases {
+		field := &querypb.Field{Type: value.Type()}
+		if sqltypes.IsDate(value.Type()) {
+			// temporal fields declare the fractional seconds of their values
+			if dot := bytes.IndexByte(value.Raw(), '.'); dot >= 0 {
+				field.Decimals = uint32(len(value.Raw()) - dot - 1)
+			}
+		}
+
+		env := EnvWithBindVars(nil, collations.CollationUtf8mb4ID)
+		env.Tz = time.UTC
+		env.Fields = []*querypb.Field{field}
+		typ, err := env.TypeOf(expr)
+		require.NoError(t, err)
+
+		env.Row = []sqltypes.Value{value}
+		res, err := env.Evaluate(expr)
+		require.NoError(t, err)
+		require.Equal(t, expected[i], res.Value(), "%s with %v", expression, value)
+		require.Equal(t, typ, res.Value().Type(), "%s with %v", expression, value)
+	}
+}
+
+func jsonValue(s string) sqltypes.Value {
+	return sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(s))
+}
+
+func dateValue(s string) sqltypes.Value {
+	return sqltypes.MakeTrusted(sqltypes.Date, []byte(s))
+}
+
+func datetimeValue(s string) sqltypes.Value {
+	return sqltypes.MakeTrusted(sqltypes.Datetime, []byte(s))
+}
+
+func timeValue(s string) sqltypes.Value {
+	return sqltypes.MakeTrusted(sqltypes.Time, []byte(s))
+}